@@ -0,0 +1,153 @@
+package akamai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/meta"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// accountKeyAttribute is added to every resource and data source of the SDK and framework providers,
+// so that a single provider instance can manage the configuration of multiple accounts
+const accountKeyAttribute = "account_key"
+
+// accountKeyImportSuffix separates the account switch key from the import ID of a resource,
+// e.g. 'prp_1,ctr_1,grp_1?account_key=1-ABCDE:1-FGHIJ'
+const accountKeyImportSuffix = "?account_key="
+
+const (
+	accountKeyDataSourceDescription = "The account switch key of the account to read the data from, defaults to the account configured in the provider"
+	accountKeyResourceDescription   = "The account switch key of the account the resource belongs to, defaults to the account configured in the provider. " +
+		"To import a resource of another account, append '" + accountKeyImportSuffix + "<key>' to the import ID"
+)
+
+// withAccountKey adds the account_key attribute to the given resources and data sources. The operations of
+// a resource with account_key set are performed with the session of that account instead of the provider's one.
+func withAccountKey(resources map[string]*schema.Resource, isDataSource bool) {
+	for name, res := range resources {
+		if _, ok := res.Schema[accountKeyAttribute]; ok {
+			panic(fmt.Sprintf("%s already defines the %s attribute", name, accountKeyAttribute))
+		}
+		res.Schema[accountKeyAttribute] = accountKeySchema(isDataSource)
+
+		res.CreateContext = withAccountMeta(res.CreateContext)
+		res.ReadContext = withAccountMeta(res.ReadContext)
+		res.UpdateContext = withAccountMeta(res.UpdateContext)
+		res.DeleteContext = withAccountMeta(res.DeleteContext)
+		if !isDataSource {
+			res.CustomizeDiff = customizeDiffWithAccountMeta(res.CustomizeDiff)
+			res.Importer = importerWithAccountMeta(res.Importer)
+		}
+	}
+}
+
+func accountKeySchema(isDataSource bool) *schema.Schema {
+	if isDataSource {
+		return &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: accountKeyDataSourceDescription,
+		}
+	}
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		ForceNew: true,
+		// removing account_key from an existing resource keeps the account the resource was created in, while setting it
+		// replaces the resource, as the account of a resource without account_key, e.g. an imported one, is not known
+		DiffSuppressFunc: func(_, _, newValue string, d *schema.ResourceData) bool {
+			return d.Id() != "" && newValue == ""
+		},
+		Description: accountKeyResourceDescription,
+	}
+}
+
+// accountMeta returns the meta of the account the resource belongs to
+func accountMeta(accountKey string, m any) (meta.Meta, error) {
+	if accountKey == "" {
+		return meta.Must(m), nil
+	}
+	return meta.Must(m).ForAccount(accountKey)
+}
+
+func withAccountMeta[F ~func(context.Context, *schema.ResourceData, any) diag.Diagnostics](f F) F {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+		accountKey, _ := d.Get(accountKeyAttribute).(string)
+		if accountKey == "" {
+			return f(ctx, d, m)
+		}
+		am, err := accountMeta(accountKey, m)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		return f(ctx, d, am)
+	}
+}
+
+func customizeDiffWithAccountMeta(f schema.CustomizeDiffFunc) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, m any) error {
+		if f == nil {
+			return nil
+		}
+
+		accountKey, _ := d.Get(accountKeyAttribute).(string)
+		if accountKey == "" {
+			return f(ctx, d, m)
+		}
+		am, err := accountMeta(accountKey, m)
+		if err != nil {
+			return err
+		}
+		return f(ctx, d, am)
+	}
+}
+
+// importerWithAccountMeta imports the resource with the meta of the account given in the import ID after accountKeyImportSuffix
+func importerWithAccountMeta(importer *schema.ResourceImporter) *schema.ResourceImporter {
+	if importer == nil {
+		return nil
+	}
+	importState := importer.StateContext
+	if importState == nil && importer.State != nil {
+		importState = func(_ context.Context, d *schema.ResourceData, m any) ([]*schema.ResourceData, error) {
+			return importer.State(d, m)
+		}
+	}
+	if importState == nil {
+		return importer
+	}
+
+	return &schema.ResourceImporter{
+		StateContext: func(ctx context.Context, d *schema.ResourceData, m any) ([]*schema.ResourceData, error) {
+			id, accountKey, found := strings.Cut(d.Id(), accountKeyImportSuffix)
+			if !found {
+				return importState(ctx, d, m)
+			}
+			if accountKey == "" {
+				return nil, fmt.Errorf("missing account switch key after '%s' in import ID %q", accountKeyImportSuffix, d.Id())
+			}
+
+			am, err := accountMeta(accountKey, m)
+			if err != nil {
+				return nil, err
+			}
+			d.SetId(id)
+			imported, err := importState(ctx, d, am)
+			if err != nil {
+				return nil, err
+			}
+			for _, rd := range imported {
+				if err = rd.Set(accountKeyAttribute, accountKey); err != nil {
+					return nil, err
+				}
+			}
+			return imported, nil
+		},
+	}
+}
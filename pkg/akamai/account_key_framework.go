@@ -0,0 +1,484 @@
+package akamai
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/meta"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+type (
+	// accountKeyResource adds the account_key attribute to a framework resource. The wrapped resource gets
+	// the plan, state and config without the attribute, and is configured with the meta of the account before
+	// every operation of a resource with account_key set.
+	accountKeyResource struct {
+		resource.Resource
+		meta meta.Meta
+	}
+
+	// accountKeyDataSource adds the account_key attribute to a framework data source, like accountKeyResource
+	accountKeyDataSource struct {
+		datasource.DataSource
+		meta meta.Meta
+	}
+
+	// accountKeyPlanModifier replaces the resource when account_key is set or changed, while removing it keeps
+	// the account the resource was created in, as the account of a resource without account_key is not known
+	accountKeyPlanModifier struct{}
+)
+
+var (
+	_ resource.ResourceWithConfigure        = &accountKeyResource{}
+	_ resource.ResourceWithImportState      = &accountKeyResource{}
+	_ resource.ResourceWithModifyPlan       = &accountKeyResource{}
+	_ resource.ResourceWithValidateConfig   = &accountKeyResource{}
+	_ resource.ResourceWithConfigValidators = &accountKeyResource{}
+
+	_ datasource.DataSourceWithConfigure        = &accountKeyDataSource{}
+	_ datasource.DataSourceWithValidateConfig   = &accountKeyDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &accountKeyDataSource{}
+)
+
+// withFrameworkResourcesAccountKey adds the account_key attribute to the given framework resources,
+// like withAccountKey does for the resources of the SDK provider
+func withFrameworkResourcesAccountKey(resources []func() resource.Resource) []func() resource.Resource {
+	wrapped := make([]func() resource.Resource, 0, len(resources))
+	for _, newResource := range resources {
+		wrapped = append(wrapped, func() resource.Resource {
+			res := newResource()
+			switch res.(type) {
+			case resource.ResourceWithUpgradeState, resource.ResourceWithMoveState:
+				panic(fmt.Sprintf("%T moves or upgrades its state, which is not supported with the %s attribute", res, accountKeyAttribute))
+			}
+			return &accountKeyResource{Resource: res}
+		})
+	}
+	return wrapped
+}
+
+// withFrameworkDataSourcesAccountKey adds the account_key attribute to the given framework data sources
+func withFrameworkDataSourcesAccountKey(dataSources []func() datasource.DataSource) []func() datasource.DataSource {
+	wrapped := make([]func() datasource.DataSource, 0, len(dataSources))
+	for _, newDataSource := range dataSources {
+		wrapped = append(wrapped, func() datasource.DataSource {
+			return &accountKeyDataSource{DataSource: newDataSource()}
+		})
+	}
+	return wrapped
+}
+
+// Schema implements resource.Resource
+func (r *accountKeyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	r.Resource.Schema(ctx, req, resp)
+	if _, ok := resp.Schema.Attributes[accountKeyAttribute]; ok {
+		panic(fmt.Sprintf("%T already defines the %s attribute", r.Resource, accountKeyAttribute))
+	}
+	attributes := make(map[string]resourceschema.Attribute, len(resp.Schema.Attributes)+1)
+	maps.Copy(attributes, resp.Schema.Attributes)
+	resp.Schema.Attributes = attributes
+	resp.Schema.Attributes[accountKeyAttribute] = resourceschema.StringAttribute{
+		Optional:      true,
+		Computed:      true,
+		PlanModifiers: []planmodifier.String{accountKeyPlanModifier{}},
+		Description:   accountKeyResourceDescription,
+	}
+}
+
+// schemas returns the schemas of the wrapped resource and of the resource with the account_key attribute
+func (r *accountKeyResource) schemas(ctx context.Context) accountKeySchemas {
+	var resp resource.SchemaResponse
+	r.Resource.Schema(ctx, resource.SchemaRequest{}, &resp)
+	return newAccountKeySchemas(ctx, tfsdk.State{Schema: resp.Schema})
+}
+
+// Configure implements resource.ResourceWithConfigure
+func (r *accountKeyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if m, ok := req.ProviderData.(meta.Meta); ok {
+		r.meta = m
+	}
+	if res, ok := r.Resource.(resource.ResourceWithConfigure); ok {
+		res.Configure(ctx, req, resp)
+	}
+}
+
+// configureAccount configures the wrapped resource with the meta of the account, if set
+func (r *accountKeyResource) configureAccount(ctx context.Context, accountKey tftypes.Value, diags *diag.Diagnostics) {
+	am, ok := accountMetaFor(r.meta, accountKey, diags)
+	if !ok {
+		return
+	}
+	if res, ok := r.Resource.(resource.ResourceWithConfigure); ok {
+		var resp resource.ConfigureResponse
+		res.Configure(ctx, resource.ConfigureRequest{ProviderData: am}, &resp)
+		diags.Append(resp.Diagnostics...)
+	}
+}
+
+// Create implements resource.Resource
+func (r *accountKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	s := r.schemas(ctx)
+	config, _ := s.split(req.Config.Raw, &resp.Diagnostics)
+	plan, accountKey := s.split(req.Plan.Raw, &resp.Diagnostics)
+	state, _ := s.split(resp.State.Raw, &resp.Diagnostics)
+	if r.configureAccount(ctx, accountKey, &resp.Diagnostics); resp.Diagnostics.HasError() {
+		return
+	}
+
+	innerResp := resource.CreateResponse{State: s.state(state), Private: resp.Private}
+	r.Resource.Create(ctx, resource.CreateRequest{Config: s.config(config), Plan: s.plan(plan), ProviderMeta: req.ProviderMeta}, &innerResp)
+	resp.Diagnostics.Append(innerResp.Diagnostics...)
+	resp.State.Raw = s.join(innerResp.State.Raw, accountKey, &resp.Diagnostics)
+	resp.Private = innerResp.Private
+}
+
+// Read implements resource.Resource
+func (r *accountKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	s := r.schemas(ctx)
+	state, accountKey := s.split(req.State.Raw, &resp.Diagnostics)
+	if r.configureAccount(ctx, accountKey, &resp.Diagnostics); resp.Diagnostics.HasError() {
+		return
+	}
+
+	innerResp := resource.ReadResponse{State: s.state(state), Private: resp.Private}
+	r.Resource.Read(ctx, resource.ReadRequest{State: s.state(state), Private: req.Private, ProviderMeta: req.ProviderMeta}, &innerResp)
+	resp.Diagnostics.Append(innerResp.Diagnostics...)
+	resp.State.Raw = s.join(innerResp.State.Raw, accountKey, &resp.Diagnostics)
+	resp.Private = innerResp.Private
+}
+
+// Update implements resource.Resource
+func (r *accountKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	s := r.schemas(ctx)
+	config, _ := s.split(req.Config.Raw, &resp.Diagnostics)
+	plan, accountKey := s.split(req.Plan.Raw, &resp.Diagnostics)
+	state, _ := s.split(req.State.Raw, &resp.Diagnostics)
+	newState, _ := s.split(resp.State.Raw, &resp.Diagnostics)
+	if r.configureAccount(ctx, accountKey, &resp.Diagnostics); resp.Diagnostics.HasError() {
+		return
+	}
+
+	innerResp := resource.UpdateResponse{State: s.state(newState), Private: resp.Private}
+	r.Resource.Update(ctx, resource.UpdateRequest{
+		Config:       s.config(config),
+		Plan:         s.plan(plan),
+		State:        s.state(state),
+		Private:      req.Private,
+		ProviderMeta: req.ProviderMeta,
+	}, &innerResp)
+	resp.Diagnostics.Append(innerResp.Diagnostics...)
+	resp.State.Raw = s.join(innerResp.State.Raw, accountKey, &resp.Diagnostics)
+	resp.Private = innerResp.Private
+}
+
+// Delete implements resource.Resource
+func (r *accountKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	s := r.schemas(ctx)
+	state, accountKey := s.split(req.State.Raw, &resp.Diagnostics)
+	newState, _ := s.split(resp.State.Raw, &resp.Diagnostics)
+	if r.configureAccount(ctx, accountKey, &resp.Diagnostics); resp.Diagnostics.HasError() {
+		return
+	}
+
+	innerResp := resource.DeleteResponse{State: s.state(newState), Private: resp.Private}
+	r.Resource.Delete(ctx, resource.DeleteRequest{State: s.state(state), Private: req.Private, ProviderMeta: req.ProviderMeta}, &innerResp)
+	resp.Diagnostics.Append(innerResp.Diagnostics...)
+	resp.State.Raw = s.join(innerResp.State.Raw, accountKey, &resp.Diagnostics)
+	resp.Private = innerResp.Private
+}
+
+// ModifyPlan implements resource.ResourceWithModifyPlan
+func (r *accountKeyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	res, ok := r.Resource.(resource.ResourceWithModifyPlan)
+	if !ok {
+		return
+	}
+	s := r.schemas(ctx)
+	config, _ := s.split(req.Config.Raw, &resp.Diagnostics)
+	state, stateAccountKey := s.split(req.State.Raw, &resp.Diagnostics)
+	plan, accountKey := s.split(resp.Plan.Raw, &resp.Diagnostics)
+	if resp.Plan.Raw.IsNull() {
+		// the resource is destroyed
+		accountKey = stateAccountKey
+	}
+	if r.configureAccount(ctx, accountKey, &resp.Diagnostics); resp.Diagnostics.HasError() {
+		return
+	}
+
+	innerResp := resource.ModifyPlanResponse{Plan: s.plan(plan), RequiresReplace: resp.RequiresReplace, Private: resp.Private}
+	res.ModifyPlan(ctx, resource.ModifyPlanRequest{
+		Config:       s.config(config),
+		Plan:         s.plan(plan),
+		State:        s.state(state),
+		Private:      req.Private,
+		ProviderMeta: req.ProviderMeta,
+	}, &innerResp)
+	resp.Diagnostics.Append(innerResp.Diagnostics...)
+	resp.Plan.Raw = s.join(innerResp.Plan.Raw, accountKey, &resp.Diagnostics)
+	resp.RequiresReplace = innerResp.RequiresReplace
+	resp.Private = innerResp.Private
+}
+
+// ImportState implements resource.ResourceWithImportState. Resources of another account are imported
+// with the account switch key appended to the import ID after accountKeyImportSuffix.
+func (r *accountKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	res, ok := r.Resource.(resource.ResourceWithImportState)
+	if !ok {
+		resp.Diagnostics.AddError("Resource Import Not Implemented",
+			"This resource does not support import. Please contact the provider developer for additional information.")
+		return
+	}
+
+	id, key, found := strings.Cut(req.ID, accountKeyImportSuffix)
+	if found && key == "" {
+		resp.Diagnostics.AddError("Invalid Import ID",
+			fmt.Sprintf("missing account switch key after '%s' in import ID %q", accountKeyImportSuffix, req.ID))
+		return
+	}
+	accountKey := tftypes.NewValue(tftypes.String, nil)
+	if found {
+		accountKey = tftypes.NewValue(tftypes.String, key)
+	}
+
+	s := r.schemas(ctx)
+	state, _ := s.split(resp.State.Raw, &resp.Diagnostics)
+	if r.configureAccount(ctx, accountKey, &resp.Diagnostics); resp.Diagnostics.HasError() {
+		return
+	}
+
+	innerResp := resource.ImportStateResponse{State: s.state(state), Private: resp.Private}
+	res.ImportState(ctx, resource.ImportStateRequest{ID: id}, &innerResp)
+	resp.Diagnostics.Append(innerResp.Diagnostics...)
+	resp.State.Raw = s.join(innerResp.State.Raw, accountKey, &resp.Diagnostics)
+	resp.Private = innerResp.Private
+}
+
+// ValidateConfig implements resource.ResourceWithValidateConfig
+func (r *accountKeyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	res, ok := r.Resource.(resource.ResourceWithValidateConfig)
+	if !ok {
+		return
+	}
+	s := r.schemas(ctx)
+	config, _ := s.split(req.Config.Raw, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	res.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: s.config(config)}, resp)
+}
+
+// ConfigValidators implements resource.ResourceWithConfigValidators
+func (r *accountKeyResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	if res, ok := r.Resource.(resource.ResourceWithConfigValidators); ok {
+		return res.ConfigValidators(ctx)
+	}
+	return nil
+}
+
+// Schema implements datasource.DataSource
+func (d *accountKeyDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	d.DataSource.Schema(ctx, req, resp)
+	if _, ok := resp.Schema.Attributes[accountKeyAttribute]; ok {
+		panic(fmt.Sprintf("%T already defines the %s attribute", d.DataSource, accountKeyAttribute))
+	}
+	attributes := make(map[string]datasourceschema.Attribute, len(resp.Schema.Attributes)+1)
+	maps.Copy(attributes, resp.Schema.Attributes)
+	resp.Schema.Attributes = attributes
+	resp.Schema.Attributes[accountKeyAttribute] = datasourceschema.StringAttribute{
+		Optional:    true,
+		Description: accountKeyDataSourceDescription,
+	}
+}
+
+// schemas returns the schemas of the wrapped data source and of the data source with the account_key attribute
+func (d *accountKeyDataSource) schemas(ctx context.Context) accountKeySchemas {
+	var resp datasource.SchemaResponse
+	d.DataSource.Schema(ctx, datasource.SchemaRequest{}, &resp)
+	return newAccountKeySchemas(ctx, tfsdk.State{Schema: resp.Schema})
+}
+
+// Configure implements datasource.DataSourceWithConfigure
+func (d *accountKeyDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if m, ok := req.ProviderData.(meta.Meta); ok {
+		d.meta = m
+	}
+	if ds, ok := d.DataSource.(datasource.DataSourceWithConfigure); ok {
+		ds.Configure(ctx, req, resp)
+	}
+}
+
+// configureAccount configures the wrapped data source with the meta of the account, if set
+func (d *accountKeyDataSource) configureAccount(ctx context.Context, accountKey tftypes.Value, diags *diag.Diagnostics) {
+	am, ok := accountMetaFor(d.meta, accountKey, diags)
+	if !ok {
+		return
+	}
+	if ds, ok := d.DataSource.(datasource.DataSourceWithConfigure); ok {
+		var resp datasource.ConfigureResponse
+		ds.Configure(ctx, datasource.ConfigureRequest{ProviderData: am}, &resp)
+		diags.Append(resp.Diagnostics...)
+	}
+}
+
+// Read implements datasource.DataSource
+func (d *accountKeyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	s := d.schemas(ctx)
+	config, accountKey := s.split(req.Config.Raw, &resp.Diagnostics)
+	state, _ := s.split(resp.State.Raw, &resp.Diagnostics)
+	if d.configureAccount(ctx, accountKey, &resp.Diagnostics); resp.Diagnostics.HasError() {
+		return
+	}
+
+	innerResp := datasource.ReadResponse{State: s.state(state)}
+	d.DataSource.Read(ctx, datasource.ReadRequest{Config: s.config(config), ProviderMeta: req.ProviderMeta}, &innerResp)
+	resp.Diagnostics.Append(innerResp.Diagnostics...)
+	resp.State.Raw = s.join(innerResp.State.Raw, accountKey, &resp.Diagnostics)
+}
+
+// ValidateConfig implements datasource.DataSourceWithValidateConfig
+func (d *accountKeyDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	ds, ok := d.DataSource.(datasource.DataSourceWithValidateConfig)
+	if !ok {
+		return
+	}
+	s := d.schemas(ctx)
+	config, _ := s.split(req.Config.Raw, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ds.ValidateConfig(ctx, datasource.ValidateConfigRequest{Config: s.config(config)}, resp)
+}
+
+// ConfigValidators implements datasource.DataSourceWithConfigValidators
+func (d *accountKeyDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	if ds, ok := d.DataSource.(datasource.DataSourceWithConfigValidators); ok {
+		return ds.ConfigValidators(ctx)
+	}
+	return nil
+}
+
+// accountMetaFor returns the meta of the account given by the account_key value, or false if the provider's meta is to be used
+func accountMetaFor(providerMeta meta.Meta, accountKey tftypes.Value, diags *diag.Diagnostics) (meta.Meta, bool) {
+	var key string
+	if accountKey.IsKnown() && !accountKey.IsNull() {
+		if err := accountKey.As(&key); err != nil {
+			diags.AddError("Invalid Account Key", err.Error())
+			return nil, false
+		}
+	}
+	if key == "" || providerMeta == nil {
+		return nil, false
+	}
+	am, err := providerMeta.ForAccount(key)
+	if err != nil {
+		diags.AddError("Account Session Failed", err.Error())
+		return nil, false
+	}
+	return am, true
+}
+
+// PlanModifyString implements planmodifier.String
+func (accountKeyPlanModifier) PlanModifyString(_ context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.State.Raw.IsNull() {
+		if req.ConfigValue.IsNull() {
+			resp.PlanValue = req.ConfigValue
+		}
+		return
+	}
+	if req.ConfigValue.IsNull() {
+		resp.PlanValue = req.StateValue
+		return
+	}
+	resp.RequiresReplace = !req.PlanValue.Equal(req.StateValue)
+}
+
+// Description implements planmodifier.String
+func (accountKeyPlanModifier) Description(_ context.Context) string {
+	return "Setting or changing the account switch key replaces the resource, while removing it keeps the account of the resource."
+}
+
+// MarkdownDescription implements planmodifier.String
+func (m accountKeyPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+// accountKeySchemas converts the values between the schema of a wrapped resource or data source
+// and the schema with the account_key attribute
+type accountKeySchemas struct {
+	// inner holds the schema of the wrapped resource or data source
+	inner     tfsdk.State
+	innerType tftypes.Object
+	outerType tftypes.Object
+}
+
+func newAccountKeySchemas(ctx context.Context, inner tfsdk.State) accountKeySchemas {
+	innerType := inner.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	attributeTypes := maps.Clone(innerType.AttributeTypes)
+	attributeTypes[accountKeyAttribute] = tftypes.String
+	return accountKeySchemas{
+		inner:     inner,
+		innerType: innerType,
+		outerType: tftypes.Object{AttributeTypes: attributeTypes},
+	}
+}
+
+func (s accountKeySchemas) state(raw tftypes.Value) tfsdk.State {
+	return tfsdk.State{Schema: s.inner.Schema, Raw: raw}
+}
+
+func (s accountKeySchemas) plan(raw tftypes.Value) tfsdk.Plan {
+	return tfsdk.Plan{Schema: s.inner.Schema, Raw: raw}
+}
+
+func (s accountKeySchemas) config(raw tftypes.Value) tfsdk.Config {
+	return tfsdk.Config{Schema: s.inner.Schema, Raw: raw}
+}
+
+// split returns the value without the account_key attribute, and the value of the attribute
+func (s accountKeySchemas) split(raw tftypes.Value, diags *diag.Diagnostics) (tftypes.Value, tftypes.Value) {
+	accountKey := tftypes.NewValue(tftypes.String, nil)
+	if raw.IsNull() {
+		return tftypes.NewValue(s.innerType, nil), accountKey
+	}
+	if !raw.IsKnown() {
+		return tftypes.NewValue(s.innerType, tftypes.UnknownValue), tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+	}
+
+	var attributes map[string]tftypes.Value
+	if err := raw.As(&attributes); err != nil {
+		diags.AddError("Invalid Value", err.Error())
+		return tftypes.NewValue(s.innerType, nil), accountKey
+	}
+	if value, ok := attributes[accountKeyAttribute]; ok {
+		accountKey = value
+		delete(attributes, accountKeyAttribute)
+	}
+	return tftypes.NewValue(s.innerType, attributes), accountKey
+}
+
+// join returns the value of the wrapped resource or data source with the account_key attribute
+func (s accountKeySchemas) join(raw, accountKey tftypes.Value, diags *diag.Diagnostics) tftypes.Value {
+	if raw.IsNull() {
+		return tftypes.NewValue(s.outerType, nil)
+	}
+	if !raw.IsKnown() {
+		return tftypes.NewValue(s.outerType, tftypes.UnknownValue)
+	}
+
+	var attributes map[string]tftypes.Value
+	if err := raw.As(&attributes); err != nil {
+		diags.AddError("Invalid Value", err.Error())
+		return tftypes.NewValue(s.outerType, nil)
+	}
+	attributes[accountKeyAttribute] = accountKey
+	return tftypes.NewValue(s.outerType, attributes)
+}
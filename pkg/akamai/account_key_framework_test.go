@@ -0,0 +1,290 @@
+package akamai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/meta"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithFrameworkAccountKey(t *testing.T) {
+	ctx := context.Background()
+	var sessions []string
+	providerMeta, err := meta.New(session.Must(session.New()), hclog.NewNullLogger(), "opID",
+		meta.WithAccountKey("1-ABC"),
+		meta.WithSessionFactory(func(accountKey string) (session.Session, error) {
+			sessions = append(sessions, accountKey)
+			return session.New()
+		}))
+	require.NoError(t, err)
+
+	objectType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"id":                tftypes.String,
+		accountKeyAttribute: tftypes.String,
+	}}
+	object := func(id, accountKey any) tftypes.Value {
+		return tftypes.NewValue(objectType, map[string]tftypes.Value{
+			"id":                tftypes.NewValue(tftypes.String, id),
+			accountKeyAttribute: tftypes.NewValue(tftypes.String, accountKey),
+		})
+	}
+
+	newResource := func(accounts *[]string) (resource.ResourceWithImportState, resourceschema.Schema) {
+		res := withFrameworkResourcesAccountKey([]func() resource.Resource{
+			func() resource.Resource { return &accountTestResource{accounts: accounts} },
+		})[0]()
+		var schemaResp resource.SchemaResponse
+		res.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+		var configureResp resource.ConfigureResponse
+		res.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{ProviderData: providerMeta}, &configureResp)
+		require.False(t, configureResp.Diagnostics.HasError())
+		return res.(resource.ResourceWithImportState), schemaResp.Schema
+	}
+
+	t.Run("operations use the meta of the account", func(t *testing.T) {
+		var accounts []string
+		sessions = nil
+		res, resSchema := newResource(&accounts)
+		require.Contains(t, resSchema.Attributes, accountKeyAttribute)
+
+		for _, accountKey := range []any{nil, "1-XYZ", "1-XYZ"} {
+			resp := resource.ReadResponse{State: tfsdk.State{Schema: resSchema, Raw: object("1", accountKey)}}
+			res.Read(ctx, resource.ReadRequest{State: tfsdk.State{Schema: resSchema, Raw: object("1", accountKey)}}, &resp)
+			require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+			assert.Equal(t, object("1", accountKey), resp.State.Raw)
+		}
+		assert.Equal(t, []string{"1-ABC", "1-XYZ", "1-XYZ"}, accounts)
+		assert.Equal(t, []string{"1-XYZ"}, sessions)
+	})
+
+	t.Run("import", func(t *testing.T) {
+		tests := map[string]struct {
+			importID           string
+			expectedState      tftypes.Value
+			expectedAccountKey string
+			withError          string
+		}{
+			"without account_key": {importID: "prp_1", expectedState: object("prp_1", nil), expectedAccountKey: "1-ABC"},
+			"with account_key": {importID: "prp_1?account_key=1-XYZ:1-DEF", expectedState: object("prp_1", "1-XYZ:1-DEF"),
+				expectedAccountKey: "1-XYZ:1-DEF"},
+			"empty account_key": {importID: "prp_1?account_key=",
+				withError: `missing account switch key after '?account_key=' in import ID "prp_1?account_key="`},
+		}
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				var accounts []string
+				res, resSchema := newResource(&accounts)
+				resp := resource.ImportStateResponse{State: tfsdk.State{Schema: resSchema, Raw: tftypes.NewValue(objectType, nil)}}
+				res.ImportState(ctx, resource.ImportStateRequest{ID: test.importID}, &resp)
+				if test.withError != "" {
+					require.True(t, resp.Diagnostics.HasError())
+					assert.Equal(t, test.withError, resp.Diagnostics[0].Detail())
+					return
+				}
+				require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+				assert.Equal(t, test.expectedState, resp.State.Raw)
+				assert.Equal(t, []string{test.expectedAccountKey}, accounts)
+			})
+		}
+	})
+
+	t.Run("setting or changing the account of an existing resource replaces it", func(t *testing.T) {
+		tests := map[string]struct {
+			state           tftypes.Value
+			config          types.String
+			expectedPlan    types.String
+			expectedReplace bool
+		}{
+			"created without account_key": {state: tftypes.NewValue(objectType, nil), config: types.StringNull(),
+				expectedPlan: types.StringNull()},
+			"created with account_key": {state: tftypes.NewValue(objectType, nil), config: types.StringValue("1-XYZ"),
+				expectedPlan: types.StringValue("1-XYZ")},
+			"account_key set": {state: object("1", nil), config: types.StringValue("1-XYZ"),
+				expectedPlan: types.StringValue("1-XYZ"), expectedReplace: true},
+			"account_key removed": {state: object("1", "1-XYZ"), config: types.StringNull(),
+				expectedPlan: types.StringValue("1-XYZ")},
+			"account_key changed": {state: object("1", "1-XYZ"), config: types.StringValue("1-DEF"),
+				expectedPlan: types.StringValue("1-DEF"), expectedReplace: true},
+			"account_key kept": {state: object("1", "1-XYZ"), config: types.StringValue("1-XYZ"),
+				expectedPlan: types.StringValue("1-XYZ")},
+		}
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				var stateValue types.String
+				if test.state.IsNull() {
+					stateValue = types.StringNull()
+				} else {
+					var attributes map[string]tftypes.Value
+					require.NoError(t, test.state.As(&attributes))
+					var key *string
+					require.NoError(t, attributes[accountKeyAttribute].As(&key))
+					stateValue = types.StringPointerValue(key)
+				}
+				planValue := test.config
+				if planValue.IsNull() && !test.state.IsNull() {
+					// computed attributes missing in the configuration are planned as unknown
+					planValue = types.StringUnknown()
+				}
+
+				resp := planmodifier.StringResponse{PlanValue: planValue}
+				accountKeyPlanModifier{}.PlanModifyString(ctx, planmodifier.StringRequest{
+					State:       tfsdk.State{Raw: test.state},
+					StateValue:  stateValue,
+					ConfigValue: test.config,
+					PlanValue:   planValue,
+				}, &resp)
+				assert.Equal(t, test.expectedPlan, resp.PlanValue)
+				assert.Equal(t, test.expectedReplace, resp.RequiresReplace)
+			})
+		}
+	})
+
+	t.Run("data sources", func(t *testing.T) {
+		var accounts []string
+		ds := withFrameworkDataSourcesAccountKey([]func() datasource.DataSource{
+			func() datasource.DataSource { return &accountTestDataSource{accounts: &accounts} },
+		})[0]()
+		var schemaResp datasource.SchemaResponse
+		ds.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+		dsSchema := schemaResp.Schema
+		require.Contains(t, dsSchema.Attributes, accountKeyAttribute)
+		var configureResp datasource.ConfigureResponse
+		ds.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: providerMeta}, &configureResp)
+
+		resp := datasource.ReadResponse{State: tfsdk.State{Schema: dsSchema, Raw: object(nil, "1-XYZ")}}
+		ds.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: dsSchema, Raw: object(nil, "1-XYZ")}}, &resp)
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+		assert.Equal(t, object("1-XYZ", "1-XYZ"), resp.State.Raw)
+		assert.Equal(t, []string{"1-XYZ"}, accounts)
+	})
+
+	t.Run("duplicated attribute", func(t *testing.T) {
+		res := withFrameworkResourcesAccountKey([]func() resource.Resource{
+			func() resource.Resource { return &accountTestResource{withAccountKey: true} },
+		})[0]()
+		assert.PanicsWithValue(t, "*akamai.accountTestResource already defines the account_key attribute", func() {
+			res.Schema(ctx, resource.SchemaRequest{}, &resource.SchemaResponse{})
+		})
+	})
+}
+
+// accountTestResource records the account of the meta its operations are performed with
+type accountTestResource struct {
+	accounts       *[]string
+	meta           meta.Meta
+	withAccountKey bool
+}
+
+var (
+	_ resource.ResourceWithConfigure   = &accountTestResource{}
+	_ resource.ResourceWithImportState = &accountTestResource{}
+)
+
+type accountTestModel struct {
+	ID types.String `tfsdk:"id"`
+}
+
+// Metadata implements resource.Resource.
+func (r *accountTestResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "akamai_account_test"
+}
+
+// Schema implements resource.Resource.
+func (r *accountTestResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceschema.Schema{
+		Attributes: map[string]resourceschema.Attribute{
+			"id": resourceschema.StringAttribute{Computed: true},
+		},
+	}
+	if r.withAccountKey {
+		resp.Schema.Attributes[accountKeyAttribute] = resourceschema.StringAttribute{Optional: true}
+	}
+}
+
+// Configure implements resource.ResourceWithConfigure.
+func (r *accountTestResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData != nil {
+		r.meta = meta.Must(req.ProviderData)
+	}
+}
+
+// Create implements resource.Resource.
+func (r *accountTestResource) Create(_ context.Context, _ resource.CreateRequest, _ *resource.CreateResponse) {
+}
+
+// Read implements resource.Resource.
+func (r *accountTestResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data accountTestModel
+	if resp.Diagnostics.Append(req.State.Get(ctx, &data)...); resp.Diagnostics.HasError() {
+		return
+	}
+	*r.accounts = append(*r.accounts, r.meta.AccountKey())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update implements resource.Resource.
+func (r *accountTestResource) Update(_ context.Context, _ resource.UpdateRequest, _ *resource.UpdateResponse) {
+}
+
+// Delete implements resource.Resource.
+func (r *accountTestResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
+
+// ImportState implements resource.ResourceWithImportState.
+func (r *accountTestResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	*r.accounts = append(*r.accounts, r.meta.AccountKey())
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// accountTestDataSource returns the account of the meta it is read with as its id
+type accountTestDataSource struct {
+	accounts *[]string
+	meta     meta.Meta
+}
+
+var _ datasource.DataSourceWithConfigure = &accountTestDataSource{}
+
+// Metadata implements datasource.DataSource.
+func (d *accountTestDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "akamai_account_test"
+}
+
+// Schema implements datasource.DataSource.
+func (d *accountTestDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = datasourceschema.Schema{
+		Attributes: map[string]datasourceschema.Attribute{
+			"id": datasourceschema.StringAttribute{Computed: true},
+		},
+	}
+}
+
+// Configure implements datasource.DataSourceWithConfigure.
+func (d *accountTestDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData != nil {
+		d.meta = meta.Must(req.ProviderData)
+	}
+}
+
+// Read implements datasource.DataSource.
+func (d *accountTestDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data accountTestModel
+	if resp.Diagnostics.Append(req.Config.Get(ctx, &data)...); resp.Diagnostics.HasError() {
+		return
+	}
+	*d.accounts = append(*d.accounts, d.meta.AccountKey())
+	data.ID = types.StringValue(d.meta.AccountKey())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,149 @@
+package akamai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/meta"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithAccountKey(t *testing.T) {
+	var readAccounts []string
+	read := func(_ context.Context, _ *schema.ResourceData, m any) diag.Diagnostics {
+		readAccounts = append(readAccounts, meta.Must(m).AccountKey())
+		return nil
+	}
+	newResources := func() map[string]*schema.Resource {
+		return map[string]*schema.Resource{
+			"akamai_test": {
+				Schema: map[string]*schema.Schema{
+					"name": {Type: schema.TypeString, Required: true, ForceNew: true},
+				},
+				CreateContext: read,
+				ReadContext:   read,
+				DeleteContext: read,
+			},
+		}
+	}
+
+	var sessions []string
+	providerMeta, err := meta.New(session.Must(session.New()), hclog.NewNullLogger(), "opID",
+		meta.WithAccountKey("1-ABC"),
+		meta.WithSessionFactory(func(accountKey string) (session.Session, error) {
+			sessions = append(sessions, accountKey)
+			return session.New()
+		}))
+	require.NoError(t, err)
+
+	t.Run("operations use the meta of the account", func(t *testing.T) {
+		readAccounts, sessions = nil, nil
+		resources := newResources()
+		withAccountKey(resources, false)
+		res := resources["akamai_test"]
+		require.NoError(t, res.InternalValidate(nil, true))
+		assert.True(t, res.Schema[accountKeyAttribute].ForceNew)
+
+		for _, accountKey := range []string{"", "1-XYZ", "1-XYZ"} {
+			d := res.TestResourceData()
+			require.NoError(t, d.Set(accountKeyAttribute, accountKey))
+			assert.False(t, res.ReadContext(context.Background(), d, providerMeta).HasError())
+		}
+		assert.Equal(t, []string{"1-ABC", "1-XYZ", "1-XYZ"}, readAccounts)
+		assert.Equal(t, []string{"1-XYZ"}, sessions)
+	})
+
+	t.Run("data sources", func(t *testing.T) {
+		dataSources := newResources()
+		dataSources["akamai_test"].Schema["name"].ForceNew = false
+		dataSources["akamai_test"].CreateContext, dataSources["akamai_test"].DeleteContext = nil, nil
+		withAccountKey(dataSources, true)
+		res := dataSources["akamai_test"]
+		require.NoError(t, res.InternalValidate(nil, false))
+		assert.False(t, res.Schema[accountKeyAttribute].ForceNew)
+		assert.Nil(t, res.CustomizeDiff)
+	})
+
+	t.Run("setting or changing the account of an existing resource replaces it", func(t *testing.T) {
+		resources := newResources()
+		withAccountKey(resources, false)
+		res := resources["akamai_test"]
+
+		tests := map[string]struct {
+			stateAccountKey  string
+			configAccountKey string
+			expectedReplace  bool
+		}{
+			"account_key set":     {stateAccountKey: "", configAccountKey: "1-XYZ", expectedReplace: true},
+			"account_key removed": {stateAccountKey: "1-XYZ", configAccountKey: "", expectedReplace: false},
+			"account_key changed": {stateAccountKey: "1-XYZ", configAccountKey: "1-DEF", expectedReplace: true},
+			"account_key kept":    {stateAccountKey: "1-XYZ", configAccountKey: "1-XYZ", expectedReplace: false},
+		}
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				state := &terraform.InstanceState{ID: "1", Attributes: map[string]string{"id": "1", "name": "test", accountKeyAttribute: test.stateAccountKey}}
+				config := terraform.NewResourceConfigRaw(map[string]any{"name": "test", accountKeyAttribute: test.configAccountKey})
+				diff, err := res.SimpleDiff(context.Background(), state, config, providerMeta)
+				require.NoError(t, err)
+				assert.Equal(t, test.expectedReplace, diff.RequiresNew())
+			})
+		}
+	})
+
+	t.Run("import", func(t *testing.T) {
+		var importedAccounts []string
+		resources := newResources()
+		resources["akamai_test"].Importer = &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, m any) ([]*schema.ResourceData, error) {
+				importedAccounts = append(importedAccounts, meta.Must(m).AccountKey())
+				return schema.ImportStatePassthroughContext(ctx, d, m)
+			},
+		}
+		withAccountKey(resources, false)
+		res := resources["akamai_test"]
+
+		tests := map[string]struct {
+			importID                string
+			expectedID              string
+			expectedAccountKey      string
+			expectedStateAccountKey string
+			withError               string
+		}{
+			"without account_key": {importID: "prp_1,ctr_1", expectedID: "prp_1,ctr_1", expectedAccountKey: "1-ABC"},
+			"with account_key": {importID: "prp_1,ctr_1?account_key=1-XYZ:1-DEF", expectedID: "prp_1,ctr_1",
+				expectedAccountKey: "1-XYZ:1-DEF", expectedStateAccountKey: "1-XYZ:1-DEF"},
+			"empty account_key": {importID: "prp_1,ctr_1?account_key=", withError: `missing account switch key after '?account_key=' in import ID "prp_1,ctr_1?account_key="`},
+		}
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				importedAccounts = nil
+				d := res.TestResourceData()
+				d.SetId(test.importID)
+				imported, err := res.Importer.StateContext(context.Background(), d, providerMeta)
+				if test.withError != "" {
+					assert.EqualError(t, err, test.withError)
+					return
+				}
+				require.NoError(t, err)
+				require.Len(t, imported, 1)
+				assert.Equal(t, test.expectedID, imported[0].Id())
+				assert.Equal(t, []string{test.expectedAccountKey}, importedAccounts)
+				assert.Equal(t, test.expectedStateAccountKey, imported[0].Get(accountKeyAttribute))
+			})
+		}
+	})
+
+	t.Run("duplicated attribute", func(t *testing.T) {
+		resources := newResources()
+		resources["akamai_test"].Schema[accountKeyAttribute] = &schema.Schema{Type: schema.TypeString, Optional: true}
+		assert.PanicsWithValue(t, "akamai_test already defines the account_key attribute", func() {
+			withAccountKey(resources, false)
+		})
+	})
+}
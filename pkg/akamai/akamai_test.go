@@ -9,6 +9,8 @@ import (
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/subprovider"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	dataschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -59,6 +61,16 @@ func (dummy) FrameworkResources() []func() resource.Resource {
 	return nil
 }
 
+// EphemeralResources implements subprovider.Subprovider.
+func (dummy) EphemeralResources() []func() ephemeral.EphemeralResource {
+	return nil
+}
+
+// Functions implements subprovider.Subprovider.
+func (dummy) Functions() []func() function.Function {
+	return nil
+}
+
 type dummyDataSource struct{}
 
 type dummyDataSourceModel struct {
@@ -0,0 +1,167 @@
+package akamai
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/log"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// auditedMethods are the methods of API calls changing Akamai configurations
+var auditedMethods = []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+// auditTransport is a http.RoundTripper which writes every API call changing Akamai configurations into the audit log
+type auditTransport struct {
+	base        http.RoundTripper
+	auditLog    *log.AuditLog
+	operationID string
+	accountKey  string
+	logger      *log.Logger
+}
+
+// newAuditTransport returns the transport writing into the audit log at the given path, or nil if path is empty
+func newAuditTransport(path, operationID, accountKey string, logger *log.Logger) (*auditTransport, error) {
+	auditLog, err := log.OpenAuditLog(path)
+	if err != nil || auditLog == nil {
+		return nil, err
+	}
+	return &auditTransport{
+		auditLog:    auditLog,
+		operationID: operationID,
+		accountKey:  accountKey,
+		logger:      logger,
+	}, nil
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *auditTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if !slices.Contains(auditedMethods, req.Method) {
+		return resp, err
+	}
+
+	entry := log.AuditEntry{
+		Time:         time.Now().UTC(),
+		OperationID:  t.operationID,
+		ResourceType: log.AuditResourceType(req.Context()),
+		AccountKey:   t.accountKey,
+		Method:       req.Method,
+		Path:         req.URL.Path,
+		AssetIDs:     assetIDs(req.URL),
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	} else {
+		entry.Status = resp.StatusCode
+		if location, parseErr := resp.Location(); parseErr == nil {
+			for name, id := range assetIDs(location) {
+				entry.AssetIDs[name] = id
+			}
+		}
+	}
+	// the API call is already made, so failing to audit it must not fail the operation
+	if auditErr := t.auditLog.Write(entry); auditErr != nil {
+		t.logger.Error("Could not write audit log", "error", auditErr)
+	}
+	return resp, err
+}
+
+// assetIDs returns the IDs of assets identified in the URL: the IDs following the collection names in the path,
+// e.g. {"properties": "prp_1", "versions": "2"} for /papi/v1/properties/prp_1/versions/2,
+// and the query parameters with names ending with "Id", e.g. contractId.
+func assetIDs(u *url.URL) map[string]string {
+	ids := make(map[string]string)
+
+	// the first two segments are the API name and version
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := 2; i+1 < len(segments); i += 2 {
+		ids[segments[i]] = segments[i+1]
+	}
+	for name, values := range u.Query() {
+		if strings.HasSuffix(name, "Id") && len(values) > 0 {
+			ids[name] = values[0]
+		}
+	}
+	return ids
+}
+
+// withAuditResourceType sets the type of the resource or data source in the context of its operations,
+// so that the audit log entries of the API calls identify the resource type
+func withAuditResourceType(resources map[string]*schema.Resource) {
+	for name, res := range resources {
+		res.CreateContext = withResourceTypeContext(name, res.CreateContext)
+		res.ReadContext = withResourceTypeContext(name, res.ReadContext)
+		res.UpdateContext = withResourceTypeContext(name, res.UpdateContext)
+		res.DeleteContext = withResourceTypeContext(name, res.DeleteContext)
+	}
+}
+
+func withResourceTypeContext[F ~func(context.Context, *schema.ResourceData, any) diag.Diagnostics](resourceType string, f F) F {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+		return f(log.WithAuditResourceType(ctx, resourceType), d, m)
+	}
+}
+
+// auditResourceTypeServer sets the type of the resource, data source or ephemeral resource of every request
+// in the context of its operations. It is used for the framework provider, whose resources cannot be wrapped
+// the way SDK resources are without losing the optional interfaces they implement.
+type auditResourceTypeServer struct {
+	tfprotov6.ProviderServerWithEphemeralResources
+}
+
+// withAuditResourceTypeServer returns the provider server setting the type of the resource in the context of its operations
+func withAuditResourceTypeServer(server func() tfprotov6.ProviderServer) func() tfprotov6.ProviderServer {
+	return func() tfprotov6.ProviderServer {
+		return auditResourceTypeServer{ProviderServerWithEphemeralResources: server().(tfprotov6.ProviderServerWithEphemeralResources)}
+	}
+}
+
+// ReadResource implements tfprotov6.ResourceServer
+func (s auditResourceTypeServer) ReadResource(ctx context.Context, req *tfprotov6.ReadResourceRequest) (*tfprotov6.ReadResourceResponse, error) {
+	return s.ProviderServerWithEphemeralResources.ReadResource(log.WithAuditResourceType(ctx, req.TypeName), req)
+}
+
+// PlanResourceChange implements tfprotov6.ResourceServer
+func (s auditResourceTypeServer) PlanResourceChange(ctx context.Context, req *tfprotov6.PlanResourceChangeRequest) (*tfprotov6.PlanResourceChangeResponse, error) {
+	return s.ProviderServerWithEphemeralResources.PlanResourceChange(log.WithAuditResourceType(ctx, req.TypeName), req)
+}
+
+// ApplyResourceChange implements tfprotov6.ResourceServer
+func (s auditResourceTypeServer) ApplyResourceChange(ctx context.Context, req *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error) {
+	return s.ProviderServerWithEphemeralResources.ApplyResourceChange(log.WithAuditResourceType(ctx, req.TypeName), req)
+}
+
+// ImportResourceState implements tfprotov6.ResourceServer
+func (s auditResourceTypeServer) ImportResourceState(ctx context.Context, req *tfprotov6.ImportResourceStateRequest) (*tfprotov6.ImportResourceStateResponse, error) {
+	return s.ProviderServerWithEphemeralResources.ImportResourceState(log.WithAuditResourceType(ctx, req.TypeName), req)
+}
+
+// ReadDataSource implements tfprotov6.DataSourceServer
+func (s auditResourceTypeServer) ReadDataSource(ctx context.Context, req *tfprotov6.ReadDataSourceRequest) (*tfprotov6.ReadDataSourceResponse, error) {
+	return s.ProviderServerWithEphemeralResources.ReadDataSource(log.WithAuditResourceType(ctx, req.TypeName), req)
+}
+
+// OpenEphemeralResource implements tfprotov6.EphemeralResourceServer
+func (s auditResourceTypeServer) OpenEphemeralResource(ctx context.Context, req *tfprotov6.OpenEphemeralResourceRequest) (*tfprotov6.OpenEphemeralResourceResponse, error) {
+	return s.ProviderServerWithEphemeralResources.OpenEphemeralResource(log.WithAuditResourceType(ctx, req.TypeName), req)
+}
+
+// RenewEphemeralResource implements tfprotov6.EphemeralResourceServer
+func (s auditResourceTypeServer) RenewEphemeralResource(ctx context.Context, req *tfprotov6.RenewEphemeralResourceRequest) (*tfprotov6.RenewEphemeralResourceResponse, error) {
+	return s.ProviderServerWithEphemeralResources.RenewEphemeralResource(log.WithAuditResourceType(ctx, req.TypeName), req)
+}
+
+// CloseEphemeralResource implements tfprotov6.EphemeralResourceServer
+func (s auditResourceTypeServer) CloseEphemeralResource(ctx context.Context, req *tfprotov6.CloseEphemeralResourceRequest) (*tfprotov6.CloseEphemeralResourceResponse, error) {
+	return s.ProviderServerWithEphemeralResources.CloseEphemeralResource(log.WithAuditResourceType(ctx, req.TypeName), req)
+}
@@ -0,0 +1,285 @@
+package akamai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/log"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/subprovider"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			w.Header().Set("Location", "/papi/v1/properties/prp_1?contractId=ctr_1&groupId=grp_1")
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	transport, err := newAuditTransport(path, "opID", "1-ABC", log.Get())
+	require.NoError(t, err)
+	transport.base = http.DefaultTransport
+	client := &http.Client{Transport: transport}
+
+	send := func(ctx context.Context, method, uri string) {
+		req, err := http.NewRequestWithContext(ctx, method, srv.URL+uri, nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+	}
+	ctx := log.WithAuditResourceType(context.Background(), "akamai_property")
+	send(ctx, http.MethodGet, "/papi/v1/properties/prp_1")
+	send(ctx, http.MethodPost, "/papi/v1/properties?contractId=ctr_1&groupId=grp_1")
+	send(context.Background(), http.MethodDelete, "/config-gtm/v1/domains/example.akadns.net/properties/www")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2, "only the calls changing configurations are audited")
+
+	var entries []log.AuditEntry
+	for _, line := range lines {
+		var entry log.AuditEntry
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		assert.False(t, entry.Time.IsZero())
+		entries = append(entries, entry)
+	}
+	assert.Equal(t, log.AuditEntry{
+		Time:         entries[0].Time,
+		OperationID:  "opID",
+		ResourceType: "akamai_property",
+		AccountKey:   "1-ABC",
+		Method:       http.MethodPost,
+		Path:         "/papi/v1/properties",
+		AssetIDs:     map[string]string{"properties": "prp_1", "contractId": "ctr_1", "groupId": "grp_1"},
+		Status:       http.StatusCreated,
+	}, entries[0])
+	assert.Equal(t, log.AuditEntry{
+		Time:        entries[1].Time,
+		OperationID: "opID",
+		AccountKey:  "1-ABC",
+		Method:      http.MethodDelete,
+		Path:        "/config-gtm/v1/domains/example.akadns.net/properties/www",
+		AssetIDs:    map[string]string{"domains": "example.akadns.net", "properties": "www"},
+		Status:      http.StatusForbidden,
+	}, entries[1])
+
+	// the audit log is shared by the transports of the process
+	other, err := newAuditTransport(path, "opID", "", log.Get())
+	require.NoError(t, err)
+	assert.Same(t, transport.auditLog, other.auditLog)
+
+	none, err := newAuditTransport("", "opID", "", log.Get())
+	require.NoError(t, err)
+	assert.Nil(t, none)
+}
+
+func TestAuditTransportError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	transport, err := newAuditTransport(path, "opID", "", log.Get())
+	require.NoError(t, err)
+	transport.base = roundTripperFunc(func(_ *http.Request) (*http.Response, error) {
+		return nil, errors.New("connection reset by peer")
+	})
+
+	req, err := http.NewRequest(http.MethodPut, "https://host.example.com/config-dns/v2/zones/example.com/recordsets", nil)
+	require.NoError(t, err)
+	_, err = transport.RoundTrip(req)
+	assert.EqualError(t, err, "connection reset by peer")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var entry log.AuditEntry
+	require.NoError(t, json.Unmarshal(data, &entry))
+	assert.Equal(t, "connection reset by peer", entry.Error)
+	assert.Equal(t, 0, entry.Status)
+	assert.Equal(t, map[string]string{"zones": "example.com"}, entry.AssetIDs)
+}
+
+func TestAssetIDs(t *testing.T) {
+	tests := map[string]struct {
+		url      string
+		expected map[string]string
+	}{
+		"nested assets": {
+			url:      "/appsec/v1/configs/1/versions/2/security-policies/abc_1",
+			expected: map[string]string{"configs": "1", "versions": "2", "security-policies": "abc_1"},
+		},
+		"collection": {
+			url:      "/config-dns/v2/zones/example.com/recordsets",
+			expected: map[string]string{"zones": "example.com"},
+		},
+		"query parameters": {
+			url:      "/papi/v1/cpcodes?contractId=ctr_1&groupId=grp_1&validate=true",
+			expected: map[string]string{"contractId": "ctr_1", "groupId": "grp_1"},
+		},
+		"no assets": {
+			url:      "/identity-management/v3/api-clients",
+			expected: map[string]string{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			u, err := url.Parse(test.url)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, assetIDs(u))
+		})
+	}
+}
+
+func TestWithAuditResourceType(t *testing.T) {
+	var resourceTypes []string
+	read := func(ctx context.Context, _ *schema.ResourceData, _ any) diag.Diagnostics {
+		resourceTypes = append(resourceTypes, log.AuditResourceType(ctx))
+		return nil
+	}
+	resources := map[string]*schema.Resource{
+		"akamai_test": {
+			Schema:        map[string]*schema.Schema{},
+			CreateContext: read,
+			ReadContext:   read,
+		},
+	}
+
+	withAuditResourceType(resources)
+	res := resources["akamai_test"]
+	assert.Nil(t, res.UpdateContext)
+	assert.False(t, res.CreateContext(context.Background(), res.TestResourceData(), nil).HasError())
+	assert.False(t, res.ReadContext(context.Background(), res.TestResourceData(), nil).HasError())
+	assert.Equal(t, []string{"akamai_test", "akamai_test"}, resourceTypes)
+}
+
+func TestWithAuditResourceTypeServer(t *testing.T) {
+	var resourceTypes []string
+	server := NewProtoV6FrameworkProvider([]subprovider.Subprovider{
+		auditSubprovider{resourceTypes: &resourceTypes},
+	})()
+
+	stateType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"id": tftypes.String, accountKeyAttribute: tftypes.String}}
+	state, err := tfprotov6.NewDynamicValue(stateType, tftypes.NewValue(stateType, map[string]tftypes.Value{
+		"id":                tftypes.NewValue(tftypes.String, "test"),
+		accountKeyAttribute: tftypes.NewValue(tftypes.String, nil),
+	}))
+	require.NoError(t, err)
+
+	resp, err := server.ReadResource(context.Background(), &tfprotov6.ReadResourceRequest{
+		TypeName:     "akamai_audit_test",
+		CurrentState: &state,
+	})
+	require.NoError(t, err)
+	require.Empty(t, resp.Diagnostics)
+	assert.Equal(t, []string{"akamai_audit_test"}, resourceTypes)
+}
+
+// auditSubprovider has only one framework resource, recording the audit resource type of its reads
+type auditSubprovider struct {
+	resourceTypes *[]string
+}
+
+var _ subprovider.Subprovider = auditSubprovider{}
+
+// SDKResources implements subprovider.Subprovider.
+func (auditSubprovider) SDKResources() map[string]*schema.Resource {
+	return nil
+}
+
+// SDKDataSources implements subprovider.Subprovider.
+func (auditSubprovider) SDKDataSources() map[string]*schema.Resource {
+	return nil
+}
+
+// FrameworkResources implements subprovider.Subprovider.
+func (p auditSubprovider) FrameworkResources() []func() resource.Resource {
+	return []func() resource.Resource{
+		func() resource.Resource {
+			return auditResource{resourceTypes: p.resourceTypes}
+		},
+	}
+}
+
+// FrameworkDataSources implements subprovider.Subprovider.
+func (auditSubprovider) FrameworkDataSources() []func() datasource.DataSource {
+	return nil
+}
+
+// EphemeralResources implements subprovider.Subprovider.
+func (auditSubprovider) EphemeralResources() []func() ephemeral.EphemeralResource {
+	return nil
+}
+
+// Functions implements subprovider.Subprovider.
+func (auditSubprovider) Functions() []func() function.Function {
+	return nil
+}
+
+type auditResource struct {
+	resourceTypes *[]string
+}
+
+var _ resource.Resource = auditResource{}
+
+// Metadata implements resource.Resource.
+func (auditResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "akamai_audit_test"
+}
+
+// Schema implements resource.Resource.
+func (auditResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceschema.Schema{
+		Attributes: map[string]resourceschema.Attribute{
+			"id": resourceschema.StringAttribute{Computed: true},
+		},
+	}
+}
+
+// Create implements resource.Resource.
+func (auditResource) Create(_ context.Context, _ resource.CreateRequest, _ *resource.CreateResponse) {
+}
+
+// Read implements resource.Resource.
+func (r auditResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	*r.resourceTypes = append(*r.resourceTypes, log.AuditResourceType(ctx))
+	resp.State = req.State
+}
+
+// Update implements resource.Resource.
+func (auditResource) Update(_ context.Context, _ resource.UpdateRequest, _ *resource.UpdateResponse) {
+}
+
+// Delete implements resource.Resource.
+func (auditResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,329 @@
+package akamai
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+)
+
+const (
+	// cassetteModeRecord writes every request sent to the API together with its response into the cassette
+	cassetteModeRecord = "record"
+	// cassetteModeReplay answers requests with the responses read from the cassette, without accessing the network
+	cassetteModeReplay = "replay"
+
+	redacted = "REDACTED"
+)
+
+var (
+	// redactedHeaders are the headers whose values are never written into a cassette
+	redactedHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+	// redactedQueryParams are the query parameters whose values are never written into a cassette
+	redactedQueryParams = []string{"accountSwitchKey"}
+	// redactedBodyFields are the substrings of JSON field names whose values are never written into a cassette
+	redactedBodyFields = []string{"secret", "password", "token", "privatekey"}
+
+	cassettesMu sync.Mutex
+	// cassettes holds the cassettes opened by the process, so that the transports of both the SDK and framework
+	// providers record into or replay from the same cassette
+	cassettes = make(map[string]*cassette)
+)
+
+type (
+	// cassetteTransport is a http.RoundTripper which records the requests and responses into a cassette,
+	// or replays the recorded responses, depending on the cassette's mode
+	cassetteTransport struct {
+		base     http.RoundTripper
+		cassette *cassette
+	}
+
+	cassette struct {
+		path string
+		mode string
+
+		mu sync.Mutex
+		// interactions holds the recorded interactions not replayed yet, keyed by the request
+		interactions map[string][]cassetteInteraction
+		// replayed holds the last replayed interaction for every request
+		replayed map[string]cassetteInteraction
+	}
+
+	cassetteInteraction struct {
+		Request  cassetteRequest  `json:"request"`
+		Response cassetteResponse `json:"response"`
+	}
+
+	cassetteRequest struct {
+		Method string      `json:"method"`
+		URL    string      `json:"url"`
+		Header http.Header `json:"header,omitempty"`
+		Body   string      `json:"body,omitempty"`
+	}
+
+	cassetteResponse struct {
+		StatusCode int         `json:"statusCode"`
+		Header     http.Header `json:"header,omitempty"`
+		Body       string      `json:"body,omitempty"`
+	}
+)
+
+// newCassetteTransport returns the transport recording into or replaying from the cassette at the given path,
+// or nil if path is empty.
+//
+// The cassette is a JSON Lines file, with one request and response pair per line. Recorded interactions are appended
+// to the cassette, so that the cassette of e.g. terraform plan followed by apply contains the interactions of both.
+func newCassetteTransport(path, mode string) (*cassetteTransport, error) {
+	if path == "" {
+		if mode != "" {
+			return nil, errors.New("cassette mode is set, but the cassette path is not")
+		}
+		return nil, nil
+	}
+	if mode != cassetteModeRecord && mode != cassetteModeReplay {
+		return nil, fmt.Errorf("invalid cassette mode %q, expected %q or %q", mode, cassetteModeRecord, cassetteModeReplay)
+	}
+
+	cassettesMu.Lock()
+	defer cassettesMu.Unlock()
+
+	c, ok := cassettes[path]
+	if !ok {
+		c = &cassette{path: path, mode: mode}
+		if mode == cassetteModeReplay {
+			if err := c.load(); err != nil {
+				return nil, err
+			}
+		}
+		cassettes[path] = c
+	}
+	if c.mode != mode {
+		return nil, fmt.Errorf("cassette %s is already open in %q mode", path, c.mode)
+	}
+
+	return &cassetteTransport{cassette: c}, nil
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *cassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.cassette.mode == cassetteModeReplay {
+		return t.cassette.replay(req)
+	}
+
+	req = req.Clone(req.Context())
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = t.cassette.record(cassetteInteraction{
+		Request: cassetteRequest{
+			Method: req.Method,
+			URL:    redactURL(req.URL),
+			Header: redactHeader(req.Header),
+			Body:   redactBody(reqBody),
+		},
+		Response: cassetteResponse{
+			StatusCode: resp.StatusCode,
+			Header:     redactHeader(resp.Header),
+			Body:       redactBody(respBody),
+		},
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *cassette) load() error {
+	f, err := os.Open(c.path)
+	if err != nil {
+		return fmt.Errorf("failed to open cassette: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	c.interactions = make(map[string][]cassetteInteraction)
+	c.replayed = make(map[string]cassetteInteraction)
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var interaction cassetteInteraction
+		if err = json.Unmarshal(scanner.Bytes(), &interaction); err != nil {
+			return fmt.Errorf("failed to read cassette %s, line %d: %w", c.path, line, err)
+		}
+		key := interactionKey(interaction.Request.Method, interaction.Request.URL)
+		c.interactions[key] = append(c.interactions[key], interaction)
+	}
+	if err = scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read cassette %s: %w", c.path, err)
+	}
+	return nil
+}
+
+func (c *cassette) record(interaction cassetteInteraction) error {
+	line, err := json.Marshal(interaction)
+	if err != nil {
+		return fmt.Errorf("failed to record interaction: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	f, err := os.OpenFile(c.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open cassette: %w", err)
+	}
+	if _, err = f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to record interaction: %w", err)
+	}
+	return f.Close()
+}
+
+// replay returns the response of the next recorded interaction matching the request method and URL.
+// Once all matching interactions are replayed, the last one is returned for any further request.
+func (c *cassette) replay(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+	key := interactionKey(req.Method, redactURL(req.URL))
+
+	c.mu.Lock()
+	interaction, ok := c.replayed[key]
+	if pending := c.interactions[key]; len(pending) > 0 {
+		interaction, ok = pending[0], true
+		c.interactions[key] = pending[1:]
+		c.replayed[key] = interaction
+	}
+	c.mu.Unlock()
+
+	if !ok {
+		return nil, fmt.Errorf("no interaction recorded in cassette %s for %s", c.path, key)
+	}
+
+	header := interaction.Response.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	// the recorded length does not have to match the redacted body
+	header.Del("Content-Length")
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+		StatusCode:    interaction.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(interaction.Response.Body)),
+		ContentLength: int64(len(interaction.Response.Body)),
+		Request:       req,
+	}, nil
+}
+
+func interactionKey(method, uri string) string {
+	return fmt.Sprintf("%s %s", method, uri)
+}
+
+// readBody reads the whole body and replaces it with a reader of the read content
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	data, err := io.ReadAll(*body)
+	if err != nil {
+		return nil, err
+	}
+	if err = (*body).Close(); err != nil {
+		return nil, err
+	}
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+// redactURL returns the request URI without the host, which is specific to the API client credentials
+func redactURL(u *url.URL) string {
+	query := u.Query()
+	for _, param := range redactedQueryParams {
+		if query.Has(param) {
+			query.Set(param, redacted)
+		}
+	}
+	redactedURL := url.URL{Path: u.Path, RawPath: u.RawPath, RawQuery: query.Encode()}
+	return redactedURL.RequestURI()
+}
+
+func redactHeader(header http.Header) http.Header {
+	header = header.Clone()
+	for _, name := range redactedHeaders {
+		if header.Get(name) != "" {
+			header.Set(name, redacted)
+		}
+	}
+	return header
+}
+
+// redactBody redacts the values of secret fields of JSON bodies, other bodies are returned as they are
+func redactBody(body []byte) string {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	// numbers are kept as they are, as large IDs do not fit into float64
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil || decoder.More() {
+		return string(body)
+	}
+	redactedBody, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return string(body)
+	}
+	return string(redactedBody)
+}
+
+func redactValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for field, fieldValue := range v {
+			if isSecretField(field) {
+				if _, ok := fieldValue.(string); ok {
+					v[field] = redacted
+					continue
+				}
+			}
+			v[field] = redactValue(fieldValue)
+		}
+	case []any:
+		for i := range v {
+			v[i] = redactValue(v[i])
+		}
+	}
+	return value
+}
+
+func isSecretField(field string) bool {
+	field = strings.ToLower(field)
+	for _, secret := range redactedBodyFields {
+		if strings.Contains(field, secret) {
+			return true
+		}
+	}
+	return false
+}
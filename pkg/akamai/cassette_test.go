@@ -0,0 +1,113 @@
+package akamai
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCassetteRecordAndReplay(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=abc")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/papi/v1/groups":
+			_, _ = w.Write([]byte(`{"accountId":"act_1","groups":{"items":[{"groupId":"grp_` + strings.Repeat("1", calls) + `"}]}}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/identity-management/v3/api-clients/self/credentials":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"credentialId":12345678901234567,"clientToken":"akab-token","clientSecret":"secret"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.jsonl")
+
+	recorder, err := newCassetteTransport(path, cassetteModeRecord)
+	require.NoError(t, err)
+	recorder.base = http.DefaultTransport
+	client := &http.Client{Transport: recorder}
+
+	send := func(client *http.Client, method, uri string, body string) (int, string) {
+		req, err := http.NewRequest(method, srv.URL+uri, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "EG1-HMAC-SHA256 client_token=akab-client;access_token=akab-access;signature=sig")
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		respBody, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		return resp.StatusCode, string(respBody)
+	}
+
+	_, firstGroups := send(client, http.MethodGet, "/papi/v1/groups?accountSwitchKey=1-ABC", "")
+	_, secondGroups := send(client, http.MethodGet, "/papi/v1/groups?accountSwitchKey=1-ABC", "")
+	status, credential := send(client, http.MethodPost, "/identity-management/v3/api-clients/self/credentials", `{"password":"pass"}`)
+	assert.Equal(t, http.StatusCreated, status)
+	assert.Contains(t, credential, `"clientSecret":"secret"`, "recording must not change the responses")
+	assert.Equal(t, 3, calls)
+
+	recorded, err := os.ReadFile(path)
+	require.NoError(t, err)
+	for _, secret := range []string{"akab-client", "akab-access", "signature=sig", "session=abc", "1-ABC", `"secret"`, "akab-token", `"pass"`, srv.Listener.Addr().String()} {
+		assert.NotContains(t, string(recorded), secret)
+	}
+	assert.Contains(t, string(recorded), "12345678901234567", "numbers must be kept as they are")
+	assert.Len(t, strings.Split(strings.TrimSpace(string(recorded)), "\n"), 3)
+
+	srv.Close()
+	// replay in a new provider process
+	delete(cassettes, path)
+
+	replayer, err := newCassetteTransport(path, cassetteModeReplay)
+	require.NoError(t, err)
+	client = &http.Client{Transport: replayer}
+
+	_, groups := send(client, http.MethodGet, "/papi/v1/groups?accountSwitchKey=1-XYZ", "")
+	assert.JSONEq(t, firstGroups, groups)
+	_, groups = send(client, http.MethodGet, "/papi/v1/groups?accountSwitchKey=1-XYZ", "")
+	assert.JSONEq(t, secondGroups, groups)
+	_, groups = send(client, http.MethodGet, "/papi/v1/groups?accountSwitchKey=1-XYZ", "")
+	assert.JSONEq(t, secondGroups, groups, "the last interaction is replayed once all are used")
+
+	status, credential = send(client, http.MethodPost, "/identity-management/v3/api-clients/self/credentials", "")
+	assert.Equal(t, http.StatusCreated, status)
+	assert.JSONEq(t, `{"credentialId":12345678901234567,"clientToken":"REDACTED","clientSecret":"REDACTED"}`, credential)
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/papi/v1/contracts", nil)
+	require.NoError(t, err)
+	_, err = client.Do(req)
+	assert.ErrorContains(t, err, "no interaction recorded in cassette "+path+" for GET /papi/v1/contracts")
+}
+
+func TestNewCassetteTransport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.jsonl")
+
+	transport, err := newCassetteTransport("", "")
+	assert.NoError(t, err)
+	assert.Nil(t, transport)
+
+	_, err = newCassetteTransport("", cassetteModeRecord)
+	assert.EqualError(t, err, "cassette mode is set, but the cassette path is not")
+
+	_, err = newCassetteTransport(path, "")
+	assert.EqualError(t, err, `invalid cassette mode "", expected "record" or "replay"`)
+
+	_, err = newCassetteTransport(path, cassetteModeReplay)
+	assert.ErrorContains(t, err, "failed to open cassette")
+
+	_, err = newCassetteTransport(path, cassetteModeRecord)
+	assert.NoError(t, err)
+	_, err = newCassetteTransport(path, cassetteModeReplay)
+	assert.EqualError(t, err, "cassette "+path+` is already open in "record" mode`)
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/cache"
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/log"
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/ratelimit"
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/retryablehttp"
 	"github.com/google/uuid"
 	"github.com/spf13/cast"
@@ -26,44 +28,151 @@ type contextConfig struct {
 	userAgent      string
 	ctx            context.Context
 	requestLimit   int
+	rateLimits     map[string]int
 	enableCache    bool
+	cacheDir       string
+	cacheTTL       time.Duration
+	auditLogPath   string
 	retryMax       int
 	retryWaitMin   time.Duration
 	retryWaitMax   time.Duration
 	retryDisabled  bool
+	retryRules     []retryRule
+	gtmSettings    meta.GTMSettings
 }
 
 func configureContext(cfg contextConfig) (*meta.OperationMeta, error) {
+	if cfg.gtmSettings.PollInterval < 0 {
+		return nil, fmt.Errorf("GTM poll interval (%v) cannot be negative", cfg.gtmSettings.PollInterval)
+	}
+
 	operationID := uuid.NewString()
-	log := log.FromContext(cfg.ctx, "OperationID", operationID)
+	logger := log.FromContext(cfg.ctx, "OperationID", operationID)
+
+	sess, err := newSession(cfg, cfg.edgegridConfig, logger, operationID)
+	if err != nil {
+		return nil, err
+	}
+	cache.Enable(cfg.enableCache)
+	if err = cache.EnableFile(cfg.cacheDir, cacheNamespace(cfg.edgegridConfig), cfg.cacheTTL); err != nil {
+		return nil, err
+	}
+
+	// sessions of other accounts, requested with account_key, use the same credentials with a different account switch key
+	accountSession := func(accountKey string) (session.Session, error) {
+		edgegridConfig := *cfg.edgegridConfig
+		edgegridConfig.AccountKey = accountKey
+		return newSession(cfg, &edgegridConfig, log.FromHCLog(logger.HCLog().With("AccountKey", accountKey)), operationID)
+	}
 
+	return meta.New(sess, logger.HCLog(), operationID,
+		meta.WithAccountKey(cfg.edgegridConfig.AccountKey),
+		meta.WithSessionFactory(accountSession),
+		meta.WithGTMSettings(cfg.gtmSettings))
+}
+
+// newSession creates the session signing the requests with the given edgegrid config
+func newSession(cfg contextConfig, edgegridConfig *edgegrid.Config, logger *log.Logger, operationID string) (session.Session, error) {
 	opts := []session.Option{
-		session.WithSigner(cfg.edgegridConfig),
+		session.WithSigner(edgegridConfig),
 		session.WithUserAgent(cfg.userAgent),
-		session.WithLog(log),
+		session.WithLog(logger),
 		session.WithHTTPTracing(cast.ToBool(os.Getenv("AKAMAI_HTTP_TRACE_ENABLED"))),
 		session.WithRequestLimit(cfg.requestLimit),
 	}
-	var sess session.Session
-	var err error
-	if cfg.retryDisabled {
-		sess, err = sessionWithoutRetry(opts)
-	} else {
-		sess, err = sessionWithRetry(cfg, opts)
+	limiter, err := newRateLimiter(edgegridConfig.Host, cfg.rateLimits)
+	if err != nil {
+		return nil, err
 	}
+	cassette, err := newCassetteTransport(os.Getenv("AKAMAI_HTTP_CASSETTE"), os.Getenv("AKAMAI_HTTP_CASSETTE_MODE"))
 	if err != nil {
 		return nil, err
 	}
-	cache.Enable(cfg.enableCache)
+	audit, err := newAuditTransport(cfg.auditLogPath, operationID, edgegridConfig.AccountKey, logger)
+	if err != nil {
+		return nil, err
+	}
+	transport := transportLayers{limiter: limiter, cassette: cassette, audit: audit}
+
+	if cfg.retryDisabled {
+		return sessionWithoutRetry(opts, transport)
+	}
+	return sessionWithRetry(cfg, opts, transport)
+}
 
-	return meta.New(sess, log.HCLog(), operationID)
+// cacheNamespace identifies the account the provider is configured for, so that entries
+// persisted in the file cache are never shared between different credentials or account switch keys
+func cacheNamespace(edgegridConfig *edgegrid.Config) string {
+	return fmt.Sprintf("%s:%s", edgegridConfig.Host, edgegridConfig.AccountKey)
 }
 
-func sessionWithoutRetry(opts []session.Option) (session.Session, error) {
-	return session.New(opts...)
+// newRateLimiter returns the transport limiting the rate of requests per API, or nil if no limits are configured.
+// The limits are shared by all sessions sending requests to the host, as they are imposed on the API client.
+func newRateLimiter(host string, rateLimits map[string]int) (*ratelimit.Transport, error) {
+	if len(rateLimits) == 0 {
+		return nil, nil
+	}
+	return ratelimit.NewSharedTransport(nil, host, rateLimits)
 }
 
-func overrideRetryPolicy(basePolicy retryablehttp.CheckRetry) retryablehttp.CheckRetry {
+// transportLayers are the optional transports wrapping the transport which sends the requests of a session
+type transportLayers struct {
+	limiter  *ratelimit.Transport
+	cassette *cassetteTransport
+	audit    *auditTransport
+}
+
+func (l transportLayers) empty() bool {
+	return l.limiter == nil && l.cassette == nil && l.audit == nil
+}
+
+// wrap returns base wrapped in the configured layers. The cassette is placed right above base,
+// so that it records or replays the exact requests which would be sent over the network.
+func (l transportLayers) wrap(base http.RoundTripper) http.RoundTripper {
+	transport := base
+	if l.cassette != nil {
+		l.cassette.base = transport
+		transport = l.cassette
+	}
+	if l.limiter != nil {
+		l.limiter.Base = transport
+		transport = l.limiter
+	}
+	return transport
+}
+
+// wrapCall returns base wrapped in the layers observing whole API calls. These are placed above the retry client,
+// so that e.g. the audit log has one entry per API call with its final status.
+func (l transportLayers) wrapCall(base http.RoundTripper) http.RoundTripper {
+	if l.audit == nil {
+		return base
+	}
+	l.audit.base = base
+	return l.audit
+}
+
+// setSession makes the layers sign the requests they delay with the session's signer
+func (l transportLayers) setSession(sess session.Session) {
+	if l.limiter != nil {
+		l.limiter.PrepareRequest = sess.Sign
+	}
+}
+
+func sessionWithoutRetry(opts []session.Option, transport transportLayers) (session.Session, error) {
+	if transport.empty() {
+		return session.New(opts...)
+	}
+
+	opts = append(opts, session.WithClient(&http.Client{Transport: transport.wrapCall(transport.wrap(http.DefaultTransport))}))
+	sess, err := session.New(opts...)
+	if err != nil {
+		return nil, err
+	}
+	transport.setSession(sess)
+	return sess, nil
+}
+
+func overrideRetryPolicy(basePolicy retryablehttp.CheckRetry, rules []retryRule) retryablehttp.CheckRetry {
 	return func(ctx context.Context, resp *http.Response, err error) (bool, error) {
 
 		// do not retry on context.Canceled or context.DeadlineExceeded
@@ -78,6 +187,18 @@ func overrideRetryPolicy(basePolicy retryablehttp.CheckRetry) retryablehttp.Chec
 			return true, nil
 		}
 
+		// Retry requests covered by the retry policy, on connection errors or on the status codes of the matching rules
+		if matching := matchingRetryRules(rules, resp, err); len(matching) > 0 {
+			if resp == nil {
+				return basePolicy(ctx, resp, err)
+			}
+			for _, rule := range matching {
+				if slices.Contains(rule.statusCodes, resp.StatusCode) {
+					return true, nil
+				}
+			}
+		}
+
 		var urlErr *url.Error
 		if (resp != nil && resp.Request.Method == http.MethodGet) ||
 			(resp == nil && errors.As(err, &urlErr) && strings.ToUpper(urlErr.Op) == http.MethodGet) {
@@ -148,7 +269,7 @@ func overrideBackoff(baseBackoff retryablehttp.Backoff, logger akalog.Interface)
 	}
 }
 
-func sessionWithRetry(cfg contextConfig, opts []session.Option) (session.Session, error) {
+func sessionWithRetry(cfg contextConfig, opts []session.Option, transport transportLayers) (session.Session, error) {
 	if cfg.retryMax == 0 {
 		cfg.retryMax = 10
 	}
@@ -169,7 +290,14 @@ func sessionWithRetry(cfg contextConfig, opts []session.Option) (session.Session
 	retryClient.RetryWaitMin = cfg.retryWaitMin
 	retryClient.RetryWaitMax = cfg.retryWaitMax
 
-	opts = append(opts, session.WithClient(retryClient.StandardClient()))
+	// the layers are placed below the retry client, so that every retry attempt is limited and recorded as well
+	if !transport.empty() {
+		retryClient.HTTPClient.Transport = transport.wrap(retryClient.HTTPClient.Transport)
+	}
+
+	client := retryClient.StandardClient()
+	client.Transport = transport.wrapCall(client.Transport)
+	opts = append(opts, session.WithClient(client))
 	sess, err := session.New(opts...)
 	if err != nil {
 		return nil, err
@@ -178,12 +306,13 @@ func sessionWithRetry(cfg contextConfig, opts []session.Option) (session.Session
 	retryClient.PrepareRetry = func(r *http.Request) error {
 		return sess.Sign(r)
 	}
+	transport.setSession(sess)
 
 	retryClient.HTTPClient.CheckRedirect = func(req *http.Request, _ []*http.Request) error {
 		return sess.Sign(req)
 	}
 
-	retryClient.CheckRetry = overrideRetryPolicy(retryablehttp.DefaultRetryPolicy)
+	retryClient.CheckRetry = overrideRetryPolicy(retryablehttp.DefaultRetryPolicy, slices.Concat(idempotentRetryRules, cfg.retryRules))
 	l := sess.Log(cfg.ctx)
 	retryClient.Backoff = overrideBackoff(retryablehttp.DefaultBackoff, l)
 	retryClient.Logger = session.GetRetryableLogger(l)
@@ -210,5 +339,5 @@ func validateRetryConfiguration(cfg contextConfig) error {
 		return fmt.Errorf("wrong retry values: retry wait time too long, minimum retry wait time (%v) cannot be higher than %v or maximum retry wait time (%v) cannot be higher than %v", cfg.retryWaitMin, maxWaitTime, cfg.retryWaitMax, maxWaitTime)
 
 	}
-	return nil
+	return validateRetryRules(cfg.retryRules)
 }
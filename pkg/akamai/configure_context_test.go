@@ -16,6 +16,8 @@ import (
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/papi"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/session"
 	"github.com/akamai/terraform-provider-akamai/v7/internal/test"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/ratelimit"
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/retryablehttp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -126,7 +128,7 @@ func TestOverrideRetryPolicy(t *testing.T) {
 	basePolicy := func(_ context.Context, _ *http.Response, _ error) (bool, error) {
 		return false, errors.New("base policy: dummy, not implemented")
 	}
-	policy := overrideRetryPolicy(basePolicy)
+	policy := overrideRetryPolicy(basePolicy, nil)
 
 	tests := map[string]struct {
 		ctx            context.Context
@@ -406,3 +408,102 @@ func TestXRateLimitPost(t *testing.T) {
 		xrlHandler.ReturnTimes()[1],
 		xrlHandler.AvailableAt().Add(time.Duration(time.Millisecond)*1100))
 }
+
+func TestConfigureContextRateLimits(t *testing.T) {
+	tests := map[string]struct {
+		retryDisabled bool
+		transport     func(session.Session) http.RoundTripper
+	}{
+		"with retries": {
+			transport: func(sess session.Session) http.RoundTripper {
+				return sess.Client().Transport.(*retryablehttp.RoundTripper).Client.HTTPClient.Transport
+			},
+		},
+		"without retries": {
+			retryDisabled: true,
+			transport: func(sess session.Session) http.RoundTripper {
+				return sess.Client().Transport
+			},
+		},
+	}
+
+	for name, tst := range tests {
+		t.Run(name, func(t *testing.T) {
+			meta, err := configureContext(contextConfig{
+				userAgent:      "terraform-provider-akamai",
+				edgegridConfig: &edgegrid.Config{Host: "host.example.com"},
+				ctx:            context.Background(),
+				rateLimits:     map[string]int{"/papi/": 10},
+				retryDisabled:  tst.retryDisabled,
+			})
+			require.NoError(t, err)
+
+			limiter, ok := tst.transport(meta.Session()).(*ratelimit.Transport)
+			require.True(t, ok)
+			assert.NotNil(t, limiter.PrepareRequest)
+		})
+	}
+
+	_, err := configureContext(contextConfig{
+		userAgent:      "terraform-provider-akamai",
+		edgegridConfig: &edgegrid.Config{Host: "host.example.com"},
+		ctx:            context.Background(),
+		rateLimits:     map[string]int{"/papi/": -1},
+	})
+	assert.EqualError(t, err, `rate limit (-1) for "/papi/" cannot be negative`)
+}
+
+func TestConfigureContextRateLimitsShared(t *testing.T) {
+	configure := func(host string) *ratelimit.Transport {
+		meta, err := configureContext(contextConfig{
+			userAgent:      "terraform-provider-akamai",
+			edgegridConfig: &edgegrid.Config{Host: host},
+			ctx:            context.Background(),
+			rateLimits:     map[string]int{"/papi/": 1},
+			retryDisabled:  true,
+		})
+		require.NoError(t, err)
+		limiter, ok := meta.Session().Client().Transport.(*ratelimit.Transport)
+		require.True(t, ok)
+		limiter.Base = roundTripperFunc(func(_ *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+		})
+		return limiter
+	}
+	send := func(limiter *ratelimit.Transport) error {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://host/papi/v1/groups", nil)
+		require.NoError(t, err)
+		_, err = limiter.RoundTrip(req)
+		return err
+	}
+
+	first, second := configure("shared-budget.example.com"), configure("shared-budget.example.com")
+	other := configure("other-budget.example.com")
+
+	// the first provider takes the only token available per second, so the second one has to wait for the next
+	require.NoError(t, send(first))
+	assert.ErrorIs(t, send(second), context.DeadlineExceeded)
+	assert.NoError(t, send(other))
+}
+
+func TestConfigureContextGTMSettings(t *testing.T) {
+	settings := meta.GTMSettings{PollInterval: 30 * time.Second, FailOnTimeout: true, BatchUpdates: true}
+	providerMeta, err := configureContext(contextConfig{
+		userAgent:      "terraform-provider-akamai",
+		edgegridConfig: &edgegrid.Config{Host: "host.example.com"},
+		ctx:            context.Background(),
+		gtmSettings:    settings,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, settings, providerMeta.GTMSettings())
+
+	_, err = configureContext(contextConfig{
+		userAgent:      "terraform-provider-akamai",
+		edgegridConfig: &edgegrid.Config{Host: "host.example.com"},
+		ctx:            context.Background(),
+		gtmSettings:    meta.GTMSettings{PollInterval: -time.Second},
+	})
+	assert.EqualError(t, err, "GTM poll interval (-1s) cannot be negative")
+}
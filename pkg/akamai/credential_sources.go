@@ -0,0 +1,217 @@
+package akamai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cast"
+)
+
+const (
+	// credentialProcessTimeout is the maximum time the credential_process command may run
+	credentialProcessTimeout = time.Minute
+	// vaultTimeout is the maximum time of reading the credentials from Vault
+	vaultTimeout = 30 * time.Second
+)
+
+var (
+	credentialsMu sync.Mutex
+	// resolvedCredentials holds the credentials read by the process, keyed by their source. Both the SDK
+	// and framework providers are configured with the same sources, and e.g. a file descriptor can be read only once.
+	resolvedCredentials = make(map[string]configBearer)
+)
+
+// credentialSources are the external sources of EdgeGrid credentials, which provide the credentials as JSON
+// object with the same fields as the config block, e.g.
+//
+//	{"host": "...", "client_token": "...", "client_secret": "...", "access_token": "...", "account_key": "..."}
+type credentialSources struct {
+	// process is the command printing the credentials to its standard output
+	process string
+	// fd is the file descriptor number, or "stdin", from which the credentials are read
+	fd string
+	// vaultPath is the path of the Vault secret holding the credentials, e.g. "secret/data/akamai".
+	// The Vault address and token are read from VAULT_ADDR and VAULT_TOKEN environment variables.
+	vaultPath string
+}
+
+// resolve returns the credentials of the first configured source, or false if no source is configured
+func (s credentialSources) resolve(ctx context.Context) (configBearer, bool, error) {
+	switch {
+	case s.process != "":
+		c, err := resolveOnce("credential_process:"+s.process, func() (configBearer, error) {
+			return credentialsFromProcess(ctx, s.process)
+		})
+		return c, true, err
+	case s.fd != "":
+		c, err := resolveOnce("credentials_fd:"+s.fd, func() (configBearer, error) {
+			return credentialsFromFD(s.fd)
+		})
+		return c, true, err
+	case s.vaultPath != "":
+		c, err := resolveOnce("vault_secret_path:"+s.vaultPath, func() (configBearer, error) {
+			return credentialsFromVault(ctx, os.Getenv("VAULT_ADDR"), os.Getenv("VAULT_TOKEN"), s.vaultPath)
+		})
+		return c, true, err
+	}
+	return configBearer{}, false, nil
+}
+
+func resolveOnce(source string, read func() (configBearer, error)) (configBearer, error) {
+	credentialsMu.Lock()
+	defer credentialsMu.Unlock()
+
+	if c, ok := resolvedCredentials[source]; ok {
+		return c, nil
+	}
+	c, err := read()
+	if err != nil {
+		return configBearer{}, err
+	}
+	resolvedCredentials[source] = c
+	return c, nil
+}
+
+func credentialsFromProcess(ctx context.Context, command string) (configBearer, error) {
+	ctx, cancel := context.WithTimeout(ctx, credentialProcessTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd.exe", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return configBearer{}, fmt.Errorf("credential_process failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	c, err := parseCredentials(stdout.Bytes())
+	if err != nil {
+		return configBearer{}, fmt.Errorf("credential_process output: %w", err)
+	}
+	return c, nil
+}
+
+func credentialsFromFD(fd string) (configBearer, error) {
+	var f *os.File
+	if fd == "stdin" || fd == "0" {
+		f = os.Stdin
+	} else {
+		n, err := strconv.ParseUint(fd, 10, 0)
+		if err != nil {
+			return configBearer{}, fmt.Errorf("invalid credentials_fd %q, expected a file descriptor number or \"stdin\"", fd)
+		}
+		f = os.NewFile(uintptr(n), "credentials_fd")
+		if f == nil {
+			return configBearer{}, fmt.Errorf("invalid credentials_fd %q", fd)
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+	}
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return configBearer{}, fmt.Errorf("reading credentials_fd %s: %w", fd, err)
+	}
+	c, err := parseCredentials(data)
+	if err != nil {
+		return configBearer{}, fmt.Errorf("credentials_fd %s: %w", fd, err)
+	}
+	return c, nil
+}
+
+// credentialsFromVault reads the credentials from the secret of the Vault compatible HTTP API. Both the KV version 1
+// ({"data": {...}}) and version 2 ({"data": {"data": {...}}}) response formats are supported.
+func credentialsFromVault(ctx context.Context, address, token, secretPath string) (configBearer, error) {
+	if address == "" {
+		return configBearer{}, errors.New("VAULT_ADDR has to be set to read the credentials from vault_secret_path")
+	}
+	ctx, cancel := context.WithTimeout(ctx, vaultTimeout)
+	defer cancel()
+
+	secretURL := fmt.Sprintf("%s/v1/%s", strings.TrimSuffix(address, "/"), strings.TrimPrefix(secretPath, "/"))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, secretURL, nil)
+	if err != nil {
+		return configBearer{}, fmt.Errorf("reading vault secret %s: %w", secretPath, err)
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	if namespace := os.Getenv("VAULT_NAMESPACE"); namespace != "" {
+		req.Header.Set("X-Vault-Namespace", namespace)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return configBearer{}, fmt.Errorf("reading vault secret %s: %w", secretPath, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return configBearer{}, fmt.Errorf("reading vault secret %s: unexpected status %d", secretPath, resp.StatusCode)
+	}
+
+	var secret struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&secret); err != nil {
+		return configBearer{}, fmt.Errorf("reading vault secret %s: %w", secretPath, err)
+	}
+	data, err := json.Marshal(secret.Data)
+	if err != nil {
+		return configBearer{}, fmt.Errorf("reading vault secret %s: %w", secretPath, err)
+	}
+	if nested, ok := secret.Data["data"]; ok {
+		data = nested
+	}
+	c, err := parseCredentials(data)
+	if err != nil {
+		return configBearer{}, fmt.Errorf("vault secret %s: %w", secretPath, err)
+	}
+	return c, nil
+}
+
+// parseCredentials reads the credentials JSON object. max_body may be given as a number or a string,
+// as e.g. Vault stores the values of secrets written with the CLI as strings.
+func parseCredentials(data []byte) (configBearer, error) {
+	var credentials struct {
+		Host         string `json:"host"`
+		ClientToken  string `json:"client_token"`
+		ClientSecret string `json:"client_secret"`
+		AccessToken  string `json:"access_token"`
+		AccountKey   string `json:"account_key"`
+		MaxBody      any    `json:"max_body"`
+	}
+	if err := json.Unmarshal(data, &credentials); err != nil {
+		return configBearer{}, fmt.Errorf("invalid credentials JSON: %w", err)
+	}
+	maxBody, err := cast.ToIntE(credentials.MaxBody)
+	if err != nil {
+		return configBearer{}, fmt.Errorf("invalid max_body: %w", err)
+	}
+
+	return configBearer{
+		accessToken:  credentials.AccessToken,
+		accountKey:   credentials.AccountKey,
+		clientSecret: credentials.ClientSecret,
+		clientToken:  credentials.ClientToken,
+		host:         credentials.Host,
+		maxBody:      maxBody,
+	}, nil
+}
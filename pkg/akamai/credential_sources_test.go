@@ -0,0 +1,144 @@
+package akamai
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const credentialsJSON = `{"host": "akab-host.luna.akamaiapis.net", "client_token": "akab-client-token", "client_secret": "secret", "access_token": "akab-access-token", "account_key": "1-ABC", "max_body": 1024}`
+
+var expectedCredentials = configBearer{
+	host:         "akab-host.luna.akamaiapis.net",
+	clientToken:  "akab-client-token",
+	clientSecret: "secret",
+	accessToken:  "akab-access-token",
+	accountKey:   "1-ABC",
+	maxBody:      1024,
+}
+
+func TestCredentialsFromProcess(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test commands require sh")
+	}
+
+	tests := map[string]struct {
+		command   string
+		expected  configBearer
+		withError string
+	}{
+		"credentials printed": {
+			command:  "echo '" + credentialsJSON + "'",
+			expected: expectedCredentials,
+		},
+		"command failed": {
+			command:   "echo 'no access' >&2; exit 3",
+			withError: "credential_process failed: exit status 3: no access",
+		},
+		"invalid output": {
+			command:   "echo 'host=example.com'",
+			withError: "credential_process output: invalid credentials JSON",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			credentials, err := credentialsFromProcess(context.Background(), test.command)
+			if test.withError != "" {
+				assert.ErrorContains(t, err, test.withError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, credentials)
+		})
+	}
+}
+
+func TestCredentialsFromFD(t *testing.T) {
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	_, err = w.WriteString(credentialsJSON)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	fd := strconv.Itoa(int(r.Fd()))
+	sources := credentialSources{fd: fd}
+	credentials, ok, err := sources.resolve(context.Background())
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, expectedCredentials, credentials)
+
+	// the descriptor is read once, both providers use the same credentials
+	credentials, _, err = sources.resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, expectedCredentials, credentials)
+	delete(resolvedCredentials, "credentials_fd:"+fd)
+
+	_, err = credentialsFromFD("fd")
+	assert.EqualError(t, err, `invalid credentials_fd "fd", expected a file descriptor number or "stdin"`)
+}
+
+func TestCredentialsFromVault(t *testing.T) {
+	tests := map[string]struct {
+		body      string
+		status    int
+		token     string
+		expected  configBearer
+		withError string
+	}{
+		"KV version 2": {
+			body:     `{"data": {"data": ` + credentialsJSON + `, "metadata": {"version": 1}}}`,
+			status:   http.StatusOK,
+			token:    "vault-token",
+			expected: expectedCredentials,
+		},
+		"KV version 1 with string max_body": {
+			body:   `{"data": {"host": "akab-host.luna.akamaiapis.net", "client_token": "a", "client_secret": "b", "access_token": "c", "max_body": "2048"}}`,
+			status: http.StatusOK,
+			token:  "vault-token",
+			expected: configBearer{
+				host:         "akab-host.luna.akamaiapis.net",
+				clientToken:  "a",
+				clientSecret: "b",
+				accessToken:  "c",
+				maxBody:      2048,
+			},
+		},
+		"permission denied": {
+			body:      `{"errors": ["permission denied"]}`,
+			status:    http.StatusForbidden,
+			token:     "other-token",
+			withError: "reading vault secret /secret/data/akamai: unexpected status 403",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/v1/secret/data/akamai", r.URL.Path)
+				assert.Equal(t, test.token, r.Header.Get("X-Vault-Token"))
+				w.WriteHeader(test.status)
+				_, _ = w.Write([]byte(test.body))
+			}))
+			defer srv.Close()
+
+			credentials, err := credentialsFromVault(context.Background(), srv.URL+"/", test.token, "/secret/data/akamai")
+			if test.withError != "" {
+				assert.EqualError(t, err, test.withError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, credentials)
+		})
+	}
+
+	_, err := credentialsFromVault(context.Background(), "", "token", "secret/data/akamai")
+	assert.EqualError(t, err, "VAULT_ADDR has to be set to read the credentials from vault_secret_path")
+}
@@ -1,6 +1,7 @@
 package akamai
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -52,10 +53,16 @@ func (c configBearer) valid() bool {
 // It evaluates possibility of creating the config in the following order:
 //  1. Environmental variables
 //  2. Config block
-//  3. Edgerc file
+//  3. Output of the credential_process command
+//  4. Credentials read from credentials_fd
+//  5. Vault secret at vault_secret_path
+//  6. Edgerc file
+//
+// Only one of the external sources (3-5) is used, and if it is configured, the credentials
+// it provides have to be valid.
 //
 // If edgerc path or section are not provided, it uses the edgegrid defaults.
-func newEdgegridConfig(path, section string, config configBearer) (*edgegrid.Config, error) {
+func newEdgegridConfig(ctx context.Context, path, section string, config configBearer, sources credentialSources) (*edgegrid.Config, error) {
 	envEdgerc := &edgegrid.Config{}
 	err := envEdgerc.FromEnv(edgercSectionOrDefault(section))
 	if err == nil {
@@ -67,6 +74,18 @@ func newEdgegridConfig(path, section string, config configBearer) (*edgegrid.Con
 		return validateEdgerc(configEdgerc)
 	}
 
+	sourceConfig, ok, err := sources.resolve(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrWrongEdgeGridConfiguration, err)
+	}
+	if ok {
+		sourceEdgerc, err := sourceConfig.toEdgegridConfig()
+		if err != nil {
+			return nil, fmt.Errorf("%w: incomplete credentials, host, client_token, client_secret and access_token are required", err)
+		}
+		return validateEdgerc(sourceEdgerc)
+	}
+
 	fileEdgerc := &edgegrid.Config{}
 	err = fileEdgerc.FromFile(edgercPathOrDefault(path), edgercSectionOrDefault(section))
 	if err == nil {
@@ -1,6 +1,7 @@
 package akamai
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -33,7 +34,7 @@ func TestNewEdgegridConfig(t *testing.T) {
 		t.Setenv("AKAMAI_CLIENT_TOKEN", clientToken)
 		t.Setenv("AKAMAI_CLIENT_SECRET", clientSecret)
 
-		edgegridConfig, err := newEdgegridConfig("", "", config)
+		edgegridConfig, err := newEdgegridConfig(context.Background(), "", "", config, credentialSources{})
 		require.NoError(t, err)
 		assert.Equal(t, envHost, edgegridConfig.Host)
 	})
@@ -44,7 +45,7 @@ func TestNewEdgegridConfig(t *testing.T) {
 		t.Setenv("AKAMAI_CLIENT_TOKEN", clientToken)
 		t.Setenv("AKAMAI_CLIENT_SECRET", clientSecret)
 
-		edgegridConfig, err := newEdgegridConfig(edgercPath, section, configBearer{})
+		edgegridConfig, err := newEdgegridConfig(context.Background(), edgercPath, section, configBearer{}, credentialSources{})
 		require.NoError(t, err)
 		assert.Equal(t, envHost, edgegridConfig.Host)
 	})
@@ -58,13 +59,13 @@ func TestNewEdgegridConfig(t *testing.T) {
 		t.Setenv(fmt.Sprintf("AKAMAI_%s_CLIENT_TOKEN", testSection), clientToken)
 		t.Setenv(fmt.Sprintf("AKAMAI_%s_CLIENT_SECRET", testSection), clientSecret)
 
-		edgegridConfig, err := newEdgegridConfig("", testSection, config)
+		edgegridConfig, err := newEdgegridConfig(context.Background(), "", testSection, config, credentialSources{})
 		require.NoError(t, err)
 		assert.Equal(t, host, edgegridConfig.Host)
 	})
 
 	t.Run("uses config when provided and env not set", func(t *testing.T) {
-		edgegridConfig, err := newEdgegridConfig("", "", config)
+		edgegridConfig, err := newEdgegridConfig(context.Background(), "", "", config, credentialSources{})
 		require.NoError(t, err)
 		assert.Equal(t, configHost, edgegridConfig.Host)
 	})
@@ -73,19 +74,40 @@ func TestNewEdgegridConfig(t *testing.T) {
 		t.Setenv("AKAMAI_HOST", "env.com")
 		t.Setenv("AKAMAI_ACCESS_TOKEN", accessToken)
 
-		edgegridConfig, err := newEdgegridConfig("", "", config)
+		edgegridConfig, err := newEdgegridConfig(context.Background(), "", "", config, credentialSources{})
 		require.NoError(t, err)
 		assert.Equal(t, configHost, edgegridConfig.Host)
 	})
 
 	t.Run("config is prioritized over edgerc file", func(t *testing.T) {
-		edgegridConfig, err := newEdgegridConfig(edgercPath, section, config)
+		edgegridConfig, err := newEdgegridConfig(context.Background(), edgercPath, section, config, credentialSources{})
 		require.NoError(t, err)
 		assert.Equal(t, configHost, edgegridConfig.Host)
 	})
 
+	t.Run("config is prioritized over credential sources", func(t *testing.T) {
+		sources := credentialSources{process: `echo '{"host": "process.com"}'`}
+		edgegridConfig, err := newEdgegridConfig(context.Background(), edgercPath, section, config, sources)
+		require.NoError(t, err)
+		assert.Equal(t, configHost, edgegridConfig.Host)
+	})
+
+	t.Run("credential source is prioritized over edgerc file", func(t *testing.T) {
+		sources := credentialSources{process: `echo '{"host": "process.com", "client_token": "a", "client_secret": "b", "access_token": "c"}'`}
+		edgegridConfig, err := newEdgegridConfig(context.Background(), edgercPath, section, configBearer{}, sources)
+		require.NoError(t, err)
+		assert.Equal(t, "process.com", edgegridConfig.Host)
+	})
+
+	t.Run("incomplete credentials of credential source", func(t *testing.T) {
+		sources := credentialSources{process: `echo '{"host": "process.com"}'`}
+		_, err := newEdgegridConfig(context.Background(), edgercPath, section, configBearer{}, sources)
+		assert.ErrorIs(t, err, ErrWrongEdgeGridConfiguration)
+		assert.ErrorContains(t, err, "incomplete credentials")
+	})
+
 	t.Run("uses edgerc file when env and config not provided", func(t *testing.T) {
-		edgegridConfig, err := newEdgegridConfig(edgercPath, section, configBearer{})
+		edgegridConfig, err := newEdgegridConfig(context.Background(), edgercPath, section, configBearer{}, credentialSources{})
 		require.NoError(t, err)
 		assert.Equal(t, fileHost, edgegridConfig.Host)
 	})
@@ -94,14 +116,14 @@ func TestNewEdgegridConfig(t *testing.T) {
 		t.Setenv("AKAMAI_HOST", "env.com")
 		t.Setenv("AKAMAI_ACCESS_TOKEN", accessToken)
 
-		edgegridConfig, err := newEdgegridConfig(edgercPath, section, configBearer{})
+		edgegridConfig, err := newEdgegridConfig(context.Background(), edgercPath, section, configBearer{}, credentialSources{})
 		require.NoError(t, err)
 		assert.Equal(t, fileHost, edgegridConfig.Host)
 
 	})
 
 	t.Run("uses default edgerc path and section when none provided", func(t *testing.T) {
-		edgegridConfig, err := newEdgegridConfig("", "", configBearer{})
+		edgegridConfig, err := newEdgegridConfig(context.Background(), "", "", configBearer{}, credentialSources{})
 		require.NoError(t, err)
 		assert.Equal(t, fileHost, edgegridConfig.Host)
 	})
@@ -2,23 +2,34 @@ package akamai
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"strconv"
 	"time"
 
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/tf/validators"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/ratelimit"
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/subprovider"
 	"github.com/akamai/terraform-provider-akamai/v7/version"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 )
 
-var _ provider.Provider = &Provider{}
+var (
+	_ provider.Provider                       = &Provider{}
+	_ provider.ProviderWithEphemeralResources = &Provider{}
+	_ provider.ProviderWithFunctions          = &Provider{}
+)
 
 // Provider is the implementation of akamai terraform provider which uses terraform-plugin-framework
 type Provider struct {
@@ -27,15 +38,33 @@ type Provider struct {
 
 // ProviderModel represents the model of Provider configuration
 type ProviderModel struct {
-	EdgercPath    types.String `tfsdk:"edgerc"`
-	EdgercSection types.String `tfsdk:"config_section"`
-	EdgercConfig  types.Set    `tfsdk:"config"`
-	CacheEnabled  types.Bool   `tfsdk:"cache_enabled"`
-	RequestLimit  types.Int64  `tfsdk:"request_limit"`
-	RetryMax      types.Int64  `tfsdk:"retry_max"`
-	RetryWaitMin  types.Int64  `tfsdk:"retry_wait_min"`
-	RetryWaitMax  types.Int64  `tfsdk:"retry_wait_max"`
-	RetryDisabled types.Bool   `tfsdk:"retry_disabled"`
+	EdgercPath        types.String `tfsdk:"edgerc"`
+	EdgercSection     types.String `tfsdk:"config_section"`
+	EdgercConfig      types.Set    `tfsdk:"config"`
+	CredentialProcess types.String `tfsdk:"credential_process"`
+	CredentialsFD     types.String `tfsdk:"credentials_fd"`
+	VaultSecretPath   types.String `tfsdk:"vault_secret_path"`
+	CacheEnabled      types.Bool   `tfsdk:"cache_enabled"`
+	CacheDir          types.String `tfsdk:"cache_dir"`
+	CacheTTL          types.Int64  `tfsdk:"cache_ttl"`
+	AuditLogPath      types.String `tfsdk:"audit_log_path"`
+	RequestLimit      types.Int64  `tfsdk:"request_limit"`
+	RateLimits        types.Map    `tfsdk:"rate_limits"`
+	RetryMax          types.Int64  `tfsdk:"retry_max"`
+	RetryWaitMin      types.Int64  `tfsdk:"retry_wait_min"`
+	RetryWaitMax      types.Int64  `tfsdk:"retry_wait_max"`
+	RetryDisabled     types.Bool   `tfsdk:"retry_disabled"`
+	RetryPolicy       types.List   `tfsdk:"retry_policy"`
+	GTMPollInterval   types.Int64  `tfsdk:"gtm_poll_interval"`
+	GTMFailOnTimeout  types.Bool   `tfsdk:"gtm_fail_on_timeout"`
+	GTMBatchUpdates   types.Bool   `tfsdk:"gtm_batch_updates"`
+}
+
+// RetryPolicyModel represents the model of retry_policy configuration block
+type RetryPolicyModel struct {
+	Methods     types.Set  `tfsdk:"methods"`
+	StatusCodes types.Set  `tfsdk:"status_codes"`
+	Paths       types.List `tfsdk:"paths"`
 }
 
 // ConfigModel represents the model of edgegrid configuration block
@@ -48,6 +77,12 @@ type ConfigModel struct {
 	AccountKey   types.String `tfsdk:"account_key"`
 }
 
+// NewProtoV6FrameworkProvider returns the protocol version 6 server of the framework provider, which sets
+// the type of the resource in the context of its operations, so that the audit log entries identify it
+func NewProtoV6FrameworkProvider(subproviders []subprovider.Subprovider) func() tfprotov6.ProviderServer {
+	return withAuditResourceTypeServer(providerserver.NewProtocol6(NewFrameworkProvider(subproviders...)()))
+}
+
 // NewFrameworkProvider returns a function returning Provider as provider.Provider
 func NewFrameworkProvider(subproviders ...subprovider.Subprovider) func() provider.Provider {
 	return func() provider.Provider {
@@ -74,13 +109,42 @@ func (p *Provider) Schema(_ context.Context, _ provider.SchemaRequest, resp *pro
 				Description: "The section of the edgerc file to use for configuration",
 				Optional:    true,
 			},
+			"credential_process": schema.StringAttribute{
+				Description: "The command printing the EdgeGrid credentials as JSON to its standard output, used when neither environment variables nor the config block provide them",
+				Optional:    true,
+			},
+			"credentials_fd": schema.StringAttribute{
+				Description: "The file descriptor number (or \"stdin\") from which the EdgeGrid credentials are read as JSON, used when neither environment variables nor the config block provide them",
+				Optional:    true,
+			},
+			"vault_secret_path": schema.StringAttribute{
+				Description: "The path of the Vault secret holding the EdgeGrid credentials (e.g. \"secret/data/akamai\"), read from the address set in VAULT_ADDR with the token set in VAULT_TOKEN",
+				Optional:    true,
+			},
 			"cache_enabled": schema.BoolAttribute{
 				Optional: true,
 			},
+			"cache_dir": schema.StringAttribute{
+				Description: "The directory in which cached API responses are persisted and shared across provider processes, disabled by default",
+				Optional:    true,
+			},
+			"audit_log_path": schema.StringAttribute{
+				Description: "The path of the file to which every API call changing Akamai configurations is appended as a JSON line, disabled by default",
+				Optional:    true,
+			},
+			"cache_ttl": schema.Int64Attribute{
+				Description: "The time in seconds after which API responses persisted in cache_dir expire, by default the TTL of the type of the response, e.g. 1 hour for groups and contracts",
+				Optional:    true,
+			},
 			"request_limit": schema.Int64Attribute{
 				Description: "The maximum number of API requests to be made per second (0 for no limit)",
 				Optional:    true,
 			},
+			"rate_limits": schema.MapAttribute{
+				ElementType: types.Int64Type,
+				Description: "Limits of API requests to be made per second, keyed by the API path prefix (e.g. \"/papi/\"). The limits also follow the X-RateLimit headers returned by the API (0 for no fixed limit)",
+				Optional:    true,
+			},
 			"retry_max": schema.Int64Attribute{
 				Description: "The maximum number retires of API requests, default 10",
 				Optional:    true,
@@ -97,8 +161,42 @@ func (p *Provider) Schema(_ context.Context, _ provider.SchemaRequest, resp *pro
 				Description: "Should the retries of API requests be disabled, default false",
 				Optional:    true,
 			},
+			"gtm_poll_interval": schema.Int64Attribute{
+				Description: "The interval in seconds between the checks of the propagation status of GTM domain changes, default is 5 sec",
+				Optional:    true,
+			},
+			"gtm_fail_on_timeout": schema.BoolAttribute{
+				Description: "Should the GTM resources fail, instead of warning, when their changes are not propagated within their timeouts, default false",
+				Optional:    true,
+			},
+			"gtm_batch_updates": schema.BoolAttribute{
+				Description: "Should the changes of GTM properties, datacenters and geographic maps of the same domain be submitted in a single domain update, default false",
+				Optional:    true,
+			},
 		},
 		Blocks: map[string]schema.Block{
+			"retry_policy": schema.ListNestedBlock{
+				Description: "Additional rules of retrying API requests other than GET, e.g. POST or PUT requests of idempotent API operations",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"methods": schema.SetAttribute{
+							ElementType: types.StringType,
+							Required:    true,
+							Description: "The HTTP methods of the retried requests, e.g. PUT",
+						},
+						"status_codes": schema.SetAttribute{
+							ElementType: types.Int64Type,
+							Required:    true,
+							Description: "The response status codes on which the requests are retried, e.g. 503",
+						},
+						"paths": schema.ListAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Description: "The patterns of the retried request paths, where '*' matches a single path segment, e.g. /config-dns/v2/zones/*/recordsets. All paths are retried if not provided",
+						},
+					},
+				},
+			},
 			"config": schema.SetNestedBlock{
 				NestedObject: schema.NestedBlockObject{
 					Attributes: map[string]schema.Attribute{
@@ -184,7 +282,13 @@ func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest,
 
 	}
 
-	edgegridConfig, err := newEdgegridConfig(data.EdgercPath.ValueString(), data.EdgercSection.ValueString(), edgegridConfigBearer)
+	sources := credentialSources{
+		process:   getFrameworkConfigString(data.CredentialProcess, "AKAMAI_CREDENTIAL_PROCESS"),
+		fd:        getFrameworkConfigString(data.CredentialsFD, "AKAMAI_CREDENTIALS_FD"),
+		vaultPath: getFrameworkConfigString(data.VaultSecretPath, "AKAMAI_VAULT_SECRET_PATH"),
+	}
+
+	edgegridConfig, err := newEdgegridConfig(ctx, data.EdgercPath.ValueString(), data.EdgercSection.ValueString(), edgegridConfigBearer, sources)
 	if err != nil {
 		resp.Diagnostics.Append(diag.NewErrorDiagnostic("configuring context failed", err.Error()))
 		return
@@ -196,6 +300,12 @@ func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest,
 		return
 	}
 
+	rateLimits, err := getFrameworkConfigRateLimits(ctx, data.RateLimits, "AKAMAI_RATE_LIMITS")
+	if err != nil {
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic("configuring context failed", err.Error()))
+		return
+	}
+
 	retryMax, err := getFrameworkConfigInt(data.RetryMax, "AKAMAI_RETRY_MAX")
 	if err != nil {
 		resp.Diagnostics.Append(diag.NewErrorDiagnostic("configuring context failed", err.Error()))
@@ -220,16 +330,56 @@ func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest,
 		return
 	}
 
+	cacheTTL, err := getFrameworkConfigInt(data.CacheTTL, "AKAMAI_CACHE_TTL")
+	if err != nil {
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic("configuring context failed", err.Error()))
+		return
+	}
+
+	retryRules, diags := getFrameworkRetryRules(ctx, data.RetryPolicy)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	gtmPollInterval, err := getFrameworkConfigInt(data.GTMPollInterval, "AKAMAI_GTM_POLL_INTERVAL")
+	if err != nil {
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic("configuring context failed", err.Error()))
+		return
+	}
+
+	gtmFailOnTimeout, err := getFrameworkConfigBool(data.GTMFailOnTimeout, "AKAMAI_GTM_FAIL_ON_TIMEOUT")
+	if err != nil {
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic("configuring context failed", err.Error()))
+		return
+	}
+
+	gtmBatchUpdates, err := getFrameworkConfigBool(data.GTMBatchUpdates, "AKAMAI_GTM_BATCH_UPDATES")
+	if err != nil {
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic("configuring context failed", err.Error()))
+		return
+	}
+
 	meta, err := configureContext(contextConfig{
 		edgegridConfig: edgegridConfig,
 		userAgent:      userAgent(req.TerraformVersion),
 		ctx:            ctx,
 		requestLimit:   requestLimit,
+		rateLimits:     rateLimits,
 		enableCache:    data.CacheEnabled.ValueBool(),
+		cacheDir:       getFrameworkConfigString(data.CacheDir, "AKAMAI_CACHE_DIR"),
+		cacheTTL:       time.Duration(cacheTTL) * time.Second,
+		auditLogPath:   getFrameworkConfigString(data.AuditLogPath, "AKAMAI_AUDIT_LOG_PATH"),
 		retryMax:       retryMax,
 		retryWaitMin:   time.Duration(retryWaitMin) * time.Second,
 		retryWaitMax:   time.Duration(retryWaitMax) * time.Second,
 		retryDisabled:  retryDisabled,
+		retryRules:     retryRules,
+		gtmSettings: meta.GTMSettings{
+			PollInterval:  time.Duration(gtmPollInterval) * time.Second,
+			FailOnTimeout: gtmFailOnTimeout,
+			BatchUpdates:  gtmBatchUpdates,
+		},
 	})
 	if err != nil {
 		resp.Diagnostics.Append(diag.NewErrorDiagnostic("configuring context failed", err.Error()))
@@ -238,6 +388,7 @@ func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest,
 
 	resp.DataSourceData = meta
 	resp.ResourceData = meta
+	resp.EphemeralResourceData = meta
 }
 
 // Resources returns slice of functions used to instantiate resource implementations
@@ -248,7 +399,7 @@ func (p *Provider) Resources(_ context.Context) []func() resource.Resource {
 		resources = append(resources, subprovider.FrameworkResources()...)
 	}
 
-	return resources
+	return withFrameworkResourcesAccountKey(resources)
 }
 
 // DataSources returns slice of functions used to instantiate data source implementations
@@ -259,7 +410,33 @@ func (p *Provider) DataSources(_ context.Context) []func() datasource.DataSource
 		dataSources = append(dataSources, subprovider.FrameworkDataSources()...)
 	}
 
-	return dataSources
+	return withFrameworkDataSourcesAccountKey(dataSources)
+}
+
+// EphemeralResources returns slice of functions used to instantiate ephemeral resource implementations
+func (p *Provider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	ephemeralResources := make([]func() ephemeral.EphemeralResource, 0)
+
+	for _, subprovider := range p.subproviders {
+		ephemeralResources = append(ephemeralResources, subprovider.EphemeralResources()...)
+	}
+
+	return ephemeralResources
+}
+
+// Functions returns slice of functions used to instantiate provider-defined function implementations
+func (p *Provider) Functions(_ context.Context) []func() function.Function {
+	functions := []func() function.Function{
+		NewAddPrefixFunction,
+		NewParseImportIDFunction,
+		NewStripPrefixFunction,
+	}
+
+	for _, subprovider := range p.subproviders {
+		functions = append(functions, subprovider.Functions()...)
+	}
+
+	return functions
 }
 
 func getFrameworkConfigInt(tfValue types.Int64, envKey string) (int, error) {
@@ -276,6 +453,64 @@ func getFrameworkConfigInt(tfValue types.Int64, envKey string) (int, error) {
 	return ret, nil
 }
 
+func getFrameworkRetryRules(ctx context.Context, policies types.List) ([]retryRule, diag.Diagnostics) {
+	if policies.IsNull() {
+		return nil, nil
+	}
+
+	var models []RetryPolicyModel
+	diags := policies.ElementsAs(ctx, &models, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	rules := make([]retryRule, 0, len(models))
+	for _, model := range models {
+		var rule retryRule
+		var statusCodes []int64
+		diags.Append(model.Methods.ElementsAs(ctx, &rule.methods, false)...)
+		diags.Append(model.StatusCodes.ElementsAs(ctx, &statusCodes, false)...)
+		if !model.Paths.IsNull() {
+			diags.Append(model.Paths.ElementsAs(ctx, &rule.paths, false)...)
+		}
+		if diags.HasError() {
+			return nil, diags
+		}
+		for _, code := range statusCodes {
+			rule.statusCodes = append(rule.statusCodes, int(code))
+		}
+		rules = append(rules, rule)
+	}
+	return rules, diags
+}
+
+func getFrameworkConfigRateLimits(ctx context.Context, tfValue types.Map, envKey string) (map[string]int, error) {
+	if tfValue.IsNull() {
+		if v := os.Getenv(envKey); v != "" {
+			return ratelimit.ParseLimits(v)
+		}
+		return nil, nil
+	}
+
+	var values map[string]int64
+	if diags := tfValue.ElementsAs(ctx, &values, false); diags.HasError() {
+		return nil, fmt.Errorf("invalid rate_limits: %v", diags.Errors())
+	}
+
+	limits := make(map[string]int, len(values))
+	for prefix, value := range values {
+		limits[prefix] = int(value)
+	}
+	return limits, nil
+}
+
+func getFrameworkConfigString(tfValue types.String, envKey string) string {
+	if tfValue.IsNull() {
+		return os.Getenv(envKey)
+	}
+	return tfValue.ValueString()
+}
+
 func getFrameworkConfigBool(tfValue types.Bool, envKey string) (bool, error) {
 	ret := tfValue.ValueBool()
 	if tfValue.IsNull() {
@@ -0,0 +1,53 @@
+package akamai
+
+import (
+	"context"
+
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/str"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &addPrefixFunction{}
+
+type addPrefixFunction struct{}
+
+// NewAddPrefixFunction returns a new add_prefix function
+func NewAddPrefixFunction() function.Function {
+	return &addPrefixFunction{}
+}
+
+// Metadata implements function.Function
+func (f *addPrefixFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "add_prefix"
+}
+
+// Definition implements function.Function
+func (f *addPrefixFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Adds a prefix to an ID",
+		Description: "Adds the given prefix (for example `ctr_`) to the ID unless the ID is empty or already starts with it.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "id",
+				Description: "The ID with or without the prefix.",
+			},
+			function.StringParameter{
+				Name:        "prefix",
+				Description: "The prefix to add.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run implements function.Function
+func (f *addPrefixFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var id, prefix string
+
+	resp.Error = req.Arguments.Get(ctx, &id, &prefix)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, str.AddPrefix(id, prefix))
+}
@@ -0,0 +1,51 @@
+package akamai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddPrefixFunction(t *testing.T) {
+	tests := map[string]struct {
+		id       string
+		prefix   string
+		expected string
+	}{
+		"id without prefix": {
+			id:       "1-AB123",
+			prefix:   "ctr_",
+			expected: "ctr_1-AB123",
+		},
+		"id with prefix": {
+			id:       "ctr_1-AB123",
+			prefix:   "ctr_",
+			expected: "ctr_1-AB123",
+		},
+		"empty id": {
+			id:       "",
+			prefix:   "ctr_",
+			expected: "",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(test.id), types.StringValue(test.prefix)}),
+			}
+			resp := function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+
+			NewAddPrefixFunction().Run(context.Background(), req, &resp)
+
+			assert.Nil(t, resp.Error)
+			assert.Equal(t, types.StringValue(test.expected), resp.Result.Value())
+		})
+	}
+}
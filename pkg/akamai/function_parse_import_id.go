@@ -0,0 +1,75 @@
+package akamai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/id"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &parseImportIDFunction{}
+
+type parseImportIDFunction struct{}
+
+// NewParseImportIDFunction returns a new parse_import_id function
+func NewParseImportIDFunction() function.Function {
+	return &parseImportIDFunction{}
+}
+
+// Metadata implements function.Function
+func (f *parseImportIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_import_id"
+}
+
+// Definition implements function.Function
+func (f *parseImportIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Splits a colon-separated import ID into its parts",
+		Description: "Splits a colon-separated import ID (for example `prp_123:ctr_1-AB123:grp_12345`) according to the given format " +
+			"(for example `property_id:contract_id:group_id`) and returns a map from the names used in the format to the parts of the ID.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "id",
+				Description: "The import ID to split.",
+			},
+			function.StringParameter{
+				Name:        "format",
+				Description: "Colon-separated names of the parts of the import ID.",
+			},
+		},
+		Return: function.MapReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+// Run implements function.Function
+func (f *parseImportIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var importID, format string
+
+	resp.Error = req.Arguments.Get(ctx, &importID, &format)
+	if resp.Error != nil {
+		return
+	}
+
+	names := strings.Split(format, ":")
+	parts, err := id.Split(importID, len(names), format)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	result := make(map[string]string, len(names))
+	for i, name := range names {
+		if _, ok := result[name]; ok {
+			resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("format '%s' contains duplicated part name '%s'", format, name))
+			return
+		}
+		result[name] = parts[i]
+	}
+
+	resp.Error = resp.Result.Set(ctx, result)
+}
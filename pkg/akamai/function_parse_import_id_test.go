@@ -0,0 +1,67 @@
+package akamai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseImportIDFunction(t *testing.T) {
+	tests := map[string]struct {
+		id            string
+		format        string
+		expected      map[string]attr.Value
+		expectedError *function.FuncError
+	}{
+		"three parts": {
+			id:     "prp_123:ctr_1-AB123:grp_12345",
+			format: "property_id:contract_id:group_id",
+			expected: map[string]attr.Value{
+				"property_id": types.StringValue("prp_123"),
+				"contract_id": types.StringValue("ctr_1-AB123"),
+				"group_id":    types.StringValue("grp_12345"),
+			},
+		},
+		"single part": {
+			id:     "prp_123",
+			format: "property_id",
+			expected: map[string]attr.Value{
+				"property_id": types.StringValue("prp_123"),
+			},
+		},
+		"wrong number of parts": {
+			id:            "prp_123:ctr_1-AB123",
+			format:        "property_id:contract_id:group_id",
+			expectedError: function.NewArgumentFuncError(0, "id 'prp_123:ctr_1-AB123' is incorrectly formatted: should be of form 'property_id:contract_id:group_id'"),
+		},
+		"duplicated part name": {
+			id:            "prp_123:prp_456",
+			format:        "property_id:property_id",
+			expectedError: function.NewArgumentFuncError(1, "format 'property_id:property_id' contains duplicated part name 'property_id'"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(test.id), types.StringValue(test.format)}),
+			}
+			resp := function.RunResponse{
+				Result: function.NewResultData(types.MapUnknown(types.StringType)),
+			}
+
+			NewParseImportIDFunction().Run(context.Background(), req, &resp)
+
+			if test.expectedError != nil {
+				assert.Equal(t, test.expectedError, resp.Error)
+				return
+			}
+			assert.Nil(t, resp.Error)
+			assert.Equal(t, types.MapValueMust(types.StringType, test.expected), resp.Result.Value())
+		})
+	}
+}
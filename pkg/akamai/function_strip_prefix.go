@@ -0,0 +1,53 @@
+package akamai
+
+import (
+	"context"
+
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/str"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &stripPrefixFunction{}
+
+type stripPrefixFunction struct{}
+
+// NewStripPrefixFunction returns a new strip_prefix function
+func NewStripPrefixFunction() function.Function {
+	return &stripPrefixFunction{}
+}
+
+// Metadata implements function.Function
+func (f *stripPrefixFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "strip_prefix"
+}
+
+// Definition implements function.Function
+func (f *stripPrefixFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Removes a prefix from an ID",
+		Description: "Removes the given prefix (for example `ctr_`) from the ID. The ID is returned unchanged if it does not start with the prefix.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "id",
+				Description: "The ID with or without the prefix.",
+			},
+			function.StringParameter{
+				Name:        "prefix",
+				Description: "The prefix to remove.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run implements function.Function
+func (f *stripPrefixFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var id, prefix string
+
+	resp.Error = req.Arguments.Get(ctx, &id, &prefix)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, str.StripPrefix(id, prefix))
+}
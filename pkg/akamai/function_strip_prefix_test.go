@@ -0,0 +1,51 @@
+package akamai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStripPrefixFunction(t *testing.T) {
+	tests := map[string]struct {
+		id       string
+		prefix   string
+		expected string
+	}{
+		"id with prefix": {
+			id:       "grp_12345",
+			prefix:   "grp_",
+			expected: "12345",
+		},
+		"id without prefix": {
+			id:       "12345",
+			prefix:   "grp_",
+			expected: "12345",
+		},
+		"prefix not at the beginning": {
+			id:       "12345grp_",
+			prefix:   "grp_",
+			expected: "12345grp_",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(test.id), types.StringValue(test.prefix)}),
+			}
+			resp := function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+
+			NewStripPrefixFunction().Run(context.Background(), req, &resp)
+
+			assert.Nil(t, resp.Error)
+			assert.Equal(t, types.StringValue(test.expected), resp.Result.Value())
+		})
+	}
+}
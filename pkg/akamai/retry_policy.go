@@ -0,0 +1,111 @@
+package akamai
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+)
+
+// retryRule describes requests other than GET which are safe to retry, e.g. because the API operation is idempotent
+type retryRule struct {
+	// methods are the HTTP methods of the retried requests
+	methods []string
+	// statusCodes are the response status codes on which the requests are retried
+	statusCodes []int
+	// paths are the patterns (as accepted by path.Match) of the retried request paths, any path matches if empty
+	paths []string
+}
+
+// idempotentRetryRules lists the API operations known to be idempotent, which are always retried on transient server errors
+var idempotentRetryRules = []retryRule{
+	{
+		methods:     []string{http.MethodPut},
+		statusCodes: []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+		paths: []string{
+			"/config-dns/v2/zones/*/recordsets",
+			"/config-dns/v2/zones/*/names/*/types/*",
+			"/config-gtm/v1/domains/*/as-maps/*",
+			"/config-gtm/v1/domains/*/cidr-maps/*",
+			"/config-gtm/v1/domains/*/datacenters/*",
+			"/config-gtm/v1/domains/*/geographic-maps/*",
+			"/config-gtm/v1/domains/*/properties/*",
+			"/config-gtm/v1/domains/*/resources/*",
+		},
+	},
+}
+
+// matches returns whether the rule applies to the request with the given method and path
+func (r retryRule) matches(method, requestPath string) bool {
+	if !slices.Contains(r.methods, method) {
+		return false
+	}
+	if len(r.paths) == 0 {
+		return true
+	}
+	for _, pattern := range r.paths {
+		if ok, _ := path.Match(pattern, requestPath); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func validateRetryRules(rules []retryRule) error {
+	for _, rule := range rules {
+		if len(rule.methods) == 0 {
+			return errors.New("wrong retry policy: at least one method has to be provided")
+		}
+		for _, method := range rule.methods {
+			if method != strings.ToUpper(method) {
+				return fmt.Errorf("wrong retry policy: method %q has to be upper case", method)
+			}
+		}
+		if len(rule.statusCodes) == 0 {
+			return errors.New("wrong retry policy: at least one status code has to be provided")
+		}
+		for _, code := range rule.statusCodes {
+			if code < 100 || code > 599 {
+				return fmt.Errorf("wrong retry policy: invalid status code %d", code)
+			}
+		}
+		for _, pattern := range rule.paths {
+			if !strings.HasPrefix(pattern, "/") {
+				return fmt.Errorf("wrong retry policy: path %q has to start with '/'", pattern)
+			}
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("wrong retry policy: invalid path %q: %w", pattern, err)
+			}
+		}
+	}
+	return nil
+}
+
+// matchingRetryRules returns the rules matching the request which resulted in the given response or error
+func matchingRetryRules(rules []retryRule, resp *http.Response, err error) []retryRule {
+	var method, requestPath string
+	var urlErr *url.Error
+	switch {
+	case resp != nil && resp.Request != nil && resp.Request.URL != nil:
+		method, requestPath = resp.Request.Method, resp.Request.URL.Path
+	case resp == nil && errors.As(err, &urlErr):
+		u, parseErr := url.Parse(urlErr.URL)
+		if parseErr != nil {
+			return nil
+		}
+		method, requestPath = strings.ToUpper(urlErr.Op), u.Path
+	default:
+		return nil
+	}
+
+	var matching []retryRule
+	for _, rule := range rules {
+		if rule.matches(method, requestPath) {
+			matching = append(matching, rule)
+		}
+	}
+	return matching
+}
@@ -0,0 +1,154 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateRetryRules(t *testing.T) {
+	tests := map[string]struct {
+		rules     []retryRule
+		withError string
+	}{
+		"valid rules": {
+			rules: []retryRule{
+				{methods: []string{http.MethodPut}, statusCodes: []int{503}, paths: []string{"/papi/v1/properties/*"}},
+				{methods: []string{http.MethodPost, http.MethodDelete}, statusCodes: []int{502, 504}},
+			},
+		},
+		"default rules": {
+			rules: idempotentRetryRules,
+		},
+		"no methods": {
+			rules:     []retryRule{{statusCodes: []int{503}}},
+			withError: "wrong retry policy: at least one method has to be provided",
+		},
+		"lower case method": {
+			rules:     []retryRule{{methods: []string{"put"}, statusCodes: []int{503}}},
+			withError: `wrong retry policy: method "put" has to be upper case`,
+		},
+		"no status codes": {
+			rules:     []retryRule{{methods: []string{http.MethodPut}}},
+			withError: "wrong retry policy: at least one status code has to be provided",
+		},
+		"invalid status code": {
+			rules:     []retryRule{{methods: []string{http.MethodPut}, statusCodes: []int{1000}}},
+			withError: "wrong retry policy: invalid status code 1000",
+		},
+		"relative path": {
+			rules:     []retryRule{{methods: []string{http.MethodPut}, statusCodes: []int{503}, paths: []string{"papi/*"}}},
+			withError: `wrong retry policy: path "papi/*" has to start with '/'`,
+		},
+		"invalid path pattern": {
+			rules:     []retryRule{{methods: []string{http.MethodPut}, statusCodes: []int{503}, paths: []string{"/papi/["}}},
+			withError: `wrong retry policy: invalid path "/papi/[": syntax error in pattern`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := validateRetryRules(test.rules)
+			if test.withError != "" {
+				assert.EqualError(t, err, test.withError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestOverrideRetryPolicyWithRules(t *testing.T) {
+	basePolicy := func(_ context.Context, _ *http.Response, _ error) (bool, error) {
+		return true, errors.New("base policy: dummy, not implemented")
+	}
+	rules := slices.Concat(idempotentRetryRules, []retryRule{{
+		methods:     []string{http.MethodPost},
+		statusCodes: []int{http.StatusInternalServerError},
+		paths:       []string{"/appsec/v1/configs/*/versions"},
+	}})
+	policy := overrideRetryPolicy(basePolicy, rules)
+
+	tests := map[string]struct {
+		resp           *http.Response
+		err            error
+		expectedResult bool
+		expectedError  string
+	}{
+		"should retry DNS record sets PUT with status 503": {
+			resp: &http.Response{
+				Request:    newRequest(t, http.MethodPut, "/config-dns/v2/zones/example.com/recordsets"),
+				StatusCode: http.StatusServiceUnavailable,
+			},
+			expectedResult: true,
+		},
+		"should retry GTM property PUT with status 504": {
+			resp: &http.Response{
+				Request:    newRequest(t, http.MethodPut, "/config-gtm/v1/domains/example.akadns.net/properties/www"),
+				StatusCode: http.StatusGatewayTimeout,
+			},
+			expectedResult: true,
+		},
+		"should not retry GTM property PUT with status 400": {
+			resp: &http.Response{
+				Request:    newRequest(t, http.MethodPut, "/config-gtm/v1/domains/example.akadns.net/properties/www"),
+				StatusCode: http.StatusBadRequest,
+			},
+			expectedResult: false,
+		},
+		"should not retry GTM property DELETE with status 503": {
+			resp: &http.Response{
+				Request:    newRequest(t, http.MethodDelete, "/config-gtm/v1/domains/example.akadns.net/properties/www"),
+				StatusCode: http.StatusServiceUnavailable,
+			},
+			expectedResult: false,
+		},
+		"should not retry PUT of a path not covered by the rules": {
+			resp: &http.Response{
+				Request:    newRequest(t, http.MethodPut, "/papi/v1/properties/prp_1/versions/1/rules"),
+				StatusCode: http.StatusServiceUnavailable,
+			},
+			expectedResult: false,
+		},
+		"should retry POST covered by the configured rule": {
+			resp: &http.Response{
+				Request:    newRequest(t, http.MethodPost, "/appsec/v1/configs/1/versions"),
+				StatusCode: http.StatusInternalServerError,
+			},
+			expectedResult: true,
+		},
+		"should call base policy on connection error of a request covered by the rules": {
+			err: &url.Error{
+				Op:  "Put",
+				URL: "https://host.example.com/config-dns/v2/zones/example.com/recordsets",
+				Err: errors.New("connection reset by peer"),
+			},
+			expectedError: "base policy: dummy, not implemented",
+		},
+		"should not retry on connection error of a request not covered by the rules": {
+			err: &url.Error{
+				Op:  "Post",
+				URL: "https://host.example.com/papi/v1/properties",
+				Err: errors.New("connection reset by peer"),
+			},
+			expectedResult: false,
+		},
+	}
+
+	for name, tst := range tests {
+		t.Run(name, func(t *testing.T) {
+			shouldRetry, err := policy(context.Background(), tst.resp, tst.err)
+			if len(tst.expectedError) > 0 {
+				assert.ErrorContains(t, err, tst.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tst.expectedResult, shouldRetry)
+			}
+		})
+	}
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/cache"
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/collections"
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/ratelimit"
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/subprovider"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
@@ -32,6 +34,21 @@ func NewSDKProvider(subprovs ...subprovider.Subprovider) plugin.ProviderFunc {
 				Optional:    true,
 				Type:        schema.TypeString,
 			},
+			"credential_process": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: "The command printing the EdgeGrid credentials as JSON to its standard output, used when neither environment variables nor the config block provide them",
+			},
+			"credentials_fd": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: "The file descriptor number (or \"stdin\") from which the EdgeGrid credentials are read as JSON, used when neither environment variables nor the config block provide them",
+			},
+			"vault_secret_path": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: "The path of the Vault secret holding the EdgeGrid credentials (e.g. \"secret/data/akamai\"), read from the address set in VAULT_ADDR with the token set in VAULT_TOKEN",
+			},
 			"config": {
 				Optional:      true,
 				Type:          schema.TypeSet,
@@ -66,15 +83,63 @@ func NewSDKProvider(subprovs ...subprovider.Subprovider) plugin.ProviderFunc {
 					},
 				},
 			},
+			"retry_policy": {
+				Optional:    true,
+				Type:        schema.TypeList,
+				Description: "Additional rules of retrying API requests other than GET, e.g. POST or PUT requests of idempotent API operations",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"methods": {
+							Type:        schema.TypeSet,
+							Required:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The HTTP methods of the retried requests, e.g. PUT",
+						},
+						"status_codes": {
+							Type:        schema.TypeSet,
+							Required:    true,
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+							Description: "The response status codes on which the requests are retried, e.g. 503",
+						},
+						"paths": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The patterns of the retried request paths, where '*' matches a single path segment, e.g. /config-dns/v2/zones/*/recordsets. All paths are retried if not provided",
+						},
+					},
+				},
+			},
 			"cache_enabled": {
 				Optional: true,
 				Type:     schema.TypeBool,
 			},
+			"cache_dir": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: "The directory in which cached API responses are persisted and shared across provider processes, disabled by default",
+			},
+			"audit_log_path": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: "The path of the file to which every API call changing Akamai configurations is appended as a JSON line, disabled by default",
+			},
+			"cache_ttl": {
+				Optional:    true,
+				Type:        schema.TypeInt,
+				Description: "The time in seconds after which API responses persisted in cache_dir expire, by default the TTL of the type of the response, e.g. 1 hour for groups and contracts",
+			},
 			"request_limit": {
 				Optional:    true,
 				Type:        schema.TypeInt,
 				Description: "The maximum number of API requests to be made per second (0 for no limit)",
 			},
+			"rate_limits": {
+				Optional:    true,
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "Limits of API requests to be made per second, keyed by the API path prefix (e.g. \"/papi/\"). The limits also follow the X-RateLimit headers returned by the API (0 for no fixed limit)",
+			},
 			"retry_max": {
 				Optional:    true,
 				Type:        schema.TypeInt,
@@ -95,6 +160,21 @@ func NewSDKProvider(subprovs ...subprovider.Subprovider) plugin.ProviderFunc {
 				Type:        schema.TypeBool,
 				Description: "Should the retries of API requests be disabled, default false",
 			},
+			"gtm_poll_interval": {
+				Optional:    true,
+				Type:        schema.TypeInt,
+				Description: "The interval in seconds between the checks of the propagation status of GTM domain changes, default is 5 sec",
+			},
+			"gtm_fail_on_timeout": {
+				Optional:    true,
+				Type:        schema.TypeBool,
+				Description: "Should the GTM resources fail, instead of warning, when their changes are not propagated within their timeouts, default false",
+			},
+			"gtm_batch_updates": {
+				Optional:    true,
+				Type:        schema.TypeBool,
+				Description: "Should the changes of GTM properties, datacenters and geographic maps of the same domain be submitted in a single domain update, default false",
+			},
 		},
 		ResourcesMap:   make(map[string]*schema.Resource),
 		DataSourcesMap: make(map[string]*schema.Resource),
@@ -110,6 +190,11 @@ func NewSDKProvider(subprovs ...subprovider.Subprovider) plugin.ProviderFunc {
 		}
 	}
 
+	withAccountKey(prov.ResourcesMap, false)
+	withAccountKey(prov.DataSourcesMap, true)
+	withAuditResourceType(prov.ResourcesMap)
+	withAuditResourceType(prov.DataSourcesMap)
+
 	prov.ConfigureContextFunc = configureProviderContext(prov)
 
 	return func() *schema.Provider {
@@ -164,7 +249,18 @@ func configureProviderContext(p *schema.Provider) schema.ConfigureContextFunc {
 			}
 		}
 
-		edgegridConfig, err := newEdgegridConfig(edgercPath, edgercSection, edgegridConfigBearer)
+		var sources credentialSources
+		if sources.process, err = getPluginConfigString(d, "credential_process", "AKAMAI_CREDENTIAL_PROCESS"); err != nil {
+			return nil, diag.FromErr(err)
+		}
+		if sources.fd, err = getPluginConfigString(d, "credentials_fd", "AKAMAI_CREDENTIALS_FD"); err != nil {
+			return nil, diag.FromErr(err)
+		}
+		if sources.vaultPath, err = getPluginConfigString(d, "vault_secret_path", "AKAMAI_VAULT_SECRET_PATH"); err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		edgegridConfig, err := newEdgegridConfig(ctx, edgercPath, edgercSection, edgegridConfigBearer, sources)
 		if err != nil {
 			return nil, diag.FromErr(err)
 		}
@@ -174,6 +270,11 @@ func configureProviderContext(p *schema.Provider) schema.ConfigureContextFunc {
 			return nil, diag.FromErr(err)
 		}
 
+		rateLimits, err := getPluginConfigRateLimits(d, "rate_limits", "AKAMAI_RATE_LIMITS")
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
 		retryMax, err := getPluginConfigInt(d, "retry_max", "AKAMAI_RETRY_MAX")
 		if err != nil {
 			return nil, diag.FromErr(err)
@@ -194,16 +295,61 @@ func configureProviderContext(p *schema.Provider) schema.ConfigureContextFunc {
 			return nil, diag.FromErr(err)
 		}
 
+		cacheDir, err := getPluginConfigString(d, "cache_dir", "AKAMAI_CACHE_DIR")
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		cacheTTL, err := getPluginConfigInt(d, "cache_ttl", "AKAMAI_CACHE_TTL")
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		retryRules, err := getPluginRetryRules(d)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		auditLogPath, err := getPluginConfigString(d, "audit_log_path", "AKAMAI_AUDIT_LOG_PATH")
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		gtmPollInterval, err := getPluginConfigInt(d, "gtm_poll_interval", "AKAMAI_GTM_POLL_INTERVAL")
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		gtmFailOnTimeout, err := getPluginConfigBool(d, "gtm_fail_on_timeout", "AKAMAI_GTM_FAIL_ON_TIMEOUT")
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		gtmBatchUpdates, err := getPluginConfigBool(d, "gtm_batch_updates", "AKAMAI_GTM_BATCH_UPDATES")
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
 		meta, err := configureContext(contextConfig{
 			edgegridConfig: edgegridConfig,
 			userAgent:      userAgent(p.TerraformVersion),
 			ctx:            ctx,
 			requestLimit:   requestLimit,
+			rateLimits:     rateLimits,
 			enableCache:    cacheEnabled,
+			cacheDir:       cacheDir,
+			cacheTTL:       time.Duration(cacheTTL) * time.Second,
+			auditLogPath:   auditLogPath,
 			retryMax:       retryMax,
 			retryWaitMin:   time.Duration(retryWaitMin) * time.Second,
 			retryWaitMax:   time.Duration(retryWaitMax) * time.Second,
 			retryDisabled:  retryDisabled,
+			retryRules:     retryRules,
+			gtmSettings: meta.GTMSettings{
+				PollInterval:  time.Duration(gtmPollInterval) * time.Second,
+				FailOnTimeout: gtmFailOnTimeout,
+				BatchUpdates:  gtmBatchUpdates,
+			},
 		})
 		if err != nil {
 			return nil, diag.FromErr(err)
@@ -240,6 +386,67 @@ func getPluginConfigInt(d *schema.ResourceData, key string, envKey string) (int,
 	return value, nil
 }
 
+func getPluginRetryRules(d *schema.ResourceData) ([]retryRule, error) {
+	policies, err := tf.GetListValue("retry_policy", d)
+	if err != nil && !errors.Is(err, tf.ErrNotFound) {
+		return nil, err
+	}
+
+	rules := make([]retryRule, 0, len(policies))
+	for _, policy := range policies {
+		policyMap, ok := policy.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s, %q", tf.ErrInvalidType, "retry_policy", "map[string]any")
+		}
+		var rule retryRule
+		for _, method := range policyMap["methods"].(*schema.Set).List() {
+			rule.methods = append(rule.methods, method.(string))
+		}
+		for _, code := range policyMap["status_codes"].(*schema.Set).List() {
+			rule.statusCodes = append(rule.statusCodes, code.(int))
+		}
+		for _, path := range policyMap["paths"].([]any) {
+			rule.paths = append(rule.paths, path.(string))
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func getPluginConfigRateLimits(d *schema.ResourceData, key string, envKey string) (map[string]int, error) {
+	values, err := tf.GetMapValue(key, d)
+	if err != nil {
+		if !errors.Is(err, tf.ErrNotFound) {
+			return nil, err
+		}
+		if v := os.Getenv(envKey); v != "" {
+			return ratelimit.ParseLimits(v)
+		}
+		return nil, nil
+	}
+
+	limits := make(map[string]int, len(values))
+	for prefix, value := range values {
+		limit, ok := value.(int)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s, %q", tf.ErrInvalidType, key, "int")
+		}
+		limits[prefix] = limit
+	}
+	return limits, nil
+}
+
+func getPluginConfigString(d *schema.ResourceData, key string, envKey string) (string, error) {
+	value, err := tf.GetStringValue(key, d)
+	if err != nil {
+		if !errors.Is(err, tf.ErrNotFound) {
+			return "", err
+		}
+		value = os.Getenv(envKey)
+	}
+	return value, nil
+}
+
 func getPluginConfigBool(d *schema.ResourceData, key string, envKey string) (bool, error) {
 	value, err := tf.GetBoolValue(key, d)
 	if err != nil {
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/log"
@@ -18,12 +19,32 @@ var (
 	ErrEntryNotFound = errors.New("cache entry not found")
 )
 
-var defaultCache = newCache(10 * time.Minute)
+const (
+	// DefaultMemoryTTL is the time after which entries of buckets without their own TTL expire in memory
+	DefaultMemoryTTL = 10 * time.Minute
 
-type cache struct {
-	cache   *bigcache.BigCache
-	enabled bool
-}
+	// maxMemoryTTL is the time after which entries are evicted from memory regardless of their TTL
+	maxMemoryTTL = 24 * time.Hour
+)
+
+var defaultCache = newCache(maxMemoryTTL)
+
+type (
+	// cache holds the entries of the provider process. Enabling the cache and the file cache may happen while
+	// other goroutines use it (e.g. when several provider instances are configured in parallel), so the switches
+	// are stored atomically.
+	cache struct {
+		cache   *bigcache.BigCache
+		file    atomic.Pointer[fileCache]
+		enabled atomic.Bool
+		now     func() time.Time
+	}
+
+	entry struct {
+		ExpiresAt time.Time       `json:"expiresAt"`
+		Data      json.RawMessage `json:"data"`
+	}
+)
 
 // BucketName can be used as a bucket argument to Set and Get functions
 type BucketName string
@@ -33,6 +54,15 @@ func (b BucketName) Name() string {
 	return string(b)
 }
 
+// AccountBucket returns the bucket of entries specific to the given account switch key, e.g. the lists of
+// all groups or contracts, so that the entries of different accounts managed by one provider are never mixed
+func AccountBucket(name, accountKey string) Bucket {
+	if accountKey == "" {
+		return BucketName(name)
+	}
+	return BucketName(fmt.Sprintf("%s:%s", name, accountKey))
+}
+
 // Bucket defines a contract for a bucket used to form a key
 type Bucket interface {
 	Name() string
@@ -44,24 +74,31 @@ func newCache(eviction time.Duration) *cache {
 		panic(err)
 	}
 
-	return &cache{cache: c}
+	return &cache{cache: c, now: time.Now}
 }
 
 // Enable is used to enable or disable cache
 func Enable(enabled bool) {
-	defaultCache.enabled = enabled
+	defaultCache.enabled.Store(enabled)
 }
 
 // IsEnabled returns whether cache is enabled
 func IsEnabled() bool {
-	return defaultCache.enabled
+	return defaultCache.enabled.Load()
+}
+
+// Reset removes all the entries from memory and detaches the file cache, bringing the cache back to the state
+// of a newly started provider process
+func Reset() error {
+	defaultCache.file.Store(nil)
+	return defaultCache.cache.Reset()
 }
 
 // Set sets the given value under the key in cache
 func Set(bucket Bucket, key string, val any) error {
 	log := log.Get("cache", "CacheSet")
 
-	if !defaultCache.enabled {
+	if !defaultCache.enabled.Load() {
 		log.Debug("cache disabled")
 		return ErrDisabled
 	}
@@ -75,30 +112,104 @@ func Set(bucket Bucket, key string, val any) error {
 
 	log.Debugf("cache set for for key %s [%d bytes]", key, len(data))
 
-	return defaultCache.cache.Set(key, data)
+	if err = setInMemory(key, data, defaultCache.now().Add(memoryTTL(bucket))); err != nil {
+		return err
+	}
+
+	if b, ok := bucket.(BucketWithTTL); ok {
+		// file cache is best effort, failing to persist the entry must not fail the operation
+		if file := defaultCache.file.Load(); file != nil {
+			if err = file.set(b, key, data); err != nil {
+				log.Warnf("file cache set for key %s failed: %s", key, err)
+			}
+		}
+	}
+
+	return nil
 }
 
 // Get returns value stored under the key from cache and writes it into out
 func Get(bucket Bucket, key string, out any) error {
 	log := log.Get("cache", "CacheGet")
 
-	if !defaultCache.enabled {
+	if !defaultCache.enabled.Load() {
 		log.Debug("cache disabled")
 		return ErrDisabled
 	}
 
 	key = fmt.Sprintf("%s:%s", key, bucket.Name())
 
-	data, err := defaultCache.cache.Get(key)
+	data, err := getFromMemory(key)
 	if err != nil {
-		if errors.Is(err, bigcache.ErrEntryNotFound) {
+		if !errors.Is(err, ErrEntryNotFound) {
+			return err
+		}
+		if data, err = getFromFile(bucket, key); err != nil {
 			log.Debugf("cache miss for key %s", key)
-			return ErrEntryNotFound
+			return err
 		}
-		return err
 	}
 
 	log.Debugf("cache get for for key %s: [%d bytes]", key, len(data))
 
 	return json.Unmarshal(data, out)
 }
+
+// memoryTTL returns the time after which entries of the bucket expire in memory
+func memoryTTL(bucket Bucket) time.Duration {
+	if b, ok := bucket.(BucketWithTTL); ok && b.TTL() > 0 {
+		return b.TTL()
+	}
+	return DefaultMemoryTTL
+}
+
+func setInMemory(key string, data []byte, expiresAt time.Time) error {
+	e, err := json.Marshal(entry{ExpiresAt: expiresAt, Data: data})
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache entry: %w", err)
+	}
+	return defaultCache.cache.Set(key, e)
+}
+
+func getFromMemory(key string) ([]byte, error) {
+	raw, err := defaultCache.cache.Get(key)
+	if err != nil {
+		if errors.Is(err, bigcache.ErrEntryNotFound) {
+			return nil, ErrEntryNotFound
+		}
+		return nil, err
+	}
+
+	var e entry
+	if err = json.Unmarshal(raw, &e); err != nil || !defaultCache.now().Before(e.ExpiresAt) {
+		_ = defaultCache.cache.Delete(key)
+		return nil, ErrEntryNotFound
+	}
+	return e.Data, nil
+}
+
+// getFromFile returns the value stored under the key in the file cache and stores it in memory,
+// so that the subsequent reads do not touch the disk. Only entries of buckets with TTL are persisted in the file cache.
+func getFromFile(bucket Bucket, key string) ([]byte, error) {
+	b, ok := bucket.(BucketWithTTL)
+	file := defaultCache.file.Load()
+	if !ok || file == nil {
+		return nil, ErrEntryNotFound
+	}
+
+	data, expiresAt, err := file.get(b, key)
+	if err != nil {
+		if !errors.Is(err, ErrEntryNotFound) {
+			log.Get("cache", "CacheGet").Warnf("file cache get for key %s failed: %s", key, err)
+		}
+		return nil, ErrEntryNotFound
+	}
+
+	if memoryExpiresAt := defaultCache.now().Add(memoryTTL(bucket)); memoryExpiresAt.Before(expiresAt) {
+		expiresAt = memoryExpiresAt
+	}
+	if err = setInMemory(key, data, expiresAt); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
@@ -1,7 +1,9 @@
 package cache
 
 import (
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -43,3 +45,81 @@ func TestCache(t *testing.T) {
 	err = Get(bucket, key, nil)
 	assert.ErrorIs(t, err, ErrDisabled)
 }
+
+func TestCacheExpiration(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	defaultCache.now = func() time.Time { return now }
+	Enable(true)
+	defer func() {
+		defaultCache.now = time.Now
+		Enable(false)
+	}()
+
+	bucket := BucketName("testExpirationBucket")
+	ttlBucket := WithTTL(BucketName("testExpirationTTLBucket"), time.Hour)
+	object := TestObject{"1234"}
+	require.NoError(t, Set(bucket, "key", object))
+	require.NoError(t, Set(ttlBucket, "key", object))
+
+	now = now.Add(DefaultMemoryTTL)
+
+	var out TestObject
+	assert.ErrorIs(t, Get(bucket, "key", &out), ErrEntryNotFound)
+	require.NoError(t, Get(ttlBucket, "key", &out))
+	assert.Equal(t, object, out)
+
+	now = now.Add(time.Hour)
+
+	assert.ErrorIs(t, Get(ttlBucket, "key", &out), ErrEntryNotFound)
+}
+
+func TestReset(t *testing.T) {
+	Enable(true)
+	defer Enable(false)
+
+	bucket := WithTTL(BucketName("testResetBucket"), time.Hour)
+	object := TestObject{"1234"}
+	require.NoError(t, EnableFile(t.TempDir(), "namespace", time.Hour))
+	require.NoError(t, Set(bucket, "key", object))
+
+	require.NoError(t, Reset())
+	assert.False(t, IsFileEnabled())
+
+	var out TestObject
+	assert.ErrorIs(t, Get(bucket, "key", &out), ErrEntryNotFound)
+}
+
+func TestCacheConcurrentConfiguration(t *testing.T) {
+	defer func() {
+		Enable(false)
+		require.NoError(t, EnableFile("", "", 0))
+	}()
+
+	bucket := WithTTL(BucketName("testConcurrentBucket"), time.Hour)
+	dir := t.TempDir()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			Enable(i%2 == 0)
+			assert.NoError(t, EnableFile(dir, "namespace", time.Hour))
+		}()
+		go func() {
+			defer wg.Done()
+			var out TestObject
+			_ = Set(bucket, "key", TestObject{"1234"})
+			_ = Get(bucket, "key", &out)
+			Delete(bucket, "key")
+			_ = IsEnabled()
+			_ = IsFileEnabled()
+		}()
+	}
+	wg.Wait()
+}
+
+func TestAccountBucket(t *testing.T) {
+	assert.Equal(t, "PAPI", AccountBucket("PAPI", "").Name())
+	assert.Equal(t, "PAPI:1-ABC", AccountBucket("PAPI", "1-ABC").Name())
+	assert.NotEqual(t, AccountBucket("PAPI", "1-ABC").Name(), AccountBucket("PAPI", "1-XYZ").Name())
+}
@@ -0,0 +1,78 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/log"
+	"github.com/allegro/bigcache/v2"
+	"golang.org/x/sync/singleflight"
+)
+
+var fetchGroup singleflight.Group
+
+// GetOrFetch returns the value stored under the key in cache. On a cache miss the value is obtained
+// by calling fetch and stored in cache for subsequent calls.
+//
+// Concurrent calls for the same bucket and key share a single call of fetch, so identical requests
+// issued by many resources at once reach the API only once. If cache is disabled, fetch is always called.
+func GetOrFetch[T any](bucket Bucket, key string, fetch func() (T, error)) (T, error) {
+	logger := log.Get("cache", "GetOrFetch")
+
+	var out T
+	err := Get(bucket, key, &out)
+	if err == nil {
+		return out, nil
+	}
+	if errors.Is(err, ErrDisabled) {
+		return fetch()
+	}
+	if !errors.Is(err, ErrEntryNotFound) {
+		logger.Errorf("error reading from cache: %s", err)
+	}
+
+	flightKey := fmt.Sprintf("%s:%s", bucket.Name(), key)
+	val, err, shared := fetchGroup.Do(flightKey, func() (any, error) {
+		// the value could have been stored by a call which completed right before this one started
+		var cached T
+		if err := Get(bucket, key, &cached); err == nil {
+			return cached, nil
+		}
+
+		fetched, err := fetch()
+		if err != nil {
+			return fetched, err
+		}
+
+		if err = Set(bucket, key, fetched); err != nil && !errors.Is(err, ErrDisabled) {
+			logger.Errorf("error storing value into cache: %s", err)
+		}
+		return fetched, nil
+	})
+	if shared {
+		logger.Debugf("shared result of a concurrent request for key %s", flightKey)
+	}
+	if err != nil {
+		return out, err
+	}
+
+	return val.(T), nil
+}
+
+// Delete removes the value stored under the key from cache. It should be used to invalidate
+// entries after operations which change the data they were fetched from.
+func Delete(bucket Bucket, key string) {
+	logger := log.Get("cache", "CacheDelete")
+
+	key = fmt.Sprintf("%s:%s", key, bucket.Name())
+
+	if err := defaultCache.cache.Delete(key); err != nil && !errors.Is(err, bigcache.ErrEntryNotFound) {
+		logger.Warnf("cache delete for key %s failed: %s", key, err)
+	}
+
+	if file := defaultCache.file.Load(); file != nil {
+		if err := file.delete(bucket, key); err != nil {
+			logger.Warnf("file cache delete for key %s failed: %s", key, err)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package cache
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetOrFetch(t *testing.T) {
+	bucket := BucketName("testFetchBucket")
+
+	t.Run("cache disabled always fetches", func(t *testing.T) {
+		Enable(false)
+		var calls int
+		for i := 0; i < 3; i++ {
+			out, err := GetOrFetch(bucket, "disabled", func() (*TestObject, error) {
+				calls++
+				return &TestObject{"1234"}, nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, &TestObject{"1234"}, out)
+		}
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("cache enabled fetches once", func(t *testing.T) {
+		Enable(true)
+		defer Enable(false)
+
+		var calls int
+		for i := 0; i < 3; i++ {
+			out, err := GetOrFetch(bucket, "enabled", func() (*TestObject, error) {
+				calls++
+				return &TestObject{"1234"}, nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, &TestObject{"1234"}, out)
+		}
+		assert.Equal(t, 1, calls)
+
+		Delete(bucket, "enabled")
+		_, err := GetOrFetch(bucket, "enabled", func() (*TestObject, error) {
+			calls++
+			return &TestObject{"1234"}, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		Enable(true)
+		defer Enable(false)
+
+		_, err := GetOrFetch(bucket, "error", func() (*TestObject, error) {
+			return nil, errors.New("oops")
+		})
+		assert.EqualError(t, err, "oops")
+
+		out, err := GetOrFetch(bucket, "error", func() (*TestObject, error) {
+			return &TestObject{"1234"}, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, &TestObject{"1234"}, out)
+	})
+
+	t.Run("concurrent requests are deduplicated", func(t *testing.T) {
+		Enable(true)
+		defer Enable(false)
+
+		var calls atomic.Int32
+		release := make(chan struct{})
+		var wg sync.WaitGroup
+		results := make([]*TestObject, 10)
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				out, err := GetOrFetch(bucket, "concurrent", func() (*TestObject, error) {
+					calls.Add(1)
+					<-release
+					return &TestObject{"1234"}, nil
+				})
+				assert.NoError(t, err)
+				results[i] = out
+			}(i)
+		}
+
+		// give all goroutines time to join the in-flight request
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		assert.Equal(t, int32(1), calls.Load())
+		for _, out := range results {
+			assert.Equal(t, &TestObject{"1234"}, out)
+		}
+	})
+}
@@ -0,0 +1,165 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultFileTTL is the time after which entries stored in the file cache expire, if neither their bucket nor
+// the configuration defines it
+const DefaultFileTTL = 10 * time.Minute
+
+// BucketWithTTL is a Bucket whose entries expire after its own TTL. Only entries of such buckets are persisted
+// in the file cache, entries of other buckets live in memory of a single provider process. It should be used only for
+// read-mostly data, which is not changed by terraform operations, as other processes keep reading the entries until they expire.
+type BucketWithTTL interface {
+	Bucket
+	TTL() time.Duration
+}
+
+type (
+	fileCache struct {
+		dir string
+		ttl time.Duration
+		now func() time.Time
+	}
+
+	ttlBucket struct {
+		Bucket
+		ttl time.Duration
+	}
+)
+
+// WithTTL returns the bucket whose entries expire after ttl and are persisted in the file cache, when it is enabled
+func WithTTL(bucket Bucket, ttl time.Duration) BucketWithTTL {
+	return ttlBucket{Bucket: bucket, ttl: ttl}
+}
+
+// TTL returns the time after which entries of the bucket expire
+func (b ttlBucket) TTL() time.Duration {
+	return b.ttl
+}
+
+// EnableFile configures the file cache that persists entries across provider processes.
+//
+// Entries are stored under dir in a sub-directory specific to the given namespace (e.g. the account the
+// provider is configured for), so that processes configured for different accounts do not share entries.
+// Entries expire after ttl, or the TTL of their bucket if ttl is 0. Passing an empty dir disables the file cache.
+func EnableFile(dir, namespace string, ttl time.Duration) error {
+	if dir == "" {
+		defaultCache.file.Store(nil)
+		return nil
+	}
+	if ttl < 0 {
+		return fmt.Errorf("cache ttl (%v) cannot be negative", ttl)
+	}
+
+	sum := sha256.Sum256([]byte(namespace))
+	dir = filepath.Join(dir, hex.EncodeToString(sum[:8]))
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	defaultCache.file.Store(&fileCache{
+		dir: dir,
+		ttl: ttl,
+		now: time.Now,
+	})
+	return nil
+}
+
+// IsFileEnabled returns whether file cache is enabled
+func IsFileEnabled() bool {
+	return defaultCache.file.Load() != nil
+}
+
+func (f *fileCache) path(bucket Bucket, key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(f.dir, url.PathEscape(bucket.Name()), hex.EncodeToString(sum[:])+".json")
+}
+
+func (f *fileCache) set(bucket BucketWithTTL, key string, data []byte) error {
+	ttl := f.ttl
+	if ttl == 0 {
+		ttl = bucket.TTL()
+	}
+	if ttl <= 0 {
+		ttl = DefaultFileTTL
+	}
+
+	e, err := json.Marshal(entry{
+		ExpiresAt: f.now().Add(ttl),
+		Data:      data,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal file cache entry: %w", err)
+	}
+
+	path := f.path(bucket, key)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	// write to a temporary file first and rename it, so that other provider processes never read partial entries
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create file cache entry: %w", err)
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if _, err = tmp.Write(e); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write file cache entry: %w", err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write file cache entry: %w", err)
+	}
+
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write file cache entry: %w", err)
+	}
+	return nil
+}
+
+// get returns the entry stored under the key and the time at which it expires
+func (f *fileCache) get(bucket Bucket, key string) ([]byte, time.Time, error) {
+	path := f.path(bucket, key)
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, time.Time{}, ErrEntryNotFound
+		}
+		return nil, time.Time{}, fmt.Errorf("failed to read file cache entry: %w", err)
+	}
+
+	var e entry
+	if err = json.Unmarshal(raw, &e); err != nil {
+		// corrupted entries are treated as missing and overwritten on the next set
+		_ = os.Remove(path)
+		return nil, time.Time{}, ErrEntryNotFound
+	}
+
+	if !f.now().Before(e.ExpiresAt) {
+		_ = os.Remove(path)
+		return nil, time.Time{}, ErrEntryNotFound
+	}
+
+	return e.Data, e.ExpiresAt, nil
+}
+
+func (f *fileCache) delete(bucket Bucket, key string) error {
+	if err := os.Remove(f.path(bucket, key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to remove file cache entry: %w", err)
+	}
+	return nil
+}
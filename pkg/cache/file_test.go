@@ -0,0 +1,116 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileCache(t *testing.T) {
+	bucket := WithTTL(BucketName("testFileBucket"), time.Hour)
+	memoryBucket := BucketName("testMemoryBucket")
+	key := "testFileKey"
+	object := TestObject{"1234"}
+
+	dir := t.TempDir()
+	require.NoError(t, EnableFile(dir, "host:account", time.Minute))
+	defer func() {
+		require.NoError(t, EnableFile("", "", 0))
+		Enable(false)
+	}()
+	assert.True(t, IsFileEnabled())
+
+	Enable(true)
+	require.NoError(t, Set(bucket, key, object))
+	require.NoError(t, Set(memoryBucket, key, object))
+
+	// a new in-memory cache simulates another provider process sharing the same directory
+	resetMemory(t)
+
+	var out TestObject
+	require.NoError(t, Get(bucket, key, &out))
+	assert.Equal(t, object, out)
+
+	// entries of buckets without TTL are not persisted
+	assert.ErrorIs(t, Get(memoryBucket, key, &out), ErrEntryNotFound)
+
+	// entries of a different namespace are not shared
+	resetMemory(t)
+	require.NoError(t, EnableFile(dir, "host:other-account", time.Minute))
+	assert.ErrorIs(t, Get(bucket, key, &out), ErrEntryNotFound)
+}
+
+func TestFileCacheExpiration(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	f := &fileCache{
+		dir: t.TempDir(),
+		now: func() time.Time { return now },
+	}
+
+	short := WithTTL(BucketName("testShortBucket"), time.Minute)
+	long := WithTTL(BucketName("testLongBucket"), time.Hour)
+	require.NoError(t, f.set(short, "key", []byte(`"short"`)))
+	require.NoError(t, f.set(long, "key", []byte(`"long"`)))
+
+	data, expiresAt, err := f.get(short, "key")
+	require.NoError(t, err)
+	assert.Equal(t, `"short"`, string(data))
+	assert.Equal(t, now.Add(time.Minute), expiresAt)
+
+	now = now.Add(2 * time.Minute)
+
+	_, _, err = f.get(short, "key")
+	assert.ErrorIs(t, err, ErrEntryNotFound)
+	_, err = os.Stat(f.path(short, "key"))
+	assert.ErrorIs(t, err, os.ErrNotExist, "expired entry should be removed")
+
+	data, _, err = f.get(long, "key")
+	require.NoError(t, err)
+	assert.Equal(t, `"long"`, string(data))
+
+	// the configured TTL overrides the TTL of the bucket
+	f.ttl = 5 * time.Minute
+	require.NoError(t, f.set(long, "key", []byte(`"long"`)))
+	_, expiresAt, err = f.get(long, "key")
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(5*time.Minute), expiresAt)
+}
+
+func TestFileCacheCorruptedEntry(t *testing.T) {
+	f := &fileCache{
+		dir: t.TempDir(),
+		ttl: time.Minute,
+		now: time.Now,
+	}
+
+	bucket := BucketName("testFileBucket")
+	path := f.path(bucket, "key")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	require.NoError(t, os.WriteFile(path, []byte("{not json"), 0600))
+
+	_, _, err := f.get(bucket, "key")
+	assert.ErrorIs(t, err, ErrEntryNotFound)
+}
+
+func TestEnableFile(t *testing.T) {
+	defer func() {
+		require.NoError(t, EnableFile("", "", 0))
+	}()
+
+	require.NoError(t, EnableFile(t.TempDir(), "namespace", time.Minute))
+	assert.Equal(t, time.Minute, defaultCache.file.Load().ttl)
+
+	assert.EqualError(t, EnableFile(t.TempDir(), "namespace", -time.Second), "cache ttl (-1s) cannot be negative")
+
+	require.NoError(t, EnableFile("", "", 0))
+	assert.False(t, IsFileEnabled())
+}
+
+func resetMemory(t *testing.T) {
+	t.Helper()
+	require.NoError(t, defaultCache.cache.Reset())
+}
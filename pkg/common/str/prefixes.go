@@ -16,6 +16,11 @@ func AddPrefix(str, pre string) string {
 	return pre + str
 }
 
+// StripPrefix will remove prefix from given string.
+func StripPrefix(str, pre string) string {
+	return strings.TrimPrefix(str, pre)
+}
+
 // GetIntID is used to get the id out from the string.
 func GetIntID(str, prefix string) (int, error) {
 	return strconv.Atoi(strings.TrimPrefix(str, prefix))
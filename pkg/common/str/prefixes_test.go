@@ -23,6 +23,24 @@ func TestAddPrefix(t *testing.T) {
 	}
 }
 
+func TestStripPrefix(t *testing.T) {
+	tests := map[string]struct {
+		givenStr, givenPrefix, expected string
+	}{
+		"blank string":                   {"", "pre_", ""},
+		"blank prefix":                   {"pre_test", "", "pre_test"},
+		"remove prefix":                  {"pre_test", "pre_", "test"},
+		"no prefix, return string":       {"test", "pre_", "test"},
+		"prefix only once":               {"pre_pre_test", "pre_", "pre_test"},
+		"prefix not at start, unchanged": {"test_pre_", "pre_", "test_pre_"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, StripPrefix(test.givenStr, test.givenPrefix))
+		})
+	}
+}
+
 func TestGetIntID(t *testing.T) {
 	tests := map[string]struct {
 		givenStr, givenPrefix string
@@ -4,6 +4,8 @@ package testprovider
 import (
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/subprovider"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -38,3 +40,13 @@ func (p *mockSubprovider) FrameworkResources() []func() resource.Resource {
 func (p *mockSubprovider) FrameworkDataSources() []func() datasource.DataSource {
 	return []func() datasource.DataSource{}
 }
+
+// EphemeralResources returns the test ephemeral resources implemented using terraform-plugin-framework
+func (p *mockSubprovider) EphemeralResources() []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{}
+}
+
+// Functions returns the test provider-defined functions implemented using terraform-plugin-framework
+func (p *mockSubprovider) Functions() []func() function.Function {
+	return []func() function.Function{}
+}
@@ -4,18 +4,25 @@ import (
 	"context"
 
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/cache"
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/subprovider"
-	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
 )
 
-// NewProtoV6ProviderFactory uses provided subprovider to create provider factory for test purposes
+// NewProtoV6ProviderFactory uses provided subprovider to create provider factory for test purposes.
+// Every provider server starts with an empty cache, as the provider process started by terraform for every command,
+// so that responses mocked in one test or step are never served from the cache in another
 func NewProtoV6ProviderFactory(subproviders ...subprovider.Subprovider) map[string]func() (tfprotov6.ProviderServer, error) {
 	return map[string]func() (tfprotov6.ProviderServer, error){
 		"akamai": func() (tfprotov6.ProviderServer, error) {
 			ctx := context.Background()
 
+			if err := cache.Reset(); err != nil {
+				return nil, err
+			}
+
 			sdkProviderV6, err := akamai.NewProtoV6SDKProvider(subproviders)
 			if err != nil {
 				return nil, err
@@ -23,9 +30,7 @@ func NewProtoV6ProviderFactory(subproviders ...subprovider.Subprovider) map[stri
 
 			providers := []func() tfprotov6.ProviderServer{
 				sdkProviderV6,
-				providerserver.NewProtocol6(
-					akamai.NewFrameworkProvider(subproviders...)(),
-				),
+				akamai.NewProtoV6FrameworkProvider(subproviders),
 			}
 
 			muxServer, err := tf6muxserver.NewMuxServer(ctx, providers...)
@@ -37,3 +42,11 @@ func NewProtoV6ProviderFactory(subproviders ...subprovider.Subprovider) map[stri
 		},
 	}
 }
+
+// NewProtoV6ProviderFactoryWithEcho uses provided subprovider to create provider factory for test purposes
+// and adds the echo provider, which allows to verify values of ephemeral resources
+func NewProtoV6ProviderFactoryWithEcho(subproviders ...subprovider.Subprovider) map[string]func() (tfprotov6.ProviderServer, error) {
+	factories := NewProtoV6ProviderFactory(subproviders...)
+	factories["echo"] = echoprovider.NewProviderServer()
+	return factories
+}
@@ -0,0 +1,47 @@
+package tf
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuoteHCL(t *testing.T) {
+	tests := map[string]struct {
+		value    string
+		expected string
+	}{
+		"plain string":       {"abc", `"abc"`},
+		"empty string":       {"", `""`},
+		"quotes and escapes": {"a \"b\"\\c\n\td\r", `"a \"b\"\\c\n\td\r"`},
+		"template sequences": {"${var.x} %{if} $x %x {{user.PMUSER_X}}", `"$${var.x} %%{if} $x %x {{user.PMUSER_X}}"`},
+		"control characters": {"a\x00b", `"a\u0000b"`},
+		"unicode":            {"zażółć", `"zażółć"`},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, QuoteHCL(test.value))
+		})
+	}
+}
+
+func TestBlockName(t *testing.T) {
+	tests := map[string]struct {
+		value    string
+		expected string
+	}{
+		"name":                    {"my_property", "my_property"},
+		"hostname":                {"www.Example.com", "www_example_com"},
+		"spaces":                  {"Content Compression", "content_compression"},
+		"leading digit":           {"1st rule", "_1st_rule"},
+		"leading dash":            {"-rule", "_-rule"},
+		"empty":                   {"", "_"},
+		"non ASCII letters":       {"reguła", "regu_a"},
+		"hostname with edge host": {"example.com.edgekey.net", "example_com_edgekey_net"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, BlockName(test.value))
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package log
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+type (
+	// AuditLog writes an audit trail of the API calls changing Akamai configurations, as JSON Lines.
+	// It is written independently of the terraform log level.
+	AuditLog struct {
+		path string
+
+		mu   sync.Mutex
+		file *os.File
+	}
+
+	// AuditEntry describes a single API call changing Akamai configuration
+	AuditEntry struct {
+		Time         time.Time         `json:"time"`
+		OperationID  string            `json:"operation_id"`
+		ResourceType string            `json:"resource_type,omitempty"`
+		AccountKey   string            `json:"account_key,omitempty"`
+		Method       string            `json:"method"`
+		Path         string            `json:"path"`
+		AssetIDs     map[string]string `json:"asset_ids,omitempty"`
+		Status       int               `json:"status,omitempty"`
+		Error        string            `json:"error,omitempty"`
+	}
+
+	auditResourceTypeKey struct{}
+)
+
+var (
+	auditLogsMu sync.Mutex
+	// auditLogs holds the audit logs opened by the process, so that both the SDK and framework providers
+	// append to the same file
+	auditLogs = make(map[string]*AuditLog)
+)
+
+// OpenAuditLog returns the audit log appending to the file at the given path, or nil if path is empty
+func OpenAuditLog(path string) (*AuditLog, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	auditLogsMu.Lock()
+	defer auditLogsMu.Unlock()
+
+	if a, ok := auditLogs[path]; ok {
+		return a, nil
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	a := &AuditLog{path: path, file: f}
+	auditLogs[path] = a
+	return a, nil
+}
+
+// Write appends the entry to the audit log
+func (a *AuditLog) Write(entry AuditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to write audit log %s: %w", a.path, err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, err = a.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log %s: %w", a.path, err)
+	}
+	return nil
+}
+
+// WithAuditResourceType returns the context of the operations of the given resource type,
+// which is written into the audit log entries of the API calls made with the context
+func WithAuditResourceType(ctx context.Context, resourceType string) context.Context {
+	return context.WithValue(ctx, auditResourceTypeKey{}, resourceType)
+}
+
+// AuditResourceType returns the resource type set with WithAuditResourceType
+func AuditResourceType(ctx context.Context) string {
+	resourceType, _ := ctx.Value(auditResourceTypeKey{}).(string)
+	return resourceType
+}
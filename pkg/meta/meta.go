@@ -4,6 +4,8 @@ package meta
 import (
 	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	akalog "github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/log"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/session"
@@ -24,6 +26,33 @@ type (
 
 		// Session returns the operation API session
 		Session() session.Session
+
+		// AccountKey returns the account switch key the session is configured for, or an empty string for the
+		// account of the API client
+		AccountKey() string
+
+		// ForAccount returns the meta whose session is configured for the given account switch key.
+		// An empty account key returns the meta of the account configured in the provider.
+		ForAccount(accountKey string) (Meta, error)
+
+		// GTMSettings returns the provider settings of waiting for the propagation of GTM domain changes
+		GTMSettings() GTMSettings
+
+		// OperationValue returns the value stored under key for the operation, storing the one returned by newValue
+		// first if there is none. The values are shared by the metas of all accounts of the operation and are released
+		// together with the operation, i.e. the provider configuration.
+		OperationValue(key any, newValue func() any) any
+	}
+
+	// GTMSettings configures how the GTM resources submit domain changes and wait for their propagation
+	GTMSettings struct {
+		// PollInterval is the interval between the checks of the propagation status, the default is used if zero
+		PollInterval time.Duration
+		// FailOnTimeout makes the operations fail, instead of warning, when the changes are not propagated within their timeouts
+		FailOnTimeout bool
+		// BatchUpdates makes the changes of GTM properties, datacenters and geographic maps of the same domain
+		// be accumulated and submitted in a single domain update
+		BatchUpdates bool
 	}
 
 	// OperationMeta is the implementation of Meta interface
@@ -31,6 +60,30 @@ type (
 		operationID string
 		log         hclog.Logger
 		sess        session.Session
+		accountKey  string
+		gtm         GTMSettings
+		values      *operationValues
+
+		accounts *accountSessions
+	}
+
+	// operationValues holds the values stored by OperationValue
+	operationValues struct {
+		mu     sync.Mutex
+		values map[any]any
+	}
+
+	// SessionFactory creates a new session for the given account switch key
+	SessionFactory func(accountKey string) (session.Session, error)
+
+	// accountSessions holds the sessions created for other accounts, shared by all metas of the operation
+	accountSessions struct {
+		factory SessionFactory
+		// provider is the meta of the account configured in the provider
+		provider *OperationMeta
+
+		mu    sync.Mutex
+		metas map[string]*OperationMeta
 	}
 )
 
@@ -40,19 +93,57 @@ var ErrNilLog = errors.New("nil log argument")
 // ErrNilSession is an error returned from New(...) when session argument is nil
 var ErrNilSession = errors.New("nil session argument")
 
+// ErrAccountsNotSupported is returned from ForAccount when the meta was created without a SessionFactory
+var ErrAccountsNotSupported = errors.New("account_key is not supported by this provider configuration")
+
+// Option configures the OperationMeta created by New
+type Option func(*OperationMeta)
+
+// WithAccountKey sets the account switch key of the meta's session
+func WithAccountKey(accountKey string) Option {
+	return func(m *OperationMeta) {
+		m.accountKey = accountKey
+	}
+}
+
+// WithSessionFactory enables ForAccount, which creates the sessions of other accounts using factory
+func WithSessionFactory(factory SessionFactory) Option {
+	return func(m *OperationMeta) {
+		m.accounts = &accountSessions{
+			factory: factory,
+			metas:   make(map[string]*OperationMeta),
+		}
+	}
+}
+
+// WithGTMSettings sets the settings of waiting for the propagation of GTM domain changes
+func WithGTMSettings(settings GTMSettings) Option {
+	return func(m *OperationMeta) {
+		m.gtm = settings
+	}
+}
+
 // New returns a new OperationMeta
-func New(sess session.Session, log hclog.Logger, operationID string) (*OperationMeta, error) {
+func New(sess session.Session, log hclog.Logger, operationID string, opts ...Option) (*OperationMeta, error) {
 	if log == nil {
 		return nil, ErrNilLog
 	}
 	if sess == nil {
 		return nil, ErrNilSession
 	}
-	return &OperationMeta{
+	m := &OperationMeta{
 		operationID: operationID,
 		sess:        sess,
 		log:         log,
-	}, nil
+		values:      &operationValues{values: make(map[any]any)},
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	if m.accounts != nil {
+		m.accounts.provider = m
+	}
+	return m, nil
 }
 
 // Must performs type assertion on m and panics if m does not hold Meta value
@@ -78,3 +169,65 @@ func (m *OperationMeta) OperationID() string {
 func (m *OperationMeta) Session() session.Session {
 	return m.sess
 }
+
+// AccountKey returns the account switch key of the meta session
+func (m *OperationMeta) AccountKey() string {
+	return m.accountKey
+}
+
+// GTMSettings returns the settings of waiting for the propagation of GTM domain changes
+func (m *OperationMeta) GTMSettings() GTMSettings {
+	return m.gtm
+}
+
+// OperationValue returns the value stored under key for the operation, storing the one returned by newValue first
+func (m *OperationMeta) OperationValue(key any, newValue func() any) any {
+	m.values.mu.Lock()
+	defer m.values.mu.Unlock()
+
+	value, ok := m.values.values[key]
+	if !ok {
+		value = newValue()
+		m.values.values[key] = value
+	}
+	return value
+}
+
+// ForAccount returns the meta of the given account switch key, creating its session on first use
+func (m *OperationMeta) ForAccount(accountKey string) (Meta, error) {
+	if accountKey == m.accountKey {
+		return m, nil
+	}
+	if m.accounts == nil {
+		if accountKey == "" {
+			return m, nil
+		}
+		return nil, ErrAccountsNotSupported
+	}
+	if accountKey == "" || accountKey == m.accounts.provider.accountKey {
+		return m.accounts.provider, nil
+	}
+
+	m.accounts.mu.Lock()
+	defer m.accounts.mu.Unlock()
+
+	if accountMeta, ok := m.accounts.metas[accountKey]; ok {
+		return accountMeta, nil
+	}
+
+	sess, err := m.accounts.factory(accountKey)
+	if err != nil {
+		return nil, fmt.Errorf("creating session for account %q: %w", accountKey, err)
+	}
+	accountMeta := &OperationMeta{
+		operationID: m.operationID,
+		sess:        sess,
+		log:         m.log.With("AccountKey", accountKey),
+		accountKey:  accountKey,
+		gtm:         m.accounts.provider.gtm,
+		values:      m.values,
+		accounts:    m.accounts,
+	}
+	m.accounts.metas[accountKey] = accountMeta
+	return accountMeta, nil
+}
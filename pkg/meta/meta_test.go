@@ -1,7 +1,9 @@
 package meta
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/session"
 	"github.com/hashicorp/go-hclog"
@@ -40,6 +42,89 @@ func TestNew_err(t *testing.T) {
 
 }
 
+func TestForAccount(t *testing.T) {
+	var logger = hclog.New(hclog.DefaultOptions)
+	var created []string
+	factory := func(accountKey string) (session.Session, error) {
+		if accountKey == "invalid" {
+			return nil, errors.New("oops")
+		}
+		created = append(created, accountKey)
+		return session.New()
+	}
+
+	gtmSettings := GTMSettings{PollInterval: 30 * time.Second, FailOnTimeout: true}
+	providerMeta, err := New(session.Must(session.New()), logger, "opID", WithAccountKey("1-ABC"), WithSessionFactory(factory), WithGTMSettings(gtmSettings))
+	require.NoError(t, err)
+	assert.Equal(t, "1-ABC", providerMeta.AccountKey())
+	assert.Equal(t, gtmSettings, providerMeta.GTMSettings())
+
+	t.Run("provider account", func(t *testing.T) {
+		for _, accountKey := range []string{"", "1-ABC"} {
+			m, err := providerMeta.ForAccount(accountKey)
+			require.NoError(t, err)
+			assert.Same(t, providerMeta, m)
+		}
+	})
+	t.Run("other account session is created once", func(t *testing.T) {
+		first, err := providerMeta.ForAccount("1-XYZ")
+		require.NoError(t, err)
+		second, err := providerMeta.ForAccount("1-XYZ")
+		require.NoError(t, err)
+
+		assert.Same(t, first, second)
+		assert.Equal(t, "1-XYZ", first.AccountKey())
+		assert.Equal(t, "opID", first.OperationID())
+		assert.Equal(t, gtmSettings, first.GTMSettings())
+		assert.NotSame(t, providerMeta.Session(), first.Session())
+		assert.Equal(t, []string{"1-XYZ"}, created)
+
+		back, err := first.ForAccount("")
+		require.NoError(t, err)
+		assert.Same(t, providerMeta, back)
+	})
+	t.Run("session error", func(t *testing.T) {
+		_, err := providerMeta.ForAccount("invalid")
+		assert.EqualError(t, err, `creating session for account "invalid": oops`)
+	})
+	t.Run("no session factory", func(t *testing.T) {
+		m, err := New(session.Must(session.New()), logger, "opID")
+		require.NoError(t, err)
+
+		same, err := m.ForAccount("")
+		require.NoError(t, err)
+		assert.Same(t, m, same)
+
+		_, err = m.ForAccount("1-XYZ")
+		assert.ErrorIs(t, err, ErrAccountsNotSupported)
+	})
+}
+
+func TestOperationValue(t *testing.T) {
+	factory := func(string) (session.Session, error) {
+		return session.New()
+	}
+	newMeta := func() *OperationMeta {
+		m, err := New(session.Must(session.New()), hclog.NewNullLogger(), "opID", WithSessionFactory(factory))
+		require.NoError(t, err)
+		return m
+	}
+	type key struct{}
+	counter := func() any {
+		return new(int)
+	}
+
+	m := newMeta()
+	value := m.OperationValue(key{}, counter)
+	assert.Same(t, value, m.OperationValue(key{}, counter))
+
+	accountMeta, err := m.ForAccount("1-XYZ")
+	require.NoError(t, err)
+	assert.Same(t, value, accountMeta.OperationValue(key{}, counter), "accounts of the operation share the values")
+
+	assert.NotSame(t, value, newMeta().OperationValue(key{}, counter), "values are not shared between operations")
+}
+
 func TestMust(t *testing.T) {
 	t.Run("no panic", func(t *testing.T) {
 		var sess = session.Must(session.New())
@@ -10,6 +10,12 @@ import (
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/meta"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	importFormatComment = "comment"
+	importFormatBlock   = "import_block"
 )
 
 func dataSourceExportConfiguration() *schema.Resource {
@@ -32,6 +38,27 @@ func dataSourceExportConfiguration() *schema.Resource {
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Description: "List of template files indicating resources to be exported for later import",
 			},
+			"import_format": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  importFormatComment,
+				ValidateFunc: validation.StringInSlice([]string{
+					importFormatComment,
+					importFormatBlock,
+				}, false),
+				Description: "Format of the import instructions in the output of the TERRAFORM templates: 'comment' for " +
+					"'// terraform import' comments, 'import_block' for import blocks (Terraform 1.5+) with resource addresses " +
+					"derived from the import IDs",
+			},
+			"moved_blocks": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Whether to add moved blocks from the addresses used by the 'comment' format to the addresses " +
+					"of the 'import_block' format. Use it only to migrate resources imported from an export of the same " +
+					"configuration version: the addresses of the 'comment' format depend on the position of the exported items, " +
+					"so after the configuration changed, the moved blocks can move the state of a different object.",
+			},
 			"json": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -51,6 +78,12 @@ func dataSourceExportConfigurationRead(ctx context.Context, d *schema.ResourceDa
 	client := inst.Client(meta)
 	logger := meta.Log("APPSEC", "dataSourceExportConfigurationRead")
 
+	importFormat := d.Get("import_format").(string)
+	movedBlocks := d.Get("moved_blocks").(bool)
+	if movedBlocks && importFormat != importFormatBlock {
+		return diag.Errorf("moved_blocks can only be set with import_format %q", importFormatBlock)
+	}
+
 	configID, err := tf.GetIntValue("config_id", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -90,6 +123,10 @@ func dataSourceExportConfigurationRead(ctx context.Context, d *schema.ResourceDa
 			outputtextresult = outputtextresult + outputtext
 		}
 
+		if importFormat == importFormatBlock {
+			outputtextresult = ConvertImportComments(outputtextresult, movedBlocks)
+		}
+
 		if len(outputtextresult) > 0 {
 			if err := d.Set("output_text", outputtextresult); err != nil {
 				return diag.Errorf("%s: %s", tf.ErrValueSet, err.Error())
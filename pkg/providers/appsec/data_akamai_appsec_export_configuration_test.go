@@ -2,6 +2,7 @@ package appsec
 
 import (
 	"encoding/json"
+	"regexp"
 	"testing"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/appsec"
@@ -44,4 +45,63 @@ func TestAkamaiExportConfiguration_data_basic(t *testing.T) {
 		client.AssertExpectations(t)
 	})
 
+	t.Run("Configuration Export With Import Blocks", func(t *testing.T) {
+		client := &appsec.Mock{}
+
+		getExportConfigurationResponse := appsec.GetExportConfigurationResponse{}
+		err := json.Unmarshal(testutils.LoadFixtureBytes(t, "testdata/TestDSExportConfiguration/ExportConfiguration.json"), &getExportConfigurationResponse)
+		require.NoError(t, err)
+
+		client.On("GetExportConfiguration",
+			testutils.MockContext,
+			appsec.GetExportConfigurationRequest{ConfigID: 43253, Version: 7},
+		).Return(&getExportConfigurationResponse, nil)
+
+		useClient(client, func() {
+			resource.Test(t, resource.TestCase{
+				IsUnitTest:               true,
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config: testutils.LoadFixtureString(t, "testdata/TestDSExportConfiguration/import_block.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("data.akamai_appsec_export_configuration.test", "id", "43253"),
+							resource.TestCheckResourceAttr("data.akamai_appsec_export_configuration.test", "output_text",
+								testutils.LoadFixtureString(t, "testdata/TestDSExportConfiguration/import_block.txt")),
+						),
+					},
+					{
+						Config: testutils.LoadFixtureString(t, "testdata/TestDSExportConfiguration/import_block_moved.tf"),
+						Check: resource.TestCheckResourceAttr("data.akamai_appsec_export_configuration.test", "output_text",
+							testutils.LoadFixtureString(t, "testdata/TestDSExportConfiguration/import_block_moved.txt")),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Import Format", func(t *testing.T) {
+		client := &appsec.Mock{}
+
+		useClient(client, func() {
+			resource.Test(t, resource.TestCase{
+				IsUnitTest:               true,
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config:      testutils.LoadFixtureString(t, "testdata/TestDSExportConfiguration/invalid_import_format.tf"),
+						ExpectError: regexp.MustCompile(`expected import_format to be one of \["comment" "import_block"\], got terraform`),
+					},
+					{
+						Config:      testutils.LoadFixtureString(t, "testdata/TestDSExportConfiguration/moved_blocks_without_import_block.tf"),
+						ExpectError: regexp.MustCompile(`moved_blocks can only be set with import_format "import_block"`),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
 }
@@ -8,6 +8,8 @@ import (
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/meta"
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/subprovider"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -179,3 +181,13 @@ func (p *Subprovider) FrameworkDataSources() []func() datasource.DataSource {
 		NewRapidRulesDataSource,
 	}
 }
+
+// EphemeralResources returns the appsec ephemeral resources implemented using terraform-plugin-framework
+func (p *Subprovider) EphemeralResources() []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{}
+}
+
+// Functions returns the appsec provider-defined functions implemented using terraform-plugin-framework
+func (p *Subprovider) Functions() []func() function.Function {
+	return []func() function.Function{}
+}
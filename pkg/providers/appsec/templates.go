@@ -4,11 +4,13 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 	"text/template"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/appsec"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/tf"
 	"github.com/jedib0t/go-pretty/v6/table"
 )
 
@@ -32,6 +34,9 @@ type (
 )
 
 var (
+	importCommentRegexp   = regexp.MustCompile(`^\s*// terraform import \S+\s+(.*)$`)
+	resourceAddressRegexp = regexp.MustCompile(`^(\s*resource "(\w+)" ")([^"]+)(".*)$`)
+
 	funcs = template.FuncMap{
 		"quote": func(in string) string { return fmt.Sprintf("\"%s\"", in) },
 		"json": func(v interface{}) string {
@@ -298,6 +303,83 @@ func RenderTemplates(ots map[string]*OutputTemplate, key string, str interface{}
 	return "", nil
 }
 
+// ConvertImportComments replaces the '// terraform import' comments of the TERRAFORM templates output with import blocks.
+// The resources are renamed to addresses derived from their import IDs, which, unlike the names used by the templates,
+// do not depend on the order of the exported items.
+//
+// If moved is set, moved blocks are added from the names used by the templates, so that the resources already imported
+// under those names are not recreated. The names used by the templates are indexed by the position of the items in this
+// export, so they match the names of the imported resources only if those were imported from an export of the same
+// configuration version. Otherwise, the moved blocks can move the state of a different object.
+func ConvertImportComments(output string, moved bool) string {
+	type importedResource struct {
+		resourceType, name, legacyName, id string
+	}
+
+	var importID string
+	var resources []importedResource
+	names := make(map[string]bool)
+	lines := strings.Split(output, "\n")
+	converted := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if match := importCommentRegexp.FindStringSubmatch(line); match != nil {
+			// only the last field is the ID, some templates render a stray suffix of the address before it
+			fields := strings.Fields(match[1])
+			if len(fields) > 0 {
+				importID = fields[len(fields)-1]
+			}
+			continue
+		}
+		match := resourceAddressRegexp.FindStringSubmatch(line)
+		if match == nil || importID == "" {
+			converted = append(converted, line)
+			continue
+		}
+
+		resourceType, legacyName := match[2], match[3]
+		name := stableResourceName(resourceType, importID)
+		for i := 2; names[resourceType+"."+name]; i++ {
+			name = fmt.Sprintf("%s_%d", stableResourceName(resourceType, importID), i)
+		}
+		names[resourceType+"."+name] = true
+		resources = append(resources, importedResource{resourceType: resourceType, name: name, legacyName: legacyName, id: importID})
+		converted = append(converted, match[1]+name+match[4])
+		importID = ""
+	}
+
+	if len(resources) == 0 {
+		return output
+	}
+
+	var b strings.Builder
+	b.WriteString(strings.TrimRight(strings.Join(converted, "\n"), " \n"))
+	b.WriteString("\n")
+	for _, r := range resources {
+		fmt.Fprintf(&b, "\nimport {\n  to = %s.%s\n  id = %s\n}\n", r.resourceType, r.name, tf.QuoteHCL(r.id))
+	}
+	if !moved {
+		return b.String()
+	}
+	for _, r := range resources {
+		// a move from an address which is still declared in the output is rejected by terraform
+		if r.legacyName == r.name || names[r.resourceType+"."+r.legacyName] {
+			continue
+		}
+		fmt.Fprintf(&b, "\nmoved {\n  from = %s.%s\n  to   = %s.%s\n}\n", r.resourceType, r.legacyName, r.resourceType, r.name)
+	}
+	return b.String()
+}
+
+// stableResourceName returns the name of the resource of given type built from its import ID without the config ID,
+// e.g. 'rule_aaaa_81230_950002' for 'akamai_appsec_rule' imported with '43253:AAAA_81230:950002'
+func stableResourceName(resourceType, importID string) string {
+	parts := []string{strings.TrimPrefix(resourceType, "akamai_appsec_")}
+	if idParts := strings.Split(importID, ":"); len(idParts) > 1 {
+		parts = append(parts, idParts[1:]...)
+	}
+	return tf.BlockName(strings.Join(parts, "_"))
+}
+
 // InitTemplates populates map of templates given as argument with output templates
 func InitTemplates(otm map[string]*OutputTemplate) {
 	otm["advancedSettingsAttackPayloadLoggingDS"] = &OutputTemplate{TemplateName: "advancedSettingsAttackPayloadLoggingDS", TableTitle: "Enabled|Request Body|Response Body", TemplateType: "TABULAR", TemplateString: "{{.Enabled}}|{{.RequestBody.Type}}|{{.ResponseBody.Type}}"}
@@ -0,0 +1,11 @@
+provider "akamai" {
+  edgerc        = "../../common/testutils/edgerc"
+  cache_enabled = false
+}
+
+data "akamai_appsec_export_configuration" "test" {
+  config_id    = 43253
+  version      = 7
+  search       = ["EvalGroup.tf", "Rule.tf", "AdvancedSettingsLogging.tf"]
+  moved_blocks = true
+}
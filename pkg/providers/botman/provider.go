@@ -9,6 +9,8 @@ import (
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/providers/appsec"
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/subprovider"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -133,3 +135,13 @@ func (p *Subprovider) FrameworkResources() []func() resource.Resource {
 func (p *Subprovider) FrameworkDataSources() []func() datasource.DataSource {
 	return []func() datasource.DataSource{}
 }
+
+// EphemeralResources returns the botman ephemeral resources implemented using terraform-plugin-framework
+func (p *Subprovider) EphemeralResources() []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{}
+}
+
+// Functions returns the botman provider-defined functions implemented using terraform-plugin-framework
+func (p *Subprovider) Functions() []func() function.Function {
+	return []func() function.Function{}
+}
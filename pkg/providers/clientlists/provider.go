@@ -8,6 +8,8 @@ import (
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/meta"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -79,3 +81,13 @@ func (p *Subprovider) FrameworkResources() []func() resource.Resource {
 func (p *Subprovider) FrameworkDataSources() []func() datasource.DataSource {
 	return []func() datasource.DataSource{}
 }
+
+// EphemeralResources returns the clientlists ephemeral resources implemented using terraform-plugin-framework
+func (p *Subprovider) EphemeralResources() []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{}
+}
+
+// Functions returns the clientlists provider-defined functions implemented using terraform-plugin-framework
+func (p *Subprovider) Functions() []func() function.Function {
+	return []func() function.Function{}
+}
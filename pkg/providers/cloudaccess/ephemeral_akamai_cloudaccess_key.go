@@ -0,0 +1,173 @@
+package cloudaccess
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/cloudaccess"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/meta"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = &keyEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &keyEphemeralResource{}
+)
+
+type (
+	keyEphemeralResource struct {
+		meta meta.Meta
+	}
+
+	keyEphemeralModel struct {
+		AccessKeyUID types.Int64           `tfsdk:"access_key_uid"`
+		CredentialsA *ephemeralCredentials `tfsdk:"credentials_a"`
+		CredentialsB *ephemeralCredentials `tfsdk:"credentials_b"`
+	}
+
+	ephemeralCredentials struct {
+		CloudAccessKeyID     types.String `tfsdk:"cloud_access_key_id"`
+		CloudSecretAccessKey types.String `tfsdk:"cloud_secret_access_key"`
+		Version              types.Int64  `tfsdk:"version"`
+		VersionGUID          types.String `tfsdk:"version_guid"`
+	}
+)
+
+// NewKeyEphemeralResource returns a new akamai_cloudaccess_key ephemeral resource.
+func NewKeyEphemeralResource() ephemeral.EphemeralResource {
+	return &keyEphemeralResource{}
+}
+
+func (e *keyEphemeralResource) Metadata(_ context.Context, _ ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = "akamai_cloudaccess_key"
+}
+
+func (e *keyEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			resp.Diagnostics.AddError(
+				"Unexpected Ephemeral Resource Configure Type",
+				fmt.Sprintf("Expected meta.Meta, got: %T. Please report this issue to the provider developers.",
+					req.ProviderData))
+		}
+	}()
+	e.meta = meta.Must(req.ProviderData)
+}
+
+func (e *keyEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Cloud Access Manager access key credentials. The secret access keys are never returned by the API, " +
+			"so they are taken from the configuration, e.g. from an ephemeral resource of the cloud provider, and each pair " +
+			"which is not yet a version of the access key is added as a new version when the ephemeral resource is opened. " +
+			"Neither the secrets nor the versions are persisted in the state.",
+		Attributes: map[string]schema.Attribute{
+			"access_key_uid": schema.Int64Attribute{
+				Required:    true,
+				Description: "Identifier of an existing access key.",
+			},
+			"credentials_a": ephemeralCredentialsAttribute(),
+			"credentials_b": ephemeralCredentialsAttribute(),
+		},
+	}
+}
+
+func ephemeralCredentialsAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Optional:    true,
+		Description: "The combination of a `cloud_access_key_id` and a `cloud_secret_access_key` used to sign API requests. This pair can be identified as access key version. Access key can contain only two access key versions at specific time (defined as credentialsA and credentialsB).",
+		Attributes: map[string]schema.Attribute{
+			"cloud_access_key_id": schema.StringAttribute{
+				Required:    true,
+				Description: "Access key id from cloud provider which is used to sign API requests",
+			},
+			"cloud_secret_access_key": schema.StringAttribute{
+				Required:    true,
+				Sensitive:   true,
+				Description: "Cloud Access secret from cloud provider which is used to sign API requests",
+			},
+			"version": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Numeric access key version associated with specific pair of cloud access credentials used to sign API requests",
+			},
+			"version_guid": schema.StringAttribute{
+				Computed:    true,
+				Description: "The unique identifier assigned to specific access key version",
+			},
+		},
+	}
+}
+
+func (e *keyEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	tflog.Debug(ctx, "Cloud Access Key Ephemeral Resource Open")
+
+	var data keyEphemeralModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if data.CredentialsA == nil && data.CredentialsB == nil {
+		resp.Diagnostics.AddError("at least one credentials are required", "`credentials_a` or `credentials_b` must be specified")
+		return
+	}
+
+	client := Client(e.meta)
+	versions, err := client.ListAccessKeyVersions(ctx, cloudaccess.ListAccessKeyVersionsRequest{
+		AccessKeyUID: data.AccessKeyUID.ValueInt64(),
+	})
+	if errors.Is(err, cloudaccess.ErrAccessKeyNotFound) {
+		resp.Diagnostics.AddError("get access key error", fmt.Sprintf(diagErrAccessKeyNotFound, data.AccessKeyUID.ValueInt64()))
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("list access key versions failed", err.Error())
+		return
+	}
+
+	for _, creds := range []*ephemeralCredentials{data.CredentialsA, data.CredentialsB} {
+		if creds == nil {
+			continue
+		}
+		resp.Diagnostics.Append(e.ensureVersion(ctx, data.AccessKeyUID.ValueInt64(), creds, versions.AccessKeyVersions)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, data)...)
+}
+
+// ensureVersion fills the version of the given credentials, creating it first if the access key does not have
+// a version with the same cloud access key id yet.
+func (e *keyEphemeralResource) ensureVersion(ctx context.Context, accessKeyUID int64, creds *ephemeralCredentials, versions []cloudaccess.AccessKeyVersion) diag.Diagnostics {
+	for _, version := range versions {
+		if version.CloudAccessKeyID != nil && *version.CloudAccessKeyID == creds.CloudAccessKeyID.ValueString() {
+			creds.Version = types.Int64Value(version.Version)
+			creds.VersionGUID = types.StringValue(version.VersionGUID)
+			return nil
+		}
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Creating version of access key %d for cloud access key id %s", accessKeyUID, creds.CloudAccessKeyID.ValueString()))
+	key := &KeyResource{meta: e.meta}
+	created, diags := key.createVersion(ctx, &KeyResourceModel{
+		AccessKeyUID: types.Int64Value(accessKeyUID),
+		CredentialsA: &Credentials{
+			CloudAccessKeyID:     creds.CloudAccessKeyID,
+			CloudSecretAccessKey: creds.CloudSecretAccessKey,
+		},
+	}, true)
+	if diags.HasError() {
+		return diags
+	}
+	creds.Version = created.CredentialsA.Version
+	creds.VersionGUID = created.CredentialsA.VersionGUID
+	return diags
+}
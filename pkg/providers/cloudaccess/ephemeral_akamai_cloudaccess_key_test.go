@@ -0,0 +1,152 @@
+package cloudaccess
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/cloudaccess"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/ptr"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/testutils"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestEphemeralKey(t *testing.T) {
+	createdTime := time.Date(2024, 1, 10, 11, 9, 10, 67708, time.UTC)
+
+	expectListVersions := func(m *cloudaccess.Mock) {
+		m.On("ListAccessKeyVersions", testutils.MockContext, cloudaccess.ListAccessKeyVersionsRequest{
+			AccessKeyUID: 12345,
+		}).Return(&cloudaccess.ListAccessKeyVersionsResponse{AccessKeyVersions: []cloudaccess.AccessKeyVersion{
+			{
+				AccessKeyUID:     12345,
+				CloudAccessKeyID: ptr.To("test_key_id"),
+				CreatedBy:        "dev-user",
+				CreatedTime:      createdTime,
+				DeploymentStatus: cloudaccess.Active,
+				Version:          1,
+				VersionGUID:      "asde-efdr-reded",
+			},
+		}}, nil)
+	}
+
+	credentialsACheck := statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("credentials_a"), knownvalue.ObjectExact(map[string]knownvalue.Check{
+		"cloud_access_key_id":     knownvalue.StringExact("test_key_id"),
+		"cloud_secret_access_key": knownvalue.StringExact("test_secret"),
+		"version":                 knownvalue.Int64Exact(1),
+		"version_guid":            knownvalue.StringExact("asde-efdr-reded"),
+	}))
+
+	tests := map[string]struct {
+		init       func(*cloudaccess.Mock)
+		configPath string
+		check      []statecheck.StateCheck
+		error      *regexp.Regexp
+	}{
+		"existing version": {
+			init:       expectListVersions,
+			configPath: "testdata/TestEphemeralKey/existing_version.tf",
+			check: []statecheck.StateCheck{
+				credentialsACheck,
+				statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("credentials_b"), knownvalue.Null()),
+			},
+		},
+		"new version": {
+			init: func(m *cloudaccess.Mock) {
+				expectListVersions(m)
+				m.On("CreateAccessKeyVersion", testutils.MockContext, cloudaccess.CreateAccessKeyVersionRequest{
+					AccessKeyUID: 12345,
+					Body: cloudaccess.CreateAccessKeyVersionRequestBody{
+						CloudAccessKeyID:     "test_key_id_2",
+						CloudSecretAccessKey: "test_secret_2",
+					},
+				}).Return(&cloudaccess.CreateAccessKeyVersionResponse{RequestID: 124, RetryAfter: 1}, nil)
+				m.On("GetAccessKeyVersionStatus", testutils.MockContext, cloudaccess.GetAccessKeyVersionStatusRequest{RequestID: 124}).
+					Return(&cloudaccess.GetAccessKeyVersionStatusResponse{
+						AccessKeyVersion: &cloudaccess.KeyVersion{AccessKeyUID: 12345, Version: 2},
+						ProcessingStatus: cloudaccess.ProcessingDone,
+						RequestDate:      createdTime,
+						RequestedBy:      "dev-user",
+					}, nil)
+				m.On("GetAccessKeyVersion", testutils.MockContext, cloudaccess.GetAccessKeyVersionRequest{AccessKeyUID: 12345, Version: 2}).
+					Return(&cloudaccess.GetAccessKeyVersionResponse{
+						AccessKeyUID:     12345,
+						CloudAccessKeyID: ptr.To("test_key_id_2"),
+						CreatedBy:        "dev-user",
+						CreatedTime:      createdTime,
+						DeploymentStatus: cloudaccess.Active,
+						Version:          2,
+						VersionGUID:      "asdd-ads-dasdas",
+					}, nil)
+			},
+			configPath: "testdata/TestEphemeralKey/new_version.tf",
+			check: []statecheck.StateCheck{
+				credentialsACheck,
+				statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("credentials_b"), knownvalue.ObjectExact(map[string]knownvalue.Check{
+					"cloud_access_key_id":     knownvalue.StringExact("test_key_id_2"),
+					"cloud_secret_access_key": knownvalue.StringExact("test_secret_2"),
+					"version":                 knownvalue.Int64Exact(2),
+					"version_guid":            knownvalue.StringExact("asdd-ads-dasdas"),
+				})),
+			},
+		},
+		"error no credentials": {
+			configPath: "testdata/TestEphemeralKey/no_credentials.tf",
+			error:      regexp.MustCompile("`credentials_a` or `credentials_b` must be specified"),
+		},
+		"error access key not found": {
+			init: func(m *cloudaccess.Mock) {
+				m.On("ListAccessKeyVersions", testutils.MockContext, cloudaccess.ListAccessKeyVersionsRequest{
+					AccessKeyUID: 12345,
+				}).Return(nil, fmt.Errorf("%w: not found", cloudaccess.ErrAccessKeyNotFound)).Once()
+			},
+			configPath: "testdata/TestEphemeralKey/existing_version.tf",
+			error:      regexp.MustCompile("cannot find access key: 12345"),
+		},
+		"error creating version": {
+			init: func(m *cloudaccess.Mock) {
+				expectListVersions(m)
+				m.On("CreateAccessKeyVersion", testutils.MockContext, cloudaccess.CreateAccessKeyVersionRequest{
+					AccessKeyUID: 12345,
+					Body: cloudaccess.CreateAccessKeyVersionRequestBody{
+						CloudAccessKeyID:     "test_key_id_2",
+						CloudSecretAccessKey: "test_secret_2",
+					},
+				}).Return(nil, fmt.Errorf("create version failed")).Once()
+			},
+			configPath: "testdata/TestEphemeralKey/new_version.tf",
+			error:      regexp.MustCompile("create version failed"),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &cloudaccess.Mock{}
+			if tc.init != nil {
+				tc.init(client)
+			}
+
+			useClient(client, func() {
+				resource.UnitTest(t, resource.TestCase{
+					ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactoryWithEcho(NewSubprovider()),
+					TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+						tfversion.SkipBelow(tfversion.Version1_10_0),
+					},
+					Steps: []resource.TestStep{
+						{
+							Config:            testutils.LoadFixtureString(t, tc.configPath),
+							ConfigStateChecks: tc.check,
+							ExpectError:       tc.error,
+						},
+					},
+				})
+			})
+			client.AssertExpectations(t)
+		})
+	}
+}
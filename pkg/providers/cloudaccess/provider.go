@@ -6,6 +6,8 @@ import (
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/meta"
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/subprovider"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -59,3 +61,15 @@ func (p *Subprovider) FrameworkDataSources() []func() datasource.DataSource {
 		NewKeyVersionsDataSource,
 	}
 }
+
+// EphemeralResources returns the cloudaccess ephemeral resources implemented using terraform-plugin-framework
+func (p *Subprovider) EphemeralResources() []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewKeyEphemeralResource,
+	}
+}
+
+// Functions returns the cloudaccess provider-defined functions implemented using terraform-plugin-framework
+func (p *Subprovider) Functions() []func() function.Function {
+	return []func() function.Function{}
+}
@@ -0,0 +1,17 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+ephemeral "akamai_cloudaccess_key" "test" {
+  access_key_uid = 12345
+  credentials_a = {
+    cloud_access_key_id     = "test_key_id"
+    cloud_secret_access_key = "test_secret"
+  }
+}
+
+provider "echo" {
+  data = ephemeral.akamai_cloudaccess_key.test
+}
+
+resource "echo" "test" {}
@@ -0,0 +1,13 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+ephemeral "akamai_cloudaccess_key" "test" {
+  access_key_uid = 12345
+}
+
+provider "echo" {
+  data = ephemeral.akamai_cloudaccess_key.test
+}
+
+resource "echo" "test" {}
@@ -3,6 +3,8 @@ package cloudlets
 
 import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
@@ -85,3 +87,13 @@ func (p *Subprovider) FrameworkDataSources() []func() datasource.DataSource {
 		NewSharedPolicyDataSource,
 	}
 }
+
+// EphemeralResources returns the cloudlets ephemeral resources implemented using terraform-plugin-framework
+func (p *Subprovider) EphemeralResources() []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{}
+}
+
+// Functions returns the cloudlets provider-defined functions implemented using terraform-plugin-framework
+func (p *Subprovider) Functions() []func() function.Function {
+	return []func() function.Function{}
+}
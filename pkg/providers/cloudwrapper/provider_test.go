@@ -9,6 +9,8 @@ import (
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/akamai"
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/testutils"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
@@ -104,6 +106,14 @@ func (ts *TestSubprovider) FrameworkDataSources() []func() datasource.DataSource
 	return ts.datasources
 }
 
+func (ts *TestSubprovider) EphemeralResources() []func() ephemeral.EphemeralResource {
+	return nil
+}
+
+func (ts *TestSubprovider) Functions() []func() function.Function {
+	return nil
+}
+
 func TestMain(m *testing.M) {
 	testutils.TestRunner(m)
 }
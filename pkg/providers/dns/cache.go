@@ -0,0 +1,35 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/dns"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/cache"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/meta"
+)
+
+// Groups and authoritative name servers do not change during terraform operations, while configurations
+// with many zones look them up for every zone. Reading them through the cache sends a single request instead.
+
+// cacheTTL is the time after which the cached groups and name servers expire
+const cacheTTL = time.Hour
+
+var cacheBucket = cache.WithTTL(cache.BucketName("DNS"), cacheTTL)
+
+// listGroupsCached returns the groups available to the account of meta, reading them from cache if possible
+func listGroupsCached(ctx context.Context, meta meta.Meta) (*dns.ListGroupResponse, error) {
+	return cache.GetOrFetch(cache.WithTTL(cache.AccountBucket("DNS", meta.AccountKey()), cacheTTL), "ListGroups", func() (*dns.ListGroupResponse, error) {
+		return inst.Client(meta).ListGroups(ctx, dns.ListGroupRequest{})
+	})
+}
+
+// getNameServerRecordListCached returns the authoritative name servers of the given contracts, reading them from cache if possible
+func getNameServerRecordListCached(ctx context.Context, client dns.DNS, contractIDs string) ([]string, error) {
+	return cache.GetOrFetch(cacheBucket, fmt.Sprintf("GetNameServerRecordList:%s", contractIDs), func() ([]string, error) {
+		return client.GetNameServerRecordList(ctx, dns.GetNameServerRecordListRequest{
+			ContractIDs: contractIDs,
+		})
+	})
+}
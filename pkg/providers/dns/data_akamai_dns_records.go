@@ -0,0 +1,312 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/dns"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/providers/dns/internal/txtrecord"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/providers/dns/internal/zonefile"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type (
+	recordsDataSource struct {
+		meta meta.Meta
+	}
+
+	recordsDataSourceModel struct {
+		Zone        types.String      `tfsdk:"zone"`
+		RecordTypes types.Set         `tfsdk:"record_types"`
+		NamePattern types.String      `tfsdk:"name_pattern"`
+		MinTTL      types.Int64       `tfsdk:"min_ttl"`
+		MaxTTL      types.Int64       `tfsdk:"max_ttl"`
+		PageSize    types.Int64       `tfsdk:"page_size"`
+		RecordSets  []recordSetsModel `tfsdk:"record_sets"`
+	}
+
+	// recordSetsModel represents a record set of the zone
+	recordSetsModel struct {
+		Name       types.String   `tfsdk:"name"`
+		RecordType types.String   `tfsdk:"record_type"`
+		TTL        types.Int64    `tfsdk:"ttl"`
+		Rdata      types.List     `tfsdk:"rdata"`
+		Records    []recordsModel `tfsdk:"records"`
+	}
+
+	// recordsModel represents the structured data of a single record of a record set
+	recordsModel struct {
+		Rdata            types.String `tfsdk:"rdata"`
+		Fields           types.Map    `tfsdk:"fields"`
+		CharacterStrings types.List   `tfsdk:"character_strings"`
+	}
+)
+
+var (
+	_ datasource.DataSource              = &recordsDataSource{}
+	_ datasource.DataSourceWithConfigure = &recordsDataSource{}
+)
+
+// defaultRecordSetsPageSize is the number of record sets read with each request, if not configured
+const defaultRecordSetsPageSize = 100
+
+// NewRecordsDataSource returns a new zone record sets data source
+func NewRecordsDataSource() datasource.DataSource { return &recordsDataSource{} }
+
+func (d *recordsDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = "akamai_dns_records"
+}
+
+func (d *recordsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			resp.Diagnostics.AddError(
+				"Unexpected Data Source Configure Type",
+				fmt.Sprintf("Expected meta.Meta, got: %T. Please report this issue to the provider developers.",
+					req.ProviderData))
+		}
+	}()
+	d.meta = meta.Must(req.ProviderData)
+}
+
+func (d *recordsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Record sets of a zone data source, optionally filtered by type, name and TTL.",
+		Attributes: map[string]schema.Attribute{
+			"zone": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the zone.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"record_types": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "The types of the record sets to return, e.g. A or MX. All types are returned if not set.",
+			},
+			"name_pattern": schema.StringAttribute{
+				Optional: true,
+				Description: "The pattern the names of the record sets have to match, case insensitive, " +
+					"with `*` matching any sequence of characters and `?` matching a single character, e.g. `*.api.example.com`.",
+			},
+			"min_ttl": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The minimum TTL of the record sets to return.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_ttl": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The maximum TTL of the record sets to return.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"page_size": schema.Int64Attribute{
+				Optional:    true,
+				Description: fmt.Sprintf("The number of record sets read with each API request, default is %d.", defaultRecordSetsPageSize),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"record_sets": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The record sets of the zone matching the filters, ordered by name and type.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The fully qualified name of the records.",
+						},
+						"record_type": schema.StringAttribute{
+							Computed:    true,
+							Description: "The type of the records.",
+						},
+						"ttl": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The TTL of the record set.",
+						},
+						"rdata": schema.ListAttribute{
+							Computed:    true,
+							ElementType: types.StringType,
+							Description: "The data of the records, as returned by Edge DNS.",
+						},
+						"records": schema.ListNestedAttribute{
+							Computed:    true,
+							Description: "The structured data of each record of the record set.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"rdata": schema.StringAttribute{
+										Computed:    true,
+										Description: "The data of the record.",
+									},
+									"fields": schema.MapAttribute{
+										Computed:    true,
+										ElementType: types.StringType,
+										Description: "The type specific fields of the record, named as the arguments of " +
+											"the akamai_dns_record resource, e.g. priority, weight, port and target of SRV records.",
+									},
+									"character_strings": schema.ListAttribute{
+										Computed:    true,
+										ElementType: types.StringType,
+										Description: "The decoded character strings of TXT and SPF records.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *recordsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "DNS Records DataSource Read")
+
+	var data recordsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	namePattern := strings.ToLower(data.NamePattern.ValueString())
+	if _, err := path.Match(namePattern, ""); err != nil {
+		resp.Diagnostics.AddError("invalid name_pattern: ", err.Error())
+		return
+	}
+	if !data.MinTTL.IsNull() && !data.MaxTTL.IsNull() && data.MinTTL.ValueInt64() > data.MaxTTL.ValueInt64() {
+		resp.Diagnostics.AddError("invalid TTL range: ",
+			fmt.Sprintf("min_ttl %d is greater than max_ttl %d", data.MinTTL.ValueInt64(), data.MaxTTL.ValueInt64()))
+		return
+	}
+	var recordTypes []string
+	resp.Diagnostics.Append(data.RecordTypes.ElementsAs(ctx, &recordTypes, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for i, recordType := range recordTypes {
+		recordTypes[i] = strings.ToUpper(recordType)
+	}
+	slices.Sort(recordTypes)
+	pageSize := defaultRecordSetsPageSize
+	if !data.PageSize.IsNull() {
+		pageSize = int(data.PageSize.ValueInt64())
+	}
+
+	client := inst.Client(d.meta)
+	zoneName := data.Zone.ValueString()
+	var recordSets []dns.RecordSet
+	for page := 1; ; page++ {
+		recordSetsResp, err := client.GetRecordSets(ctx, dns.GetRecordSetsRequest{
+			Zone: zoneName,
+			QueryArgs: &dns.RecordSetQueryArgs{
+				Page:     page,
+				PageSize: pageSize,
+				SortBy:   "name,type",
+				Types:    strings.Join(recordTypes, ","),
+			},
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("fetching DNS record sets failed: ", err.Error())
+			return
+		}
+		recordSets = append(recordSets, recordSetsResp.RecordSets...)
+		if page >= recordSetsResp.Metadata.LastPage {
+			break
+		}
+	}
+
+	var filtered []dns.RecordSet
+	for _, recordSet := range recordSets {
+		if namePattern != "" {
+			if ok, _ := path.Match(namePattern, strings.ToLower(recordSet.Name)); !ok {
+				continue
+			}
+		}
+		if !data.MinTTL.IsNull() && int64(recordSet.TTL) < data.MinTTL.ValueInt64() {
+			continue
+		}
+		if !data.MaxTTL.IsNull() && int64(recordSet.TTL) > data.MaxTTL.ValueInt64() {
+			continue
+		}
+		filtered = append(filtered, recordSet)
+	}
+
+	resp.Diagnostics.Append(data.setAttributes(ctx, filtered)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (m *recordsDataSourceModel) setAttributes(ctx context.Context, recordSets []dns.RecordSet) diag.Diagnostics {
+	m.RecordSets = make([]recordSetsModel, 0, len(recordSets))
+	for _, recordSet := range recordSets {
+		rdata, diags := types.ListValueFrom(ctx, types.StringType, recordSet.Rdata)
+		if diags.HasError() {
+			return diags
+		}
+		model := recordSetsModel{
+			Name:       types.StringValue(recordSet.Name),
+			RecordType: types.StringValue(recordSet.Type),
+			TTL:        types.Int64Value(int64(recordSet.TTL)),
+			Rdata:      rdata,
+			Records:    make([]recordsModel, 0, len(recordSet.Rdata)),
+		}
+		for _, r := range recordSet.Rdata {
+			record, diags := newRecordsModel(ctx, m.Zone.ValueString(), recordSet.Type, r)
+			if diags.HasError() {
+				return diags
+			}
+			model.Records = append(model.Records, record)
+		}
+		m.RecordSets = append(m.RecordSets, model)
+	}
+	return nil
+}
+
+func newRecordsModel(ctx context.Context, zone, recordType, rdata string) (recordsModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	record := recordsModel{
+		Rdata:            types.StringValue(rdata),
+		Fields:           types.MapNull(types.StringType),
+		CharacterStrings: types.ListNull(types.StringType),
+	}
+
+	// the data which cannot be parsed is still returned as rdata
+	if fields, err := zonefile.Fields(recordType, rdata, zone); err == nil {
+		record.Fields, diags = types.MapValueFrom(ctx, types.StringType, fields)
+		if diags.HasError() {
+			return record, diags
+		}
+	} else {
+		tflog.Warn(ctx, "could not parse record data", map[string]any{"type": recordType, "rdata": rdata, "error": err.Error()})
+	}
+
+	if recordType == RRTypeTxt || recordType == RRTypeSpf {
+		if characterStrings, err := txtrecord.CharacterStrings(rdata); err == nil {
+			record.CharacterStrings, diags = types.ListValueFrom(ctx, types.StringType, characterStrings)
+		} else {
+			tflog.Warn(ctx, "could not parse record data", map[string]any{"type": recordType, "rdata": rdata, "error": err.Error()})
+		}
+	}
+	return record, diags
+}
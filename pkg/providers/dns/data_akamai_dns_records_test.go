@@ -0,0 +1,127 @@
+package dns
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/dns"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/testutils"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDataDnsRecords(t *testing.T) {
+	anyContext := mock.AnythingOfType("*context.valueCtx")
+	srv := dns.RecordSet{Name: "_sip._tcp.example.com", Type: "SRV", TTL: 600, Rdata: []string{"10 60 5060 sip.example.com."}}
+	txt := dns.RecordSet{Name: "_dmarc._tcp.example.com", Type: "TXT", TTL: 300, Rdata: []string{`"v=DMARC1;" "p=none"`}}
+	shortTTL := dns.RecordSet{Name: "_ldap._tcp.example.com", Type: "SRV", TTL: 60, Rdata: []string{"0 0 389 ldap.example.com."}}
+	mx := dns.RecordSet{Name: "example.com", Type: "MX", TTL: 3600, Rdata: []string{"10 mail.example.com.", "20 mail2.example.com."}}
+
+	tests := map[string]struct {
+		givenTF            string
+		init               func(mock *dns.Mock)
+		expectedAttributes map[string]string
+		expectedError      *regexp.Regexp
+	}{
+		"all record sets read in pages": {
+			givenTF: "all.tf",
+			init: func(m *dns.Mock) {
+				m.On("GetRecordSets", anyContext, dns.GetRecordSetsRequest{
+					Zone:      "example.com",
+					QueryArgs: &dns.RecordSetQueryArgs{Page: 1, PageSize: 2, SortBy: "name,type"},
+				}).Return(&dns.GetRecordSetsResponse{
+					Metadata:   dns.Metadata{Page: 1, PageSize: 2, LastPage: 2},
+					RecordSets: []dns.RecordSet{mx, txt},
+				}, nil)
+				m.On("GetRecordSets", anyContext, dns.GetRecordSetsRequest{
+					Zone:      "example.com",
+					QueryArgs: &dns.RecordSetQueryArgs{Page: 2, PageSize: 2, SortBy: "name,type"},
+				}).Return(&dns.GetRecordSetsResponse{
+					Metadata:   dns.Metadata{Page: 2, PageSize: 2, LastPage: 2},
+					RecordSets: []dns.RecordSet{srv},
+				}, nil)
+			},
+			expectedAttributes: map[string]string{
+				"record_sets.#":                               "3",
+				"record_sets.0.name":                          "example.com",
+				"record_sets.0.record_type":                   "MX",
+				"record_sets.0.ttl":                           "3600",
+				"record_sets.0.rdata.#":                       "2",
+				"record_sets.0.records.#":                     "2",
+				"record_sets.0.records.1.rdata":               "20 mail2.example.com.",
+				"record_sets.0.records.1.fields.priority":     "20",
+				"record_sets.0.records.1.fields.target":       "mail2.example.com.",
+				"record_sets.0.records.1.character_strings.#": "0",
+				"record_sets.1.record_type":                   "TXT",
+				"record_sets.1.records.0.character_strings.#": "2",
+				"record_sets.1.records.0.character_strings.0": "v=DMARC1;",
+				"record_sets.1.records.0.character_strings.1": "p=none",
+				"record_sets.2.record_type":                   "SRV",
+				"record_sets.2.records.0.fields.port":         "5060",
+			},
+		},
+		"filtered record sets": {
+			givenTF: "filtered.tf",
+			init: func(m *dns.Mock) {
+				m.On("GetRecordSets", anyContext, dns.GetRecordSetsRequest{
+					Zone:      "example.com",
+					QueryArgs: &dns.RecordSetQueryArgs{Page: 1, PageSize: 100, SortBy: "name,type", Types: "SRV,TXT"},
+				}).Return(&dns.GetRecordSetsResponse{
+					Metadata:   dns.Metadata{Page: 1, PageSize: 100, LastPage: 1},
+					RecordSets: []dns.RecordSet{txt, shortTTL, srv},
+				}, nil)
+			},
+			expectedAttributes: map[string]string{
+				"record_sets.#":           "2",
+				"record_sets.0.name":      "_dmarc._tcp.example.com",
+				"record_sets.1.name":      "_sip._tcp.example.com",
+				"record_sets.1.ttl":       "600",
+				"record_sets.1.records.#": "1",
+			},
+		},
+		"error response from api": {
+			givenTF: "all.tf",
+			init: func(m *dns.Mock) {
+				m.On("GetRecordSets", anyContext, mock.AnythingOfType("dns.GetRecordSetsRequest")).
+					Return(nil, errors.New("API error"))
+			},
+			expectedError: regexp.MustCompile("API error"),
+		},
+		"invalid TTL range": {
+			givenTF:       "invalid_ttl_range.tf",
+			expectedError: regexp.MustCompile("min_ttl 3600 is greater than max_ttl 300"),
+		},
+		"invalid name pattern": {
+			givenTF:       "invalid_name_pattern.tf",
+			expectedError: regexp.MustCompile("invalid name_pattern"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &dns.Mock{}
+			if test.init != nil {
+				test.init(client)
+			}
+			var checkFuncs []resource.TestCheckFunc
+			for k, v := range test.expectedAttributes {
+				checkFuncs = append(checkFuncs, resource.TestCheckResourceAttr("data.akamai_dns_records.test", k, v))
+			}
+
+			useClient(client, func() {
+				resource.Test(t, resource.TestCase{
+					IsUnitTest:               true,
+					ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+					Steps: []resource.TestStep{{
+						Config:      testutils.LoadFixtureStringf(t, "testdata/TestDataDnsRecords/%s", test.givenTF),
+						Check:       resource.ComposeAggregateTestCheckFunc(checkFuncs...),
+						ExpectError: test.expectedError,
+					}},
+				})
+			})
+
+			client.AssertExpectations(t)
+		})
+	}
+}
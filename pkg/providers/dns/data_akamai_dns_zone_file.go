@@ -0,0 +1,231 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/dns"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/providers/dns/internal/zonefile"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type (
+	zoneFileDataSource struct {
+		meta meta.Meta
+	}
+
+	zoneFileDataSourceModel struct {
+		Zone       types.String      `tfsdk:"zone"`
+		Content    types.String      `tfsdk:"content"`
+		DefaultTTL types.Int64       `tfsdk:"default_ttl"`
+		Records    []zoneFileRecords `tfsdk:"records"`
+		Rendered   types.String      `tfsdk:"rendered"`
+	}
+
+	// zoneFileRecords represents a record set read from a zone file
+	zoneFileRecords struct {
+		Name       types.String `tfsdk:"name"`
+		RecordType types.String `tfsdk:"record_type"`
+		TTL        types.Int64  `tfsdk:"ttl"`
+		Target     types.List   `tfsdk:"target"`
+		Fields     types.Map    `tfsdk:"fields"`
+	}
+)
+
+var (
+	_ datasource.DataSource              = &zoneFileDataSource{}
+	_ datasource.DataSourceWithConfigure = &zoneFileDataSource{}
+)
+
+// NewZoneFileDataSource returns a new zone file data source
+func NewZoneFileDataSource() datasource.DataSource { return &zoneFileDataSource{} }
+
+func (d *zoneFileDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = "akamai_dns_zone_file"
+}
+
+func (d *zoneFileDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			resp.Diagnostics.AddError(
+				"Unexpected Data Source Configure Type",
+				fmt.Sprintf("Expected meta.Meta, got: %T. Please report this issue to the provider developers.",
+					req.ProviderData))
+		}
+	}()
+	d.meta = meta.Must(req.ProviderData)
+}
+
+func (d *zoneFileDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Zone file (RFC 1035 master file) data source. Parses a zone file into Edge DNS record sets, " +
+			"or reads the zone file of an existing zone to compare it with other DNS providers.",
+		Attributes: map[string]schema.Attribute{
+			"zone": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the zone. It is the origin of the relative names in the zone file.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"content": schema.StringAttribute{
+				Optional: true,
+				Description: "The content of the zone file. If not provided, the zone file of the zone " +
+					"is read from Edge DNS.",
+			},
+			"default_ttl": schema.Int64Attribute{
+				Optional: true,
+				Description: "The TTL of the records without TTL in the zone file, " +
+					"if the zone file does not have the $TTL directive.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"records": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The record sets of the zone file, in the order of their first appearance.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The fully qualified name of the records.",
+						},
+						"record_type": schema.StringAttribute{
+							Computed:    true,
+							Description: "The type of the records.",
+						},
+						"ttl": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The TTL of the record set.",
+						},
+						"target": schema.ListAttribute{
+							Computed:    true,
+							ElementType: types.StringType,
+							Description: "The data of the records, with the domain names fully qualified.",
+						},
+						"fields": schema.MapAttribute{
+							Computed:    true,
+							ElementType: types.ListType{ElemType: types.StringType},
+							Description: "The type specific fields of the records, named as the arguments of " +
+								"the akamai_dns_record resource, e.g. priority and port of SRV records. Each field " +
+								"holds one value per record, in the order of target, empty if the record does not have the field.",
+						},
+					},
+				},
+			},
+			"rendered": schema.StringAttribute{
+				Computed: true,
+				Description: "The records in canonical zone file format: with fully qualified names, " +
+					"the SOA and NS records of the zone first and the other records ordered by name and type.",
+			},
+		},
+	}
+}
+
+func (d *zoneFileDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "DNS ZoneFile DataSource Read")
+
+	var data zoneFileDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := inst.Client(d.meta)
+	zoneName := data.Zone.ValueString()
+	content := data.Content.ValueString()
+	if data.Content.IsNull() {
+		var err error
+		content, err = client.GetMasterZoneFile(ctx, dns.GetMasterZoneFileRequest{Zone: zoneName})
+		if err != nil {
+			resp.Diagnostics.AddError("fetching DNS zone file failed: ", err.Error())
+			return
+		}
+	}
+
+	recordSets, err := zonefile.Parse(content, zoneName, int(data.DefaultTTL.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError("parsing DNS zone file failed: ", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(data.setAttributes(ctx, client, recordSets)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (m *zoneFileDataSourceModel) setAttributes(ctx context.Context, client dns.DNS, recordSets []zonefile.RecordSet) diag.Diagnostics {
+	m.Records = make([]zoneFileRecords, 0, len(recordSets))
+	for _, recordSet := range recordSets {
+		target, diags := types.ListValueFrom(ctx, types.StringType, recordSet.Rdata)
+		if diags.HasError() {
+			return diags
+		}
+
+		fieldsValue, diags := types.MapValueFrom(ctx, types.ListType{ElemType: types.StringType}, recordFields(ctx, client, recordSet))
+		if diags.HasError() {
+			return diags
+		}
+
+		m.Records = append(m.Records, zoneFileRecords{
+			Name:       types.StringValue(recordSet.Name),
+			RecordType: types.StringValue(recordSet.Type),
+			TTL:        types.Int64Value(int64(recordSet.TTL)),
+			Target:     target,
+			Fields:     fieldsValue,
+		})
+	}
+	m.Rendered = types.StringValue(zonefile.Render(m.Zone.ValueString(), recordSets))
+	return nil
+}
+
+// recordFields returns the values of the type specific fields of each record of the record set. ParseRData splits
+// the rdata into the fields of the akamai_dns_record resource, but only reads them from the first record, so it is
+// called for every record separately.
+func recordFields(ctx context.Context, client dns.DNS, recordSet zonefile.RecordSet) map[string][]string {
+	fields := make(map[string][]string)
+	for i, rdata := range recordSet.Rdata {
+		for name, value := range client.ParseRData(ctx, recordSet.Type, []string{rdata}) {
+			if name == "target" {
+				continue
+			}
+			if _, ok := fields[name]; !ok {
+				fields[name] = make([]string, len(recordSet.Rdata))
+			}
+			fields[name][i] = fieldValue(value)
+		}
+	}
+	return fields
+}
+
+// fieldValue renders the value of a field returned by ParseRData, joining the elements of list values with spaces
+// as they appear in the rdata
+func fieldValue(value any) string {
+	switch v := value.(type) {
+	case []string:
+		return strings.Join(v, " ")
+	case []any:
+		elems := make([]string, 0, len(v))
+		for _, elem := range v {
+			elems = append(elems, fmt.Sprint(elem))
+		}
+		return strings.Join(elems, " ")
+	default:
+		return fmt.Sprint(v)
+	}
+}
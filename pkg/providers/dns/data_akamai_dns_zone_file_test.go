@@ -0,0 +1,164 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/dns"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/testutils"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDataDnsZoneFile(t *testing.T) {
+	anyContext := mock.AnythingOfType("*context.valueCtx")
+	dnsClient := dns.Client(session.Must(session.New()))
+
+	expectParseRData := func(m *dns.Mock, recordType string, rdata []string) {
+		m.On("ParseRData", anyContext, recordType, rdata).
+			Return(dnsClient.ParseRData(context.Background(), recordType, rdata))
+	}
+	soa := []string{"ns1.example.com. hostmaster.example.com. 2024010101 3600 900 604800 300"}
+	ns := []string{"ns1.example.com."}
+	cname := []string{"example.com."}
+	srv := []string{"10 60 5060 sip.example.com."}
+	rendered := `$ORIGIN example.com.
+example.com.	300	IN	SOA	ns1.example.com. hostmaster.example.com. 2024010101 3600 900 604800 300
+example.com.	300	IN	NS	ns1.example.com.
+_sip._tcp.example.com.	600	IN	SRV	10 60 5060 sip.example.com.
+www.example.com.	300	IN	CNAME	example.com.
+`
+
+	tests := map[string]struct {
+		givenTF            string
+		init               func(mock *dns.Mock)
+		expectedAttributes map[string]string
+		expectedError      *regexp.Regexp
+	}{
+		"zone file content": {
+			givenTF: "content.tf",
+			init: func(m *dns.Mock) {
+				expectParseRData(m, "SOA", soa)
+				expectParseRData(m, "NS", ns)
+				expectParseRData(m, "CNAME", cname)
+				expectParseRData(m, "SRV", srv)
+			},
+			expectedAttributes: map[string]string{
+				"records.#":                      "4",
+				"records.0.name":                 "example.com",
+				"records.0.record_type":          "SOA",
+				"records.0.ttl":                  "300",
+				"records.0.target.#":             "1",
+				"records.0.target.0":             soa[0],
+				"records.0.fields.serial.0":      "2024010101",
+				"records.0.fields.name_server.0": "ns1.example.com.",
+				"records.0.fields.refresh.0":     "3600",
+				"records.1.record_type":          "NS",
+				"records.1.target.0":             "ns1.example.com.",
+				"records.1.fields.%":             "0",
+				"records.2.name":                 "www.example.com",
+				"records.2.record_type":          "CNAME",
+				"records.2.ttl":                  "300",
+				"records.3.name":                 "_sip._tcp.example.com",
+				"records.3.ttl":                  "600",
+				"records.3.fields.priority.0":    "10",
+				"records.3.fields.weight.0":      "60",
+				"records.3.fields.port.0":        "5060",
+				"rendered":                       rendered,
+			},
+		},
+		"multi-value records": {
+			givenTF: "multi_value.tf",
+			init: func(m *dns.Mock) {
+				expectParseRData(m, "SVCB", []string{"1 svc1.example.com. alpn=h2,h3 port=8443"})
+				expectParseRData(m, "SVCB", []string{"2 svc2.example.com."})
+				expectParseRData(m, "TXT", []string{`"v=spf1" "-all"`})
+				expectParseRData(m, "TXT", []string{`"second"`})
+			},
+			expectedAttributes: map[string]string{
+				"records.#":                       "2",
+				"records.0.record_type":           "SVCB",
+				"records.0.target.#":              "2",
+				"records.0.fields.%":              "3",
+				"records.0.fields.svc_priority.#": "2",
+				"records.0.fields.svc_priority.0": "1",
+				"records.0.fields.svc_priority.1": "2",
+				"records.0.fields.target_name.0":  "svc1.example.com.",
+				"records.0.fields.target_name.1":  "svc2.example.com.",
+				"records.0.fields.svc_params.#":   "2",
+				"records.0.fields.svc_params.0":   "alpn=h2,h3 port=8443",
+				"records.0.fields.svc_params.1":   "",
+				"records.1.record_type":           "TXT",
+				"records.1.target.#":              "2",
+				"records.1.target.0":              `"v=spf1" "-all"`,
+				"records.1.target.1":              `"second"`,
+				"records.1.fields.%":              "0",
+			},
+		},
+		"zone file of existing zone": {
+			givenTF: "zone.tf",
+			init: func(m *dns.Mock) {
+				m.On("GetMasterZoneFile", anyContext, dns.GetMasterZoneFileRequest{Zone: "example.com"}).
+					Return(rendered, nil)
+				expectParseRData(m, "SOA", soa)
+				expectParseRData(m, "NS", ns)
+				expectParseRData(m, "SRV", srv)
+				expectParseRData(m, "CNAME", cname)
+			},
+			expectedAttributes: map[string]string{
+				"records.#":             "4",
+				"records.2.record_type": "SRV",
+				"records.2.ttl":         "600",
+				"records.3.record_type": "CNAME",
+				"records.3.ttl":         "300",
+				"rendered":              rendered,
+			},
+		},
+		"error response from api": {
+			givenTF: "zone.tf",
+			init: func(m *dns.Mock) {
+				m.On("GetMasterZoneFile", anyContext, dns.GetMasterZoneFileRequest{Zone: "example.com"}).
+					Return("", errors.New("API error"))
+			},
+			expectedError: regexp.MustCompile("API error"),
+		},
+		"invalid zone file": {
+			givenTF:       "invalid_content.tf",
+			expectedError: regexp.MustCompile("line 1: record type DNAME is not supported by Edge DNS"),
+		},
+		"missing required argument zone": {
+			givenTF:       "missing_zone.tf",
+			expectedError: regexp.MustCompile(`The argument "zone" is required, but no definition was found.`),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &dns.Mock{}
+			if test.init != nil {
+				test.init(client)
+			}
+			var checkFuncs []resource.TestCheckFunc
+			for k, v := range test.expectedAttributes {
+				checkFuncs = append(checkFuncs, resource.TestCheckResourceAttr("data.akamai_dns_zone_file.test", k, v))
+			}
+
+			useClient(client, func() {
+				resource.Test(t, resource.TestCase{
+					IsUnitTest:               true,
+					ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+					Steps: []resource.TestStep{{
+						Config:      testutils.LoadFixtureStringf(t, "testdata/TestDataDnsZoneFile/%s", test.givenTF),
+						Check:       resource.ComposeAggregateTestCheckFunc(checkFuncs...),
+						ExpectError: test.expectedError,
+					}},
+				})
+			})
+
+			client.AssertExpectations(t)
+		})
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"sort"
 	"strings"
 
-	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/dns"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/session"
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/tf"
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/meta"
@@ -50,9 +49,7 @@ func dataSourceAuthoritiesSetRead(ctx context.Context, d *schema.ResourceData, m
 
 	logger.Debug("Start Searching for authority records", "contractid", contractID)
 
-	ns, err := inst.Client(meta).GetNameServerRecordList(ctx, dns.GetNameServerRecordListRequest{
-		ContractIDs: contractID,
-	})
+	ns, err := getNameServerRecordListCached(ctx, inst.Client(meta), contractID)
 	if err != nil {
 		return append(diags, diag.Diagnostic{
 			Severity: diag.Error,
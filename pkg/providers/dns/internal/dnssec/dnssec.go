@@ -0,0 +1,102 @@
+// Package dnssec implements the Edge DNS key rollover operation, which is not implemented by the edgegrid dns package yet.
+//
+// The rollover is started with POST /config-dns/v2/zones/{zone}/key-rollover, without a body. Edge DNS accepts it with
+// 202 Accepted, or 204 No Content, and generates the new keys asynchronously; they are returned as the new records of
+// the DNSSEC status of the zone (POST /config-dns/v2/zones/dns-sec-status). Any other status is an Edge DNS error.
+// The endpoint is not part of the published Edge DNS API reference yet, so the akamai_dns_zone_dnssec resource
+// only calls it when AKAMAI_DNS_KSK_ROLLOVER_ENABLED is set.
+package dnssec
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/dns"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/session"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// DNSSec is the Edge DNS key rollover API interface
+	DNSSec interface {
+		// RolloverKSK starts a rollover of the key signing key (KSK) of the signed zone. The new keys are generated
+		// with the current algorithm of the zone, and returned as the new records of the DNSSEC status of the zone
+		// until the rollover completes.
+		RolloverKSK(ctx context.Context, params RolloverKSKRequest) error
+	}
+
+	dnsSec struct {
+		session.Session
+	}
+
+	// RolloverKSKRequest contains parameters required to start a KSK rollover of a zone
+	RolloverKSKRequest struct {
+		Zone string
+	}
+)
+
+var (
+	// ErrRolloverKSK is returned when RolloverKSK fails
+	ErrRolloverKSK = errors.New("starting KSK rollover")
+)
+
+// Client returns a new key rollover client with the given session
+func Client(sess session.Session) DNSSec {
+	return &dnsSec{Session: sess}
+}
+
+// Validate validates RolloverKSKRequest
+func (r RolloverKSKRequest) Validate() error {
+	return validation.Errors{
+		"Zone": validation.Validate(r.Zone, validation.Required),
+	}.Filter()
+}
+
+func (d *dnsSec) RolloverKSK(ctx context.Context, params RolloverKSKRequest) error {
+	if err := params.Validate(); err != nil {
+		return fmt.Errorf("%s: %w: %s", ErrRolloverKSK, dns.ErrStructValidation, err)
+	}
+
+	logger := d.Log(ctx)
+	logger.Debug("RolloverKSK")
+
+	rolloverURL := fmt.Sprintf("/config-dns/v2/zones/%s/key-rollover", url.PathEscape(params.Zone))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rolloverURL, nil)
+	if err != nil {
+		return fmt.Errorf("%w: failed to create request: %s", ErrRolloverKSK, err)
+	}
+
+	resp, err := d.Exec(req, nil)
+	if err != nil {
+		return fmt.Errorf("%w: request failed: %s", ErrRolloverKSK, err)
+	}
+	defer session.CloseResponseBody(resp)
+
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("%w: %w", ErrRolloverKSK, d.error(resp))
+	}
+
+	return nil
+}
+
+// error parses the Edge DNS error from the response
+func (d *dnsSec) error(r *http.Response) error {
+	e := dns.Error{StatusCode: r.StatusCode}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		e.Title = "Failed to read error body"
+		e.Detail = err.Error()
+		return &e
+	}
+	if err := json.Unmarshal(body, &e); err != nil {
+		e.Title = "Failed to unmarshal error body. DNS API failed. Check details for more information."
+		e.Detail = string(body)
+	}
+	e.StatusCode = r.StatusCode
+	return &e
+}
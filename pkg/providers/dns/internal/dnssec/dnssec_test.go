@@ -0,0 +1,96 @@
+package dnssec
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/dns"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/edgegrid"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockAPIClient(t *testing.T, mockServer *httptest.Server) DNSSec {
+	serverURL, err := url.Parse(mockServer.URL)
+	require.NoError(t, err)
+	certPool := x509.NewCertPool()
+	certPool.AddCert(mockServer.Certificate())
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs: certPool,
+			},
+		},
+	}
+	s, err := session.New(session.WithClient(httpClient), session.WithSigner(&edgegrid.Config{Host: serverURL.Host}))
+	require.NoError(t, err)
+	return Client(s)
+}
+
+func TestRolloverKSK(t *testing.T) {
+	tests := map[string]struct {
+		params         RolloverKSKRequest
+		responseStatus int
+		responseBody   string
+		expectedPath   string
+		withError      func(*testing.T, error)
+	}{
+		"202 Accepted": {
+			params:         RolloverKSKRequest{Zone: "example.com"},
+			responseStatus: http.StatusAccepted,
+			expectedPath:   "/config-dns/v2/zones/example.com/key-rollover",
+		},
+		"204 No Content": {
+			params:         RolloverKSKRequest{Zone: "example.com"},
+			responseStatus: http.StatusNoContent,
+			expectedPath:   "/config-dns/v2/zones/example.com/key-rollover",
+		},
+		"409 rollover in progress": {
+			params:         RolloverKSKRequest{Zone: "example.com"},
+			responseStatus: http.StatusConflict,
+			responseBody:   `{"type": "https://problems.luna.akamaiapis.net/authoritative-dns/conflict", "title": "Conflict", "detail": "A key rollover is already in progress"}`,
+			expectedPath:   "/config-dns/v2/zones/example.com/key-rollover",
+			withError: func(t *testing.T, err error) {
+				var dnsErr *dns.Error
+				require.True(t, errors.As(err, &dnsErr))
+				assert.Equal(t, http.StatusConflict, dnsErr.StatusCode)
+				assert.Equal(t, "A key rollover is already in progress", dnsErr.Detail)
+				assert.True(t, errors.Is(err, ErrRolloverKSK))
+			},
+		},
+		"validation error": {
+			params: RolloverKSKRequest{},
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, dns.ErrStructValidation))
+				assert.Contains(t, err.Error(), "Zone: cannot be blank")
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodPost, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer)
+			err := client.RolloverKSK(context.Background(), test.params)
+			if test.withError != nil {
+				test.withError(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package dnssec
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock is a mock of the DNSSec interface
+type Mock struct {
+	mock.Mock
+}
+
+var _ DNSSec = &Mock{}
+
+// RolloverKSK implements DNSSec
+func (m *Mock) RolloverKSK(ctx context.Context, params RolloverKSKRequest) error {
+	args := m.Called(ctx, params)
+	return args.Error(0)
+}
@@ -67,8 +67,26 @@ func NormalizeTarget(r string) (string, error) {
 	return "", fmt.Errorf("normalizing txt record targed '%s' failed", r)
 }
 
+// CharacterStrings returns the decoded character strings of txt record target,
+// e.g. ["v=spf1", "include:example.com"] for "v=spf1" "include:example.com"
+func CharacterStrings(r string) ([]string, error) {
+	stgs, err := characterStrings(r)
+	if err != nil {
+		return nil, fmt.Errorf("parsing txt record target '%s' failed: %w", r, err)
+	}
+	return stgs, nil
+}
+
 // normalizeTarget is a txt record target normalization func compliant with akamai api
 func normalizeTarget(in string) (string, bool) {
+	stgs, err := characterStrings(in)
+	if err != nil {
+		return "", false
+	}
+	return rrToString(stgs), true
+}
+
+func characterStrings(in string) ([]string, error) {
 	var newRdata strings.Builder
 	for _, ch := range in {
 		if isSafeASCII(ch) {
@@ -80,11 +98,7 @@ func normalizeTarget(in string) (string, bool) {
 	in = newRdata.String()
 
 	tok := newTokenizer(in)
-	stgs, err := rdataFromString(tok)
-	if err != nil {
-		return "", false
-	}
-	return rrToString(stgs), true
+	return rdataFromString(tok)
 }
 
 func isSafeASCII(ch rune) bool {
@@ -157,3 +157,35 @@ func Test_normalizeTarget(t *testing.T) {
 		}
 	}
 }
+
+func TestCharacterStrings(t *testing.T) {
+	tests := []struct {
+		in        string
+		expected  []string
+		withError bool
+	}{
+		{
+			in:       `"v=spf1" "include:example.com -all"`,
+			expected: []string{"v=spf1", "include:example.com -all"},
+		},
+		{
+			in:       `unquoted "with \"escaped\" quotes"`,
+			expected: []string{"unquoted", `with "escaped" quotes`},
+		},
+		{
+			in:        `"unterminated`,
+			withError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		res, err := CharacterStrings(tc.in)
+		if tc.withError {
+			assert.Error(t, err)
+			continue
+		}
+
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, res)
+	}
+}
@@ -0,0 +1,2 @@
+// Package zonefile contains logic used for parsing and rendering DNS master (zone) files as defined in RFC 1035 section 5.
+package zonefile
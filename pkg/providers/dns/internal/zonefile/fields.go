@@ -0,0 +1,42 @@
+package zonefile
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Fields returns the fields of the data of a single record, keyed by the names of the arguments
+// of the akamai_dns_record resource, e.g. priority, weight, port and target of SRV records.
+// The names in the data are fully qualified.
+func Fields(recordType, rdata, origin string) (map[string]string, error) {
+	recordType = strings.ToUpper(recordType)
+	normalized, err := NormalizeRdata(recordType, rdata, origin)
+	if err != nil {
+		return nil, err
+	}
+	lines, err := splitLines(normalized)
+	if err != nil {
+		return nil, err
+	}
+	tokens := lines[0].tokens
+
+	names := rdataFormats[recordType].fields
+	fields := make(map[string]string, len(names))
+	for i, name := range names {
+		if i >= len(tokens) {
+			break
+		}
+		if i == len(names)-1 {
+			fields[name] = strings.Join(tokens[i:], " ")
+			break
+		}
+		fields[name] = tokens[i]
+	}
+	if mnemonic, ok := fields["type_mnemonic"]; ok && recordType == "CERT" {
+		if _, err := strconv.Atoi(mnemonic); err == nil {
+			delete(fields, "type_mnemonic")
+			fields["type_value"] = mnemonic
+		}
+	}
+	return fields, nil
+}
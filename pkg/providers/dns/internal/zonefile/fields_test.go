@@ -0,0 +1,62 @@
+package zonefile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFields(t *testing.T) {
+	tests := map[string]struct {
+		recordType string
+		rdata      string
+		expected   map[string]string
+		withError  string
+	}{
+		"SRV": {
+			recordType: "SRV",
+			rdata:      "10 60 5060 sip",
+			expected:   map[string]string{"priority": "10", "weight": "60", "port": "5060", "target": "sip.example.com."},
+		},
+		"HTTPS with parameters": {
+			recordType: "HTTPS",
+			rdata:      "1 . alpn=h2,h3 port=8443",
+			expected:   map[string]string{"svc_priority": "1", "target_name": ".", "svc_params": "alpn=h2,h3 port=8443"},
+		},
+		"CAA with quoted value": {
+			recordType: "CAA",
+			rdata:      `0 issue "ca.example.net; account=1"`,
+			expected:   map[string]string{"flags": "0", "tag": "issue", "value": `"ca.example.net; account=1"`},
+		},
+		"CERT with numeric type": {
+			recordType: "CERT",
+			rdata:      "1 12345 8 ( AQPSKmynfzW4kyBv015MUG2DeIQ3 Cbl+BBZH4b/0PY1kxkmvHjcZc8no )",
+			expected: map[string]string{"type_value": "1", "keytag": "12345", "algorithm": "8",
+				"certificate": "AQPSKmynfzW4kyBv015MUG2DeIQ3Cbl+BBZH4b/0PY1kxkmvHjcZc8no"},
+		},
+		"NSEC3 type bitmaps": {
+			recordType: "NSEC3",
+			rdata:      "1 0 10 AABBCCDD 2VPTU5TIMAMQTTGL4LUU9KG21E0AOR3S A RRSIG",
+			expected: map[string]string{"algorithm": "1", "flags": "0", "iterations": "10", "salt": "AABBCCDD",
+				"next_hashed_owner_name": "2VPTU5TIMAMQTTGL4LUU9KG21E0AOR3S", "type_bitmaps": "A RRSIG"},
+		},
+		"invalid data": {
+			recordType: "MX",
+			rdata:      "10",
+			withError:  "expected at least 2 rdata fields, got 1",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fields, err := Fields(test.recordType, test.rdata, "example.com")
+			if test.withError != "" {
+				assert.EqualError(t, err, test.withError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, fields)
+		})
+	}
+}
@@ -0,0 +1,385 @@
+package zonefile
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/providers/dns/internal/txtrecord"
+)
+
+// RecordSet is a set of records of the same name and type, in the format of Edge DNS record sets
+type RecordSet struct {
+	// Name is the fully qualified name of the records, without the trailing dot
+	Name string
+	// Type is the record type, e.g. "A" or "MX"
+	Type string
+	// TTL is the TTL of the record set, in seconds
+	TTL int
+	// Rdata holds the data of each record, with the names fully qualified
+	Rdata []string
+}
+
+type rdataFormat struct {
+	// minFields is the minimum number of rdata fields
+	minFields int
+	// names are the indexes of the fields holding domain names, which are made fully qualified
+	names []int
+	// joinFrom is the index of the first field of base64 or hex data, which may be split by whitespace in zone files.
+	// The fields from joinFrom are concatenated. Zero if the type does not have such data.
+	joinFrom int
+	// ttls are the indexes of the fields holding time values, which may be given with units (e.g. 1h)
+	ttls []int
+	// fields are the names of the fields, which are the names of the arguments of the akamai_dns_record resource.
+	// The last field holds the rest of the data.
+	fields []string
+}
+
+// rdataFormats describe the rdata of the record types supported by Edge DNS
+var rdataFormats = map[string]rdataFormat{
+	"A":         {minFields: 1, fields: []string{"target"}},
+	"AAAA":      {minFields: 1, fields: []string{"target"}},
+	"AFSDB":     {minFields: 2, names: []int{1}, fields: []string{"subtype", "target"}},
+	"AKAMAICDN": {minFields: 1, fields: []string{"target"}},
+	"AKAMAITLC": {minFields: 2, fields: []string{"answer_type", "dns_name"}},
+	"CAA":       {minFields: 3, fields: []string{"flags", "tag", "value"}},
+	"CERT":      {minFields: 4, joinFrom: 3, fields: []string{"type_mnemonic", "keytag", "algorithm", "certificate"}},
+	"CNAME":     {minFields: 1, names: []int{0}, fields: []string{"target"}},
+	"DNSKEY":    {minFields: 4, joinFrom: 3, fields: []string{"flags", "protocol", "algorithm", "key"}},
+	"DS":        {minFields: 4, joinFrom: 3, fields: []string{"keytag", "algorithm", "digest_type", "digest"}},
+	"HINFO":     {minFields: 2, fields: []string{"hardware", "software"}},
+	"HTTPS":     {minFields: 2, names: []int{1}, fields: []string{"svc_priority", "target_name", "svc_params"}},
+	"LOC":       {minFields: 4, fields: []string{"target"}},
+	"MX":        {minFields: 2, names: []int{1}, fields: []string{"priority", "target"}},
+	"NAPTR": {minFields: 6, names: []int{5},
+		fields: []string{"order", "preference", "flagsnaptr", "service", "regexp", "replacement"}},
+	"NS": {minFields: 1, names: []int{0}, fields: []string{"target"}},
+	"NSEC3": {minFields: 6,
+		fields: []string{"algorithm", "flags", "iterations", "salt", "next_hashed_owner_name", "type_bitmaps"}},
+	"NSEC3PARAM": {minFields: 4, fields: []string{"algorithm", "flags", "iterations", "salt"}},
+	"PTR":        {minFields: 1, names: []int{0}, fields: []string{"target"}},
+	"RP":         {minFields: 2, names: []int{0, 1}, fields: []string{"mailbox", "txt"}},
+	"RRSIG": {minFields: 9, names: []int{7}, joinFrom: 8,
+		fields: []string{"type_covered", "algorithm", "labels", "original_ttl", "expiration", "inception", "keytag", "signer", "signature"}},
+	"SOA": {minFields: 7, names: []int{0, 1}, ttls: []int{3, 4, 5, 6},
+		fields: []string{"name_server", "email_address", "serial", "refresh", "retry", "expiry", "nxdomain_ttl"}},
+	"SPF":   {minFields: 1, fields: []string{"target"}},
+	"SRV":   {minFields: 4, names: []int{3}, fields: []string{"priority", "weight", "port", "target"}},
+	"SSHFP": {minFields: 3, joinFrom: 2, fields: []string{"algorithm", "fingerprint_type", "fingerprint"}},
+	"SVCB":  {minFields: 2, names: []int{1}, fields: []string{"svc_priority", "target_name", "svc_params"}},
+	"TLSA":  {minFields: 4, joinFrom: 3, fields: []string{"usage", "selector", "match_type", "certificate"}},
+	"TXT":   {minFields: 1, fields: []string{"target"}},
+}
+
+var classes = []string{"IN", "CS", "CH", "HS"}
+
+// line is a logical line of a zone file, which may span multiple physical lines enclosed in parentheses
+type line struct {
+	number int
+	// ownerOmitted is set when the line starts with whitespace, i.e. the owner of the previous record is used
+	ownerOmitted bool
+	tokens       []string
+}
+
+// Parse reads the records of the zone file, grouped into record sets in the order of their first appearance.
+//
+// Relative names are completed with origin, which can be changed with the $ORIGIN directive. Records without TTL
+// use the TTL set with the $TTL directive, or the TTL of the previous record, or defaultTTL if it is not zero.
+func Parse(content, origin string, defaultTTL int) ([]RecordSet, error) {
+	lines, err := splitLines(content)
+	if err != nil {
+		return nil, err
+	}
+
+	origin = strings.TrimSuffix(origin, ".")
+	var sets []RecordSet
+	index := make(map[string]int)
+	var owner string
+	lastTTL, directiveTTL := defaultTTL, 0
+
+	for _, l := range lines {
+		tokens := l.tokens
+		if strings.HasPrefix(tokens[0], "$") && !l.ownerOmitted {
+			switch strings.ToUpper(tokens[0]) {
+			case "$ORIGIN":
+				if len(tokens) != 2 {
+					return nil, fmt.Errorf("line %d: $ORIGIN requires a single domain name", l.number)
+				}
+				if origin, err = absoluteName(tokens[1], origin); err != nil {
+					return nil, fmt.Errorf("line %d: %w", l.number, err)
+				}
+			case "$TTL":
+				if len(tokens) != 2 {
+					return nil, fmt.Errorf("line %d: $TTL requires a single TTL value", l.number)
+				}
+				if directiveTTL, err = parseTTL(tokens[1]); err != nil {
+					return nil, fmt.Errorf("line %d: %w", l.number, err)
+				}
+			default:
+				return nil, fmt.Errorf("line %d: unsupported directive %s", l.number, tokens[0])
+			}
+			continue
+		}
+
+		if !l.ownerOmitted {
+			if owner, err = absoluteName(tokens[0], origin); err != nil {
+				return nil, fmt.Errorf("line %d: %w", l.number, err)
+			}
+			tokens = tokens[1:]
+		} else if owner == "" {
+			return nil, fmt.Errorf("line %d: record without owner name", l.number)
+		}
+
+		ttl := -1
+		for len(tokens) > 0 {
+			if slices.Contains(classes, strings.ToUpper(tokens[0])) {
+				if class := strings.ToUpper(tokens[0]); class != "IN" {
+					return nil, fmt.Errorf("line %d: unsupported class %s", l.number, class)
+				}
+				tokens = tokens[1:]
+				continue
+			}
+			if ttl == -1 && len(tokens[0]) > 0 && unicode.IsDigit(rune(tokens[0][0])) {
+				if ttl, err = parseTTL(tokens[0]); err != nil {
+					return nil, fmt.Errorf("line %d: %w", l.number, err)
+				}
+				tokens = tokens[1:]
+				continue
+			}
+			break
+		}
+		if len(tokens) == 0 {
+			return nil, fmt.Errorf("line %d: missing record type", l.number)
+		}
+
+		recordType := strings.ToUpper(tokens[0])
+		format, ok := rdataFormats[recordType]
+		if !ok {
+			return nil, fmt.Errorf("line %d: record type %s is not supported by Edge DNS", l.number, tokens[0])
+		}
+		rdata, err := normalizeRdata(recordType, format, tokens[1:], origin)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s record of %s: %w", l.number, recordType, owner, err)
+		}
+
+		switch {
+		case ttl != -1:
+		case directiveTTL != 0:
+			ttl = directiveTTL
+		case lastTTL != 0:
+			ttl = lastTTL
+		default:
+			return nil, fmt.Errorf("line %d: missing TTL of %s record of %s, and no $TTL directive or default TTL", l.number, recordType, owner)
+		}
+		lastTTL = ttl
+
+		key := owner + " " + recordType
+		if i, ok := index[key]; ok {
+			sets[i].Rdata = append(sets[i].Rdata, rdata)
+			continue
+		}
+		index[key] = len(sets)
+		sets = append(sets, RecordSet{Name: owner, Type: recordType, TTL: ttl, Rdata: []string{rdata}})
+	}
+	return sets, nil
+}
+
+// NormalizeRdata returns the rdata of a single record in the form of the rdata of records returned by Parse,
+// so that records can be compared regardless of the formatting of their data
+func NormalizeRdata(recordType, rdata, origin string) (string, error) {
+	recordType = strings.ToUpper(recordType)
+	format, ok := rdataFormats[recordType]
+	if !ok {
+		return "", fmt.Errorf("record type %s is not supported by Edge DNS", recordType)
+	}
+	lines, err := splitLines(rdata)
+	if err != nil {
+		return "", err
+	}
+	if len(lines) != 1 {
+		return "", fmt.Errorf("expected data of a single %s record, got %q", recordType, rdata)
+	}
+	return normalizeRdata(recordType, format, lines[0].tokens, strings.TrimSuffix(origin, "."))
+}
+
+// splitLines splits the content into logical lines of tokens, removing comments and parentheses
+func splitLines(content string) ([]line, error) {
+	var lines []line
+	var current line
+	var token strings.Builder
+	var inQuotes, escaped, inComment bool
+	var parens int
+	number := 1
+	atLineStart := true
+
+	endToken := func() {
+		if token.Len() > 0 {
+			current.tokens = append(current.tokens, token.String())
+			token.Reset()
+		}
+	}
+	endLine := func() {
+		endToken()
+		if len(current.tokens) > 0 {
+			lines = append(lines, current)
+		}
+		current = line{}
+	}
+
+	for _, ch := range content {
+		if atLineStart {
+			current.number = number
+			current.ownerOmitted = ch == ' ' || ch == '\t'
+			atLineStart = false
+		}
+		switch {
+		case ch == '\n':
+			if inQuotes {
+				return nil, fmt.Errorf("line %d: unterminated quoted string", number)
+			}
+			inComment, escaped = false, false
+			number++
+			// only the physical line starting a logical line sets its number and whether the owner is omitted
+			atLineStart = parens == 0
+			if parens == 0 {
+				endLine()
+			} else {
+				endToken()
+			}
+		case inComment:
+		case escaped:
+			token.WriteRune(ch)
+			escaped = false
+		case ch == '\\':
+			token.WriteRune(ch)
+			escaped = true
+		case ch == '"':
+			token.WriteRune(ch)
+			inQuotes = !inQuotes
+		case inQuotes:
+			token.WriteRune(ch)
+		case ch == ';':
+			endToken()
+			inComment = true
+		case ch == '(':
+			endToken()
+			parens++
+		case ch == ')':
+			endToken()
+			if parens == 0 {
+				return nil, fmt.Errorf("line %d: unbalanced parentheses", number)
+			}
+			parens--
+		case ch == ' ' || ch == '\t' || ch == '\r':
+			endToken()
+		default:
+			token.WriteRune(ch)
+		}
+	}
+	if inQuotes {
+		return nil, fmt.Errorf("line %d: unterminated quoted string", number)
+	}
+	if parens != 0 {
+		return nil, errors.New("unbalanced parentheses at the end of the zone file")
+	}
+	endLine()
+	return lines, nil
+}
+
+func normalizeRdata(recordType string, format rdataFormat, fields []string, origin string) (string, error) {
+	if len(fields) < format.minFields {
+		return "", fmt.Errorf("expected at least %d rdata fields, got %d", format.minFields, len(fields))
+	}
+	fields = slices.Clone(fields)
+	for _, i := range format.names {
+		name, err := absoluteName(fields[i], origin)
+		if err != nil {
+			return "", err
+		}
+		fields[i] = fqdn(name)
+	}
+	for _, i := range format.ttls {
+		ttl, err := parseTTL(fields[i])
+		if err != nil {
+			return "", err
+		}
+		fields[i] = strconv.Itoa(ttl)
+	}
+	if format.joinFrom > 0 {
+		fields = append(fields[:format.joinFrom], strings.Join(fields[format.joinFrom:], ""))
+	}
+
+	switch recordType {
+	case "A":
+		addr, err := netip.ParseAddr(fields[0])
+		if err != nil || !addr.Is4() || len(fields) != 1 {
+			return "", fmt.Errorf("invalid IPv4 address %q", strings.Join(fields, " "))
+		}
+	case "AAAA":
+		addr, err := netip.ParseAddr(fields[0])
+		if err != nil || !addr.Is6() || len(fields) != 1 {
+			return "", fmt.Errorf("invalid IPv6 address %q", strings.Join(fields, " "))
+		}
+	case "TXT", "SPF":
+		// character strings are always quoted in Edge DNS rdata
+		return txtrecord.NormalizeTarget(strings.Join(fields, " "))
+	}
+	return strings.Join(fields, " "), nil
+}
+
+// absoluteName returns the fully qualified name, without the trailing dot
+func absoluteName(name, origin string) (string, error) {
+	switch {
+	case name == "@":
+		if origin == "" {
+			return "", errors.New("@ used without origin")
+		}
+		return origin, nil
+	case name == ".":
+		return "", nil
+	case strings.HasSuffix(name, "."):
+		return strings.TrimSuffix(name, "."), nil
+	case origin == "":
+		return "", fmt.Errorf("relative name %s used without origin", name)
+	}
+	return name + "." + origin, nil
+}
+
+func fqdn(name string) string {
+	return name + "."
+}
+
+// parseTTL parses the TTL given in seconds, or with the units used by BIND, e.g. 1h30m
+func parseTTL(value string) (int, error) {
+	if ttl, err := strconv.Atoi(value); err == nil {
+		if ttl < 0 {
+			return 0, fmt.Errorf("invalid TTL %s", value)
+		}
+		return ttl, nil
+	}
+
+	units := map[rune]int{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}
+	var total, number int
+	var hasNumber bool
+	for _, ch := range strings.ToLower(value) {
+		if unicode.IsDigit(ch) {
+			number = number*10 + int(ch-'0')
+			hasNumber = true
+			continue
+		}
+		unit, ok := units[ch]
+		if !ok || !hasNumber {
+			return 0, fmt.Errorf("invalid TTL %s", value)
+		}
+		total += number * unit
+		number, hasNumber = 0, false
+	}
+	if hasNumber {
+		return 0, fmt.Errorf("invalid TTL %s", value)
+	}
+	return total, nil
+}
@@ -0,0 +1,218 @@
+package zonefile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]struct {
+		content    string
+		origin     string
+		defaultTTL int
+		expected   []RecordSet
+		withError  string
+	}{
+		"directives, relative names and omitted owners": {
+			content: `$ORIGIN example.com.
+$TTL 1h
+@	IN	SOA	ns1 hostmaster (
+			2024010101 ; serial
+			1h 15m 1w 300 )
+	IN	NS	ns1
+	IN	NS	ns2.example.net.
+	300	IN	MX	10 mail
+www	IN 600	CNAME	@
+mail	A	192.0.2.1
+	A	192.0.2.2 ; second address
+`,
+			expected: []RecordSet{
+				{Name: "example.com", Type: "SOA", TTL: 3600, Rdata: []string{"ns1.example.com. hostmaster.example.com. 2024010101 3600 900 604800 300"}},
+				{Name: "example.com", Type: "NS", TTL: 3600, Rdata: []string{"ns1.example.com.", "ns2.example.net."}},
+				{Name: "example.com", Type: "MX", TTL: 300, Rdata: []string{"10 mail.example.com."}},
+				{Name: "www.example.com", Type: "CNAME", TTL: 600, Rdata: []string{"example.com."}},
+				{Name: "mail.example.com", Type: "A", TTL: 3600, Rdata: []string{"192.0.2.1", "192.0.2.2"}},
+			},
+		},
+		"origin argument and TTL of previous record": {
+			content: `_sip._tcp 300 SRV 10 60 5060 sip
+sip AAAA 2001:db8::1
+_443._tcp.www TLSA 3 1 1 ( 0C72AC70B745AC19998811B131D662C9
+                           AC69DBDBE7CB23E5B514B56664C5D3D6 )
+`,
+			origin: "example.com.",
+			expected: []RecordSet{
+				{Name: "_sip._tcp.example.com", Type: "SRV", TTL: 300, Rdata: []string{"10 60 5060 sip.example.com."}},
+				{Name: "sip.example.com", Type: "AAAA", TTL: 300, Rdata: []string{"2001:db8::1"}},
+				{Name: "_443._tcp.www.example.com", Type: "TLSA", TTL: 300, Rdata: []string{"3 1 1 0C72AC70B745AC19998811B131D662C9AC69DBDBE7CB23E5B514B56664C5D3D6"}},
+			},
+		},
+		"default TTL, quoted strings and case insensitive types": {
+			content: `example.com. txt "v=spf1 -all" "; not a comment"
+example.com. caa 0 issue "ca.example.net"
+svc.example.com. HTTPS 1 . alpn=h2,h3
+`,
+			defaultTTL: 60,
+			expected: []RecordSet{
+				{Name: "example.com", Type: "TXT", TTL: 60, Rdata: []string{`"v=spf1 -all" "; not a comment"`}},
+				{Name: "example.com", Type: "CAA", TTL: 60, Rdata: []string{`0 issue "ca.example.net"`}},
+				{Name: "svc.example.com", Type: "HTTPS", TTL: 60, Rdata: []string{"1 . alpn=h2,h3"}},
+			},
+		},
+		"parenthesised record followed by a comment": {
+			content: `$TTL 300
+@ IN SOA ns1. admin. (
+  1 7200 3600 1209600 3600 ) ; soa
+www IN A 192.0.2.1
+`,
+			origin: "example.com",
+			expected: []RecordSet{
+				{Name: "example.com", Type: "SOA", TTL: 300, Rdata: []string{"ns1. admin. 1 7200 3600 1209600 3600"}},
+				{Name: "www.example.com", Type: "A", TTL: 300, Rdata: []string{"192.0.2.1"}},
+			},
+		},
+		"parenthesised record with omitted owner": {
+			content: `www 300 IN A 192.0.2.1
+  IN TXT ( "a"
+ "b" )
+`,
+			origin: "example.com",
+			expected: []RecordSet{
+				{Name: "www.example.com", Type: "A", TTL: 300, Rdata: []string{"192.0.2.1"}},
+				{Name: "www.example.com", Type: "TXT", TTL: 300, Rdata: []string{`"a" "b"`}},
+			},
+		},
+		"unsupported directive": {
+			content:   "$INCLUDE other.zone\n",
+			origin:    "example.com",
+			withError: "line 1: unsupported directive $INCLUDE",
+		},
+		"unsupported type": {
+			content:   "$TTL 300\n\nwww IN DNAME other.example.com.\n",
+			origin:    "example.com",
+			withError: "line 3: record type DNAME is not supported by Edge DNS",
+		},
+		"unsupported class": {
+			content:   "www 300 CH A 192.0.2.1\n",
+			origin:    "example.com",
+			withError: "line 1: unsupported class CH",
+		},
+		"missing rdata": {
+			content:   "www 300 MX 10\n",
+			origin:    "example.com",
+			withError: "line 1: MX record of www.example.com: expected at least 2 rdata fields, got 1",
+		},
+		"invalid address": {
+			content:   "www 300 A 2001:db8::1\n",
+			origin:    "example.com",
+			withError: `line 1: A record of www.example.com: invalid IPv4 address "2001:db8::1"`,
+		},
+		"relative name without origin": {
+			content:   "www 300 A 192.0.2.1\n",
+			withError: "line 1: relative name www used without origin",
+		},
+		"missing TTL": {
+			content:   "www IN A 192.0.2.1\n",
+			origin:    "example.com",
+			withError: "line 1: missing TTL of A record of www.example.com, and no $TTL directive or default TTL",
+		},
+		"unbalanced parentheses": {
+			content:   "@ 300 SOA ns1 hostmaster ( 1 2 3 4 5\n",
+			origin:    "example.com",
+			withError: "unbalanced parentheses at the end of the zone file",
+		},
+		"unterminated quoted string": {
+			content:   "@ 300 TXT \"text\n",
+			origin:    "example.com",
+			withError: "line 1: unterminated quoted string",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			sets, err := Parse(test.content, test.origin, test.defaultTTL)
+			if test.withError != "" {
+				assert.EqualError(t, err, test.withError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, sets)
+		})
+	}
+}
+
+func TestParseTTL(t *testing.T) {
+	tests := map[string]struct {
+		value    string
+		expected int
+		ok       bool
+	}{
+		"seconds":        {value: "300", expected: 300, ok: true},
+		"units":          {value: "1h30m", expected: 5400, ok: true},
+		"upper case":     {value: "1W", expected: 604800, ok: true},
+		"missing unit":   {value: "1h30"},
+		"unknown unit":   {value: "1y"},
+		"negative value": {value: "-1"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ttl, err := parseTTL(test.value)
+			if !test.ok {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, ttl)
+		})
+	}
+}
+
+func TestNormalizeRdata(t *testing.T) {
+	tests := map[string]struct {
+		recordType string
+		rdata      string
+		expected   string
+		withError  string
+	}{
+		"relative name": {
+			recordType: "mx",
+			rdata:      "10  mail",
+			expected:   "10 mail.example.com.",
+		},
+		"split digest": {
+			recordType: "DS",
+			rdata:      "60485 5 1 ( 2BB183AF5F22588179A53B0A 98631FAD1A292118 )",
+			expected:   "60485 5 1 2BB183AF5F22588179A53B0A98631FAD1A292118",
+		},
+		"unquoted text": {
+			recordType: "TXT",
+			rdata:      "hello",
+			expected:   `"hello"`,
+		},
+		"multiple records": {
+			recordType: "A",
+			rdata:      "192.0.2.1\n192.0.2.2",
+			withError:  "expected data of a single A record, got \"192.0.2.1\\n192.0.2.2\"",
+		},
+		"unsupported type": {
+			recordType: "DNAME",
+			rdata:      "other.example.com.",
+			withError:  "record type DNAME is not supported by Edge DNS",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rdata, err := NormalizeRdata(test.recordType, test.rdata, "example.com.")
+			if test.withError != "" {
+				assert.EqualError(t, err, test.withError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, rdata)
+		})
+	}
+}
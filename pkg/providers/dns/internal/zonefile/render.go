@@ -0,0 +1,47 @@
+package zonefile
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Render returns the record sets as zone file text in canonical form, so that zone files from different sources
+// can be compared: the names are fully qualified, the SOA and NS records of the zone come first,
+// followed by the other records ordered by name, type and data.
+func Render(origin string, sets []RecordSet) string {
+	origin = strings.TrimSuffix(origin, ".")
+	sets = slices.Clone(sets)
+	slices.SortStableFunc(sets, func(a, b RecordSet) int {
+		if rank := renderRank(origin, a) - renderRank(origin, b); rank != 0 {
+			return rank
+		}
+		if a.Name != b.Name {
+			return strings.Compare(a.Name, b.Name)
+		}
+		return strings.Compare(a.Type, b.Type)
+	})
+
+	var sb strings.Builder
+	if origin != "" {
+		fmt.Fprintf(&sb, "$ORIGIN %s\n", fqdn(origin))
+	}
+	for _, set := range sets {
+		rdata := slices.Clone(set.Rdata)
+		slices.Sort(rdata)
+		for _, r := range rdata {
+			fmt.Fprintf(&sb, "%s\t%d\tIN\t%s\t%s\n", fqdn(set.Name), set.TTL, set.Type, r)
+		}
+	}
+	return sb.String()
+}
+
+func renderRank(origin string, set RecordSet) int {
+	switch {
+	case set.Name == origin && set.Type == "SOA":
+		return 0
+	case set.Name == origin && set.Type == "NS":
+		return 1
+	}
+	return 2
+}
@@ -0,0 +1,32 @@
+package zonefile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	sets := []RecordSet{
+		{Name: "www.example.com", Type: "A", TTL: 300, Rdata: []string{"192.0.2.2", "192.0.2.1"}},
+		{Name: "example.com", Type: "NS", TTL: 86400, Rdata: []string{"ns1.example.com."}},
+		{Name: "example.com", Type: "MX", TTL: 300, Rdata: []string{"10 mail.example.com."}},
+		{Name: "example.com", Type: "SOA", TTL: 86400, Rdata: []string{"ns1.example.com. hostmaster.example.com. 1 3600 900 604800 300"}},
+	}
+
+	expected := `$ORIGIN example.com.
+example.com.	86400	IN	SOA	ns1.example.com. hostmaster.example.com. 1 3600 900 604800 300
+example.com.	86400	IN	NS	ns1.example.com.
+example.com.	300	IN	MX	10 mail.example.com.
+www.example.com.	300	IN	A	192.0.2.1
+www.example.com.	300	IN	A	192.0.2.2
+`
+	rendered := Render("example.com.", sets)
+	assert.Equal(t, expected, rendered)
+
+	// the rendered text is parsed back into the same records
+	parsed, err := Parse(rendered, "", 0)
+	require.NoError(t, err)
+	assert.Equal(t, rendered, Render("example.com", parsed))
+}
@@ -0,0 +1,371 @@
+package zonefile
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	caaTagRegexp       = regexp.MustCompile(`^[a-zA-Z0-9]+$`)
+	naptrFlagsRegexp   = regexp.MustCompile(`^"[a-zA-Z0-9]*"$`)
+	rrsigTimeRegexp    = regexp.MustCompile(`^\d{14}$`)
+	svcParamKeyRegexp  = regexp.MustCompile(`^key\d{1,5}$`)
+	certTypeMnemonics  = []string{"PKIX", "SPKI", "PGP", "IPKIX", "ISPKI", "IPGP", "ACPKIX", "IACPKIX", "URI", "OID"}
+	sshfpAlgorithms    = []string{"1", "2", "3", "4", "6"}
+	svcParamKeys       = []string{"mandatory", "alpn", "no-default-alpn", "port", "ipv4hint", "ech", "ipv6hint", "dohpath", "ohttp"}
+	dnssecRecordTypes  = []string{"RRSIG", "NSEC", "NSEC3"}
+	aliasForbiddenFrom = []string{"MX", "NS", "SRV"}
+)
+
+// digestLengths are the lengths of the hex encoded digests of DS records by digest type
+var digestLengths = map[string]int{"1": 40, "2": 64, "3": 64, "4": 96}
+
+// fingerprintLengths are the lengths of the hex encoded fingerprints of SSHFP records by fingerprint type
+var fingerprintLengths = map[string]int{"1": 40, "2": 64}
+
+// FieldNames returns the names of the rdata fields of the record type, in the order they appear in the data
+func FieldNames(recordType string) []string {
+	return slices.Clone(rdataFormats[strings.ToUpper(recordType)].fields)
+}
+
+// Validate checks the data of a single record against the constraints of the RFCs defining the record type,
+// e.g. the ranges of numeric fields, the lengths of digests and the syntax of SVCB parameters.
+// The lengths of TXT character strings are checked when normalizing the data
+func Validate(recordType, rdata, origin string) error {
+	recordType = strings.ToUpper(recordType)
+	fields, err := Fields(recordType, rdata, origin)
+	if err != nil {
+		return err
+	}
+	v := fieldValidator{fields: fields}
+
+	switch recordType {
+	case "AFSDB":
+		v.uint("subtype", 16)
+		v.name("target")
+	case "CAA":
+		v.uint("flags", 8)
+		v.match("tag", caaTagRegexp, "alphanumeric")
+	case "CERT":
+		if _, ok := fields["type_value"]; ok {
+			v.uint("type_value", 16)
+		} else {
+			v.oneOf("type_mnemonic", certTypeMnemonics)
+		}
+		v.uint("keytag", 16)
+		v.uint("algorithm", 8)
+		v.base64("certificate")
+	case "CNAME", "NS", "PTR":
+		v.name("target")
+	case "DNSKEY":
+		v.uint("flags", 16)
+		v.oneOf("protocol", []string{"3"})
+		v.uint("algorithm", 8)
+		v.base64("key")
+	case "DS":
+		v.uint("keytag", 16)
+		v.uint("algorithm", 8)
+		v.hexDigest("digest_type", "digest", digestLengths)
+	case "MX":
+		v.uint("priority", 16)
+		v.name("target")
+	case "NAPTR":
+		v.uint("order", 16)
+		v.uint("preference", 16)
+		v.match("flagsnaptr", naptrFlagsRegexp, "a quoted string of alphanumeric characters")
+		v.quoted("service")
+		v.quoted("regexp")
+		v.name("replacement")
+	case "NSEC3", "NSEC3PARAM":
+		v.uint("algorithm", 8)
+		v.uint("flags", 8)
+		v.uint("iterations", 16)
+		if fields["salt"] != "-" {
+			v.hex("salt")
+		}
+	case "RRSIG":
+		if _, ok := rdataFormats[strings.ToUpper(fields["type_covered"])]; !ok {
+			v.fail("type_covered", fmt.Sprintf("%q is not a supported record type", fields["type_covered"]))
+		}
+		v.uint("algorithm", 8)
+		v.uint("labels", 8)
+		v.uint("original_ttl", 32)
+		v.time("expiration")
+		v.time("inception")
+		v.uint("keytag", 16)
+		v.name("signer")
+		v.base64("signature")
+	case "SOA":
+		v.name("name_server")
+		v.name("email_address")
+		for _, field := range []string{"serial", "refresh", "retry", "expiry", "nxdomain_ttl"} {
+			v.uint(field, 32)
+		}
+	case "SRV":
+		v.uint("priority", 16)
+		v.uint("weight", 16)
+		v.uint("port", 16)
+		v.name("target")
+	case "SSHFP":
+		v.oneOf("algorithm", sshfpAlgorithms)
+		v.hexDigest("fingerprint_type", "fingerprint", fingerprintLengths)
+	case "SVCB", "HTTPS":
+		v.uint("svc_priority", 16)
+		v.name("target_name")
+		if v.err == nil {
+			v.err = validateSvcParams(fields["svc_priority"], fields["svc_params"])
+		}
+	case "TLSA":
+		v.oneOf("usage", []string{"0", "1", "2", "3"})
+		v.oneOf("selector", []string{"0", "1"})
+		v.oneOf("match_type", []string{"0", "1", "2"})
+		lengths := map[string]int{"1": 64, "2": 128}
+		if length, ok := lengths[fields["match_type"]]; ok {
+			v.hexLength("certificate", length)
+		} else {
+			v.hex("certificate")
+		}
+	}
+	return v.err
+}
+
+// ValidateZone checks the constraints between the record sets of the zone: CNAME records cannot be at the zone apex
+// or coexist with other records of the same name, and MX, NS and SRV records cannot point at names with CNAME records
+func ValidateZone(origin string, sets []RecordSet) error {
+	for _, set := range sets {
+		if err := ValidateRecordSet(origin, set, sets); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateRecordSet checks the constraints of ValidateZone which involve the record set, against the other
+// record sets of the zone
+func ValidateRecordSet(origin string, set RecordSet, zone []RecordSet) error {
+	origin = canonicalName(origin)
+	name := canonicalName(set.Name)
+	recordType := strings.ToUpper(set.Type)
+
+	if recordType == "CNAME" {
+		if name == origin {
+			return fmt.Errorf("CNAME record is not allowed at the zone apex %s", set.Name)
+		}
+		if len(set.Rdata) > 1 {
+			return fmt.Errorf("CNAME record set %s must have a single record, got %d", set.Name, len(set.Rdata))
+		}
+	}
+
+	for _, other := range zone {
+		otherType := strings.ToUpper(other.Type)
+		if canonicalName(other.Name) == name {
+			switch {
+			case recordType == "CNAME" && otherType != "CNAME" && !slices.Contains(dnssecRecordTypes, otherType):
+				return fmt.Errorf("CNAME record of %s cannot coexist with %s records of the same name", set.Name, otherType)
+			case otherType == "CNAME" && recordType != "CNAME" && !slices.Contains(dnssecRecordTypes, recordType):
+				return fmt.Errorf("CNAME record of %s cannot coexist with %s records of the same name", other.Name, recordType)
+			}
+		}
+		if recordType == "CNAME" {
+			if target, ok := aliasTarget(origin, other, name); ok {
+				return fmt.Errorf("%s record of %s points at %s, which has a CNAME record; it has to point at a canonical name",
+					otherType, other.Name, target)
+			}
+		}
+		if otherType == "CNAME" {
+			if target, ok := aliasTarget(origin, set, canonicalName(other.Name)); ok {
+				return fmt.Errorf("%s record of %s points at %s, which has a CNAME record; it has to point at a canonical name",
+					recordType, set.Name, target)
+			}
+		}
+	}
+	return nil
+}
+
+// aliasTarget returns the target of the MX, NS or SRV record set which points at the alias
+func aliasTarget(origin string, set RecordSet, alias string) (string, bool) {
+	recordType := strings.ToUpper(set.Type)
+	if !slices.Contains(aliasForbiddenFrom, recordType) {
+		return "", false
+	}
+	for _, rdata := range set.Rdata {
+		fields, err := Fields(recordType, rdata, origin)
+		if err != nil {
+			continue
+		}
+		if canonicalName(fields["target"]) == alias {
+			return fields["target"], true
+		}
+	}
+	return "", false
+}
+
+// canonicalName returns the lower case name without the trailing dot
+func canonicalName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+// validateSvcParams checks the SvcParams of SVCB and HTTPS records, as defined in RFC 9460
+func validateSvcParams(priority, params string) error {
+	if params == "" {
+		return nil
+	}
+	if priority == "0" {
+		return fmt.Errorf("svc_params cannot be set in AliasMode, when svc_priority is 0")
+	}
+
+	values := make(map[string]string)
+	for _, param := range strings.Fields(params) {
+		key, value, hasValue := strings.Cut(param, "=")
+		value = strings.Trim(value, `"`)
+		if !slices.Contains(svcParamKeys, key) && !svcParamKeyRegexp.MatchString(key) {
+			return fmt.Errorf("svc_params: unknown key %q", key)
+		}
+		if _, ok := values[key]; ok {
+			return fmt.Errorf("svc_params: key %q is set more than once", key)
+		}
+		if key == "no-default-alpn" {
+			if hasValue {
+				return fmt.Errorf("svc_params: key %q cannot have a value", key)
+			}
+		} else if value == "" {
+			return fmt.Errorf("svc_params: key %q requires a value", key)
+		}
+		values[key] = value
+
+		switch key {
+		case "port":
+			if _, err := strconv.ParseUint(value, 10, 16); err != nil {
+				return fmt.Errorf("svc_params: port %q is not a valid port number", value)
+			}
+		case "ipv4hint", "ipv6hint":
+			for _, address := range strings.Split(value, ",") {
+				addr, err := netip.ParseAddr(address)
+				if err != nil || (key == "ipv4hint" && !addr.Is4()) || (key == "ipv6hint" && !addr.Is6()) {
+					return fmt.Errorf("svc_params: %q is not a valid address of %s", address, key)
+				}
+			}
+		case "ech":
+			if _, err := base64.StdEncoding.DecodeString(value); err != nil {
+				return fmt.Errorf("svc_params: ech is not valid base64")
+			}
+		case "alpn":
+			if slices.Contains(strings.Split(value, ","), "") {
+				return fmt.Errorf("svc_params: alpn cannot contain empty protocol identifiers")
+			}
+		}
+	}
+
+	if _, ok := values["no-default-alpn"]; ok {
+		if _, ok := values["alpn"]; !ok {
+			return fmt.Errorf("svc_params: no-default-alpn requires alpn to be set")
+		}
+	}
+	if mandatory, ok := values["mandatory"]; ok {
+		for _, key := range strings.Split(mandatory, ",") {
+			if key == "mandatory" {
+				return fmt.Errorf("svc_params: mandatory cannot list itself")
+			}
+			if _, ok := values[key]; !ok {
+				return fmt.Errorf("svc_params: mandatory key %q is missing", key)
+			}
+		}
+	}
+	return nil
+}
+
+// fieldValidator checks the fields of a record, keeping the first error
+type fieldValidator struct {
+	fields map[string]string
+	err    error
+}
+
+func (v *fieldValidator) fail(field, reason string) {
+	if v.err == nil {
+		v.err = fmt.Errorf("%s %s", field, reason)
+	}
+}
+
+func (v *fieldValidator) uint(field string, bits int) {
+	if _, err := strconv.ParseUint(v.fields[field], 10, bits); err != nil {
+		v.fail(field, fmt.Sprintf("has to be an integer between 0 and %d, got %q", uint64(1)<<bits-1, v.fields[field]))
+	}
+}
+
+func (v *fieldValidator) oneOf(field string, values []string) {
+	if !slices.Contains(values, v.fields[field]) {
+		v.fail(field, fmt.Sprintf("has to be one of %s, got %q", strings.Join(values, ", "), v.fields[field]))
+	}
+}
+
+func (v *fieldValidator) match(field string, re *regexp.Regexp, description string) {
+	if !re.MatchString(v.fields[field]) {
+		v.fail(field, fmt.Sprintf("has to be %s, got %q", description, v.fields[field]))
+	}
+}
+
+func (v *fieldValidator) quoted(field string) {
+	value := v.fields[field]
+	if len(value) < 2 || !strings.HasPrefix(value, `"`) || !strings.HasSuffix(value, `"`) {
+		v.fail(field, fmt.Sprintf("has to be a quoted string, got %s", value))
+	}
+}
+
+func (v *fieldValidator) name(field string) {
+	name := strings.TrimSuffix(v.fields[field], ".")
+	if len(name) > 253 {
+		v.fail(field, fmt.Sprintf("cannot be longer than 253 characters, got %d characters", len(name)))
+		return
+	}
+	if name == "" {
+		return
+	}
+	for _, label := range strings.Split(name, ".") {
+		if label == "" || len(label) > 63 {
+			v.fail(field, fmt.Sprintf("has to consist of labels of 1 to 63 characters, got %q", v.fields[field]))
+			return
+		}
+	}
+}
+
+func (v *fieldValidator) base64(field string) {
+	if _, err := base64.StdEncoding.DecodeString(v.fields[field]); err != nil {
+		v.fail(field, "is not valid base64")
+	}
+}
+
+func (v *fieldValidator) hex(field string) {
+	if _, err := hex.DecodeString(v.fields[field]); err != nil {
+		v.fail(field, "is not a valid hex string")
+	}
+}
+
+func (v *fieldValidator) hexLength(field string, length int) {
+	v.hex(field)
+	if v.err == nil && len(v.fields[field]) != length {
+		v.fail(field, fmt.Sprintf("has to have %d hex digits, got %d", length, len(v.fields[field])))
+	}
+}
+
+// hexDigest checks the digest in the field, whose length depends on the type in typeField
+func (v *fieldValidator) hexDigest(typeField, field string, lengths map[string]int) {
+	if length, ok := lengths[v.fields[typeField]]; ok {
+		v.hexLength(field, length)
+		return
+	}
+	v.uint(typeField, 8)
+	v.hex(field)
+}
+
+func (v *fieldValidator) time(field string) {
+	value := v.fields[field]
+	if !rrsigTimeRegexp.MatchString(value) {
+		v.uint(field, 32)
+	}
+}
@@ -0,0 +1,221 @@
+package zonefile
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		recordType string
+		rdata      string
+		withError  string
+	}{
+		"valid MX": {
+			recordType: "MX",
+			rdata:      "10 mail",
+		},
+		"valid null MX": {
+			recordType: "MX",
+			rdata:      "0 .",
+		},
+		"MX priority out of range": {
+			recordType: "MX",
+			rdata:      "65536 mail",
+			withError:  `priority has to be an integer between 0 and 65535, got "65536"`,
+		},
+		"label too long": {
+			recordType: "CNAME",
+			rdata:      strings.Repeat("a", 64) + ".example.net.",
+			withError:  "target has to consist of labels of 1 to 63 characters",
+		},
+		"valid SRV": {
+			recordType: "SRV",
+			rdata:      "10 60 5060 sip",
+		},
+		"SRV port out of range": {
+			recordType: "SRV",
+			rdata:      "10 60 70000 sip",
+			withError:  `port has to be an integer between 0 and 65535, got "70000"`,
+		},
+		"valid TLSA": {
+			recordType: "TLSA",
+			rdata:      "3 1 1 " + strings.Repeat("ab", 32),
+		},
+		"TLSA digest length mismatch": {
+			recordType: "TLSA",
+			rdata:      "3 1 2 " + strings.Repeat("ab", 32),
+			withError:  "certificate has to have 128 hex digits, got 64",
+		},
+		"TLSA usage out of range": {
+			recordType: "TLSA",
+			rdata:      "4 1 0 abcd",
+			withError:  `usage has to be one of 0, 1, 2, 3, got "4"`,
+		},
+		"TLSA certificate not hex": {
+			recordType: "TLSA",
+			rdata:      "3 1 0 xyz",
+			withError:  "certificate is not a valid hex string",
+		},
+		"valid DS": {
+			recordType: "DS",
+			rdata:      "60485 5 1 2BB183AF5F22588179A53B0A98631FAD1A292118",
+		},
+		"DS digest length mismatch": {
+			recordType: "DS",
+			rdata:      "60485 5 2 2BB183AF5F22588179A53B0A98631FAD1A292118",
+			withError:  "digest has to have 64 hex digits, got 40",
+		},
+		"DNSKEY protocol": {
+			recordType: "DNSKEY",
+			rdata:      "257 2 8 AQPSKmynfzW4kyBv015MUG2DeIQ3",
+			withError:  `protocol has to be one of 3, got "2"`,
+		},
+		"SSHFP algorithm": {
+			recordType: "SSHFP",
+			rdata:      "5 1 " + strings.Repeat("ab", 20),
+			withError:  `algorithm has to be one of 1, 2, 3, 4, 6, got "5"`,
+		},
+		"CAA tag": {
+			recordType: "CAA",
+			rdata:      `0 is-sue "ca.example.net"`,
+			withError:  `tag has to be alphanumeric, got "is-sue"`,
+		},
+		"valid HTTPS": {
+			recordType: "HTTPS",
+			rdata:      `1 . mandatory=alpn alpn=h2,h3 no-default-alpn port=8443 ipv4hint=192.0.2.1,192.0.2.2 ipv6hint=2001:db8::1`,
+		},
+		"HTTPS params in AliasMode": {
+			recordType: "HTTPS",
+			rdata:      "0 www alpn=h2",
+			withError:  "svc_params cannot be set in AliasMode, when svc_priority is 0",
+		},
+		"SVCB unknown key": {
+			recordType: "SVCB",
+			rdata:      "1 . foo=bar",
+			withError:  `svc_params: unknown key "foo"`,
+		},
+		"SVCB duplicated key": {
+			recordType: "SVCB",
+			rdata:      "1 . port=443 port=8443",
+			withError:  `svc_params: key "port" is set more than once`,
+		},
+		"SVCB missing mandatory key": {
+			recordType: "SVCB",
+			rdata:      "1 . mandatory=port alpn=h2",
+			withError:  `svc_params: mandatory key "port" is missing`,
+		},
+		"SVCB no-default-alpn without alpn": {
+			recordType: "SVCB",
+			rdata:      "1 . no-default-alpn",
+			withError:  "svc_params: no-default-alpn requires alpn to be set",
+		},
+		"SVCB invalid ipv4hint": {
+			recordType: "SVCB",
+			rdata:      "1 . ipv4hint=2001:db8::1",
+			withError:  `svc_params: "2001:db8::1" is not a valid address of ipv4hint`,
+		},
+		"SVCB generic key": {
+			recordType: "SVCB",
+			rdata:      "1 . key65000=abc",
+		},
+		"valid NAPTR": {
+			recordType: "NAPTR",
+			rdata:      `100 10 "S" "SIP+D2U" "!^.*$!sip:info@example.com!" _sip._udp.example.com.`,
+		},
+		"TXT string too long": {
+			recordType: "TXT",
+			rdata:      `"` + strings.Repeat("a", 256) + `"`,
+			withError:  "normalizing txt record",
+		},
+		"valid SOA": {
+			recordType: "SOA",
+			rdata:      "ns1 hostmaster 2024010101 3600 600 604800 300",
+		},
+		"SOA serial out of range": {
+			recordType: "SOA",
+			rdata:      "ns1 hostmaster 4294967296 3600 600 604800 300",
+			withError:  `serial has to be an integer between 0 and 4294967295, got "4294967296"`,
+		},
+		"invalid data": {
+			recordType: "MX",
+			rdata:      "10",
+			withError:  "expected at least 2 rdata fields, got 1",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := Validate(test.recordType, test.rdata, "example.com")
+			if test.withError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.withError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateZone(t *testing.T) {
+	tests := map[string]struct {
+		sets      []RecordSet
+		withError string
+	}{
+		"valid zone": {
+			sets: []RecordSet{
+				{Name: "example.com", Type: "MX", Rdata: []string{"10 mail.example.com."}},
+				{Name: "mail.example.com", Type: "A", Rdata: []string{"192.0.2.1"}},
+				{Name: "www.example.com", Type: "CNAME", Rdata: []string{"example.net."}},
+				{Name: "www.example.com", Type: "RRSIG", Rdata: []string{"CNAME 8 3 300 20240101000000 20231201000000 12345 example.com. AQPSKmyn"}},
+			},
+		},
+		"CNAME at apex": {
+			sets: []RecordSet{
+				{Name: "Example.com.", Type: "CNAME", Rdata: []string{"example.net."}},
+			},
+			withError: "CNAME record is not allowed at the zone apex Example.com.",
+		},
+		"CNAME with several records": {
+			sets: []RecordSet{
+				{Name: "www.example.com", Type: "CNAME", Rdata: []string{"a.example.net.", "b.example.net."}},
+			},
+			withError: "CNAME record set www.example.com must have a single record, got 2",
+		},
+		"CNAME with other types": {
+			sets: []RecordSet{
+				{Name: "www.example.com", Type: "CNAME", Rdata: []string{"example.net."}},
+				{Name: "WWW.example.com", Type: "TXT", Rdata: []string{`"text"`}},
+			},
+			withError: "CNAME record of www.example.com cannot coexist with TXT records of the same name",
+		},
+		"SRV pointing at alias": {
+			sets: []RecordSet{
+				{Name: "_sip._udp.example.com", Type: "SRV", Rdata: []string{"10 60 5060 sip.example.com."}},
+				{Name: "sip.example.com", Type: "CNAME", Rdata: []string{"example.net."}},
+			},
+			withError: "SRV record of _sip._udp.example.com points at sip.example.com., which has a CNAME record; it has to point at a canonical name",
+		},
+		"MX pointing at relative alias": {
+			sets: []RecordSet{
+				{Name: "example.com", Type: "MX", Rdata: []string{"10 mail"}},
+				{Name: "mail.example.com", Type: "CNAME", Rdata: []string{"example.net."}},
+			},
+			withError: "MX record of example.com points at mail.example.com., which has a CNAME record; it has to point at a canonical name",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateZone("example.com", test.sets)
+			if test.withError != "" {
+				assert.EqualError(t, err, test.withError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
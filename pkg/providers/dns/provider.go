@@ -6,8 +6,11 @@ import (
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/dns"
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/providers/dns/internal/dnssec"
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/subprovider"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -15,7 +18,8 @@ import (
 type (
 	// Subprovider gathers dns resources and data sources
 	Subprovider struct {
-		client dns.DNS
+		client       dns.DNS
+		dnsSecClient dnssec.DNSSec
 	}
 
 	option func(p *Subprovider)
@@ -49,11 +53,20 @@ func (p *Subprovider) Client(meta meta.Meta) dns.DNS {
 	return dns.Client(meta.Session())
 }
 
+// DNSSecClient returns the Edge DNS key rollover interface
+func (p *Subprovider) DNSSecClient(meta meta.Meta) dnssec.DNSSec {
+	if p.dnsSecClient != nil {
+		return p.dnsSecClient
+	}
+	return dnssec.Client(meta.Session())
+}
+
 // SDKResources returns the DNS resources implemented using terraform-plugin-sdk
 func (p *Subprovider) SDKResources() map[string]*schema.Resource {
 	return map[string]*schema.Resource{
-		"akamai_dns_zone":   resourceDNSv2Zone(),
-		"akamai_dns_record": resourceDNSv2Record(),
+		"akamai_dns_zone":         resourceDNSv2Zone(),
+		"akamai_dns_record":       resourceDNSv2Record(),
+		"akamai_dns_zone_records": resourceDNSZoneRecords(),
 	}
 }
 
@@ -67,12 +80,27 @@ func (p *Subprovider) SDKDataSources() map[string]*schema.Resource {
 
 // FrameworkResources returns the DNS resources implemented using terraform-plugin-framework
 func (p *Subprovider) FrameworkResources() []func() resource.Resource {
-	return []func() resource.Resource{}
+	return []func() resource.Resource{
+		NewTSIGKeyResource,
+		NewZoneDNSSecResource,
+	}
 }
 
 // FrameworkDataSources returns the DNS data sources implemented using terraform-plugin-framework
 func (p *Subprovider) FrameworkDataSources() []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewZoneDNSSecStatusDataSource,
+		NewZoneFileDataSource,
+		NewRecordsDataSource,
 	}
 }
+
+// EphemeralResources returns the DNS ephemeral resources implemented using terraform-plugin-framework
+func (p *Subprovider) EphemeralResources() []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{}
+}
+
+// Functions returns the DNS provider-defined functions implemented using terraform-plugin-framework
+func (p *Subprovider) Functions() []func() function.Function {
+	return []func() function.Function{}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/dns"
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/testutils"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/providers/dns/internal/dnssec"
 )
 
 func TestMain(m *testing.M) {
@@ -28,3 +29,15 @@ func useClient(client dns.DNS, f func()) {
 
 	f()
 }
+
+// useDNSSecClient swaps out the key rollover client on the global instance for the duration of the given func
+func useDNSSecClient(client dnssec.DNSSec, f func()) {
+	orig := inst.dnsSecClient
+	inst.dnsSecClient = client
+
+	defer func() {
+		inst.dnsSecClient = orig
+	}()
+
+	f()
+}
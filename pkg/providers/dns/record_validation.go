@@ -0,0 +1,213 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/dns"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/providers/dns/internal/txtrecord"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/providers/dns/internal/zonefile"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// plannedRecordSets holds the record sets planned by the resources of a single provider configuration.
+// It is stored as a value of the operation, which is created for every Terraform command, so the record sets
+// planned together in one command are checked together.
+type plannedRecordSets struct {
+	mu   sync.Mutex
+	sets map[string]zonefile.RecordSet
+}
+
+// plannedRecordSetsKey is the key of the operation value holding the plannedRecordSets
+type plannedRecordSetsKey struct{}
+
+// unvalidatedRecordTypes are the record types whose data is only checked by validateRecord
+var unvalidatedRecordTypes = []string{RRTypeAaaa, RRTypeHinfo}
+
+// validateRecordDiff reports the errors in the record data before the plan is applied: the data is checked
+// against the RFCs defining the record type and against the other record sets of the zone planned in the same command
+func validateRecordDiff(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	keys := make([]string, 0)
+	for key, s := range getResourceDNSRecordSchema() {
+		if s.Computed {
+			continue
+		}
+		if !d.NewValueKnown(key) {
+			// the data is checked when all values are known, at the latest when the plan is applied
+			return nil
+		}
+		keys = append(keys, key)
+	}
+
+	zone := d.Get("zone").(string)
+	name := d.Get("name").(string)
+	recordType := d.Get("recordtype").(string)
+	rdata := plannedRecordData(d, recordType)
+
+	// the records in the state were already accepted by Edge DNS, even if they would fail the checks
+	if d.Id() == "" || d.HasChanges(keys...) {
+		if err := validateRecord(d); err != nil {
+			return err
+		}
+		if !slices.Contains(unvalidatedRecordTypes, recordType) {
+			for _, r := range rdata {
+				if err := zonefile.Validate(recordType, r, zone); err != nil {
+					return fmt.Errorf("invalid %s record %s: %w", recordType, name, err)
+				}
+			}
+		}
+	}
+
+	return planRecordSets(m, zone, []zonefile.RecordSet{{Name: name, Type: recordType, TTL: d.Get("ttl").(int), Rdata: rdata}})
+}
+
+// planRecordSets adds the record sets to the record sets planned in the operation of the provider configuration
+// and checks the constraints between all planned record sets of the zone
+func planRecordSets(m interface{}, zone string, sets []zonefile.RecordSet) error {
+	if m == nil {
+		return zonefile.ValidateZone(zone, sets)
+	}
+	p := meta.Must(m).OperationValue(plannedRecordSetsKey{}, func() any {
+		return &plannedRecordSets{sets: make(map[string]zonefile.RecordSet)}
+	}).(*plannedRecordSets)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	zonePrefix := strings.ToLower(strings.TrimSuffix(zone, ".")) + " "
+	for _, set := range sets {
+		p.sets[zonePrefix+strings.ToLower(strings.TrimSuffix(set.Name, "."))+" "+strings.ToUpper(set.Type)] = set
+	}
+
+	keys := make([]string, 0, len(p.sets))
+	for key := range p.sets {
+		if strings.HasPrefix(key, zonePrefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	zoneSets := make([]zonefile.RecordSet, 0, len(keys))
+	for _, key := range keys {
+		zoneSets = append(zoneSets, p.sets[key])
+	}
+	// only the errors of the planned record sets are reported, the errors of the others were reported when they were planned
+	for _, set := range sets {
+		if err := zonefile.ValidateRecordSet(zone, set, zoneSets); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// plannedRecordData returns the data of the records as it is sent to Edge DNS, built from the planned arguments.
+// Unlike bindRecord, it does not read the existing records, so the MX records of other configurations are not included.
+func plannedRecordData(d tf.ResourceDataFetcher, recordType string) []string {
+	target, _ := tf.GetListValue("target", d)
+	targets := make([]string, 0, len(target))
+	for _, t := range target {
+		if s, ok := t.(string); ok {
+			targets = append(targets, s)
+		}
+	}
+	intValue := func(key string) int {
+		value, _ := tf.GetIntValue(key, d)
+		return value
+	}
+	stringValue := func(key string) string {
+		value, _ := tf.GetStringValue(key, d)
+		return value
+	}
+
+	switch recordType {
+	case RRTypeCname, RRTypeNs, RRTypePtr:
+		for i, t := range targets {
+			targets[i] = fqdn(t)
+		}
+	case RRTypeTxt:
+		for i, t := range targets {
+			if normalized, err := txtrecord.NormalizeTarget(t); err == nil {
+				targets[i] = normalized
+			}
+		}
+	case RRTypeSpf:
+		for i, t := range targets {
+			if !strings.HasPrefix(t, `"`) {
+				targets[i] = `"` + t + `"`
+			}
+		}
+	case RRTypeAfsdb:
+		for i, t := range targets {
+			targets[i] = strconv.Itoa(intValue("subtype")) + " " + fqdn(t)
+		}
+	case RRTypeMx:
+		priority, increment := intValue("priority"), intValue("priority_increment")
+		for i, t := range targets {
+			// need to support target entry with/without priority
+			if !strings.Contains(t, " ") {
+				t = strconv.Itoa(priority) + " " + t
+				priority += increment
+			}
+			targets[i] = fqdn(t)
+		}
+	case RRTypeSrv:
+		for i, t := range targets {
+			// if target has no priority, weight and port provided, use default values
+			if !strings.Contains(t, " ") {
+				t = strconv.Itoa(intValue("priority")) + " " + strconv.Itoa(intValue("weight")) + " " + strconv.Itoa(intValue("port")) + " " + t
+			}
+			targets[i] = fqdn(t)
+		}
+	case RRTypeNaptr:
+		quoted := func(key string) string {
+			value := stringValue(key)
+			if !strings.HasPrefix(value, `"`) {
+				value = `"` + value + `"`
+			}
+			return value
+		}
+		return []string{strings.Join([]string{strconv.Itoa(intValue("order")), strconv.Itoa(intValue("preference")),
+			quoted("flagsnaptr"), quoted("service"), quoted("regexp"), stringValue("replacement")}, " ")}
+	case RRTypeCert:
+		certType := stringValue("type_mnemonic")
+		if certType == "" {
+			certType = strconv.Itoa(intValue("type_value"))
+		}
+		return []string{certType + " " + strconv.Itoa(intValue("keytag")) + " " + strconv.Itoa(intValue("algorithm")) + " " + stringValue("certificate")}
+	default:
+		fields := zonefile.FieldNames(recordType)
+		if len(fields) == 0 || slices.Contains(fields, "target") {
+			return targets
+		}
+		// the data of the other types consists of the arguments named as the fields of the data
+		values := make([]string, 0, len(fields))
+		for _, field := range fields {
+			value, _ := d.GetOk(field)
+			values = append(values, fmt.Sprint(value))
+		}
+		return []string{strings.TrimSpace(strings.Join(values, " "))}
+	}
+	return targets
+}
+
+// plannedZoneRecordSets converts the record sets of the akamai_dns_zone_records resource into the planned record sets
+func plannedZoneRecordSets(recordSets []dns.RecordSet) []zonefile.RecordSet {
+	sets := make([]zonefile.RecordSet, 0, len(recordSets))
+	for _, recordSet := range recordSets {
+		sets = append(sets, zonefile.RecordSet{Name: recordSet.Name, Type: recordSet.Type, TTL: recordSet.TTL, Rdata: recordSet.Rdata})
+	}
+	return sets
+}
+
+// fqdn returns the name with the trailing dot, as the names in the targets are fully qualified
+func fqdn(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
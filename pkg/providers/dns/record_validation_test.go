@@ -0,0 +1,117 @@
+package dns
+
+import (
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/providers/dns/internal/zonefile"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlannedRecordData(t *testing.T) {
+	tests := map[string]struct {
+		config   map[string]interface{}
+		expected []string
+	}{
+		"CNAME": {
+			config:   map[string]interface{}{"recordtype": "CNAME", "target": []interface{}{"www.example.net"}},
+			expected: []string{"www.example.net."},
+		},
+		"MX with priority and increment": {
+			config: map[string]interface{}{"recordtype": "MX", "priority": 10, "priority_increment": 5,
+				"target": []interface{}{"mx1.example.com", "mx2.example.com."}},
+			expected: []string{"10 mx1.example.com.", "15 mx2.example.com."},
+		},
+		"MX with priorities in target": {
+			config:   map[string]interface{}{"recordtype": "MX", "target": []interface{}{"5 mx1.example.com."}},
+			expected: []string{"5 mx1.example.com."},
+		},
+		"SRV with default values": {
+			config: map[string]interface{}{"recordtype": "SRV", "priority": 10, "weight": 60, "port": 5060,
+				"target": []interface{}{"sip.example.com"}},
+			expected: []string{"10 60 5060 sip.example.com."},
+		},
+		"AFSDB": {
+			config:   map[string]interface{}{"recordtype": "AFSDB", "subtype": 1, "target": []interface{}{"afs.example.com"}},
+			expected: []string{"1 afs.example.com."},
+		},
+		"NAPTR": {
+			config: map[string]interface{}{"recordtype": "NAPTR", "order": 100, "preference": 10, "flagsnaptr": "S",
+				"service": "SIP+D2U", "regexp": "", "replacement": "_sip._udp.example.com."},
+			expected: []string{`100 10 "S" "SIP+D2U" "" _sip._udp.example.com.`},
+		},
+		"CERT with type value": {
+			config:   map[string]interface{}{"recordtype": "CERT", "type_value": 1, "keytag": 12345, "algorithm": 8, "certificate": "AQPSKmyn"},
+			expected: []string{"1 12345 8 AQPSKmyn"},
+		},
+		"TLSA": {
+			config:   map[string]interface{}{"recordtype": "TLSA", "usage": 3, "selector": 1, "match_type": 1, "certificate": "abcd"},
+			expected: []string{"3 1 1 abcd"},
+		},
+		"TXT": {
+			config:   map[string]interface{}{"recordtype": "TXT", "target": []interface{}{`Hel\lo"world`}},
+			expected: []string{`"Hel\\lo\"world"`},
+		},
+		"HTTPS": {
+			config:   map[string]interface{}{"recordtype": "HTTPS", "svc_priority": 1, "target_name": ".", "svc_params": "alpn=h2"},
+			expected: []string{"1 . alpn=h2"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, getResourceDNSRecordSchema(), test.config)
+			assert.Equal(t, test.expected, plannedRecordData(d, test.config["recordtype"].(string)))
+		})
+	}
+}
+
+func TestPlanRecordSets(t *testing.T) {
+	cname := zonefile.RecordSet{Name: "www.example.com", Type: "CNAME", Rdata: []string{"example.net."}}
+	txt := zonefile.RecordSet{Name: "www.example.com", Type: "TXT", Rdata: []string{`"text"`}}
+	newMeta := func(t *testing.T) meta.Meta {
+		m, err := meta.New(session.Must(session.New()), hclog.NewNullLogger(), "opID",
+			meta.WithSessionFactory(func(string) (session.Session, error) {
+				return session.New()
+			}))
+		require.NoError(t, err)
+		return m
+	}
+
+	t.Run("record sets planned with the same configuration are checked together", func(t *testing.T) {
+		m := newMeta(t)
+		assert.NoError(t, planRecordSets(m, "example.com", []zonefile.RecordSet{cname}))
+		assert.EqualError(t, planRecordSets(m, "example.com.", []zonefile.RecordSet{txt}),
+			"CNAME record of www.example.com cannot coexist with TXT records of the same name")
+	})
+
+	t.Run("record sets planned with other accounts of the configuration are checked together", func(t *testing.T) {
+		m := newMeta(t)
+		accountMeta, err := m.ForAccount("1-XYZ")
+		require.NoError(t, err)
+		assert.NoError(t, planRecordSets(m, "example.com", []zonefile.RecordSet{cname}))
+		assert.Error(t, planRecordSets(accountMeta, "example.com", []zonefile.RecordSet{txt}))
+	})
+
+	t.Run("errors of record sets planned before are not reported again", func(t *testing.T) {
+		m := newMeta(t)
+		assert.NoError(t, planRecordSets(m, "example.com", []zonefile.RecordSet{cname}))
+		assert.Error(t, planRecordSets(m, "example.com", []zonefile.RecordSet{txt}))
+		assert.NoError(t, planRecordSets(m, "example.com", []zonefile.RecordSet{{Name: "example.com", Type: "MX", Rdata: []string{"10 mail.example.com."}}}))
+	})
+
+	t.Run("record sets planned with earlier configurations are not leaked", func(t *testing.T) {
+		assert.NoError(t, planRecordSets(newMeta(t), "example.com", []zonefile.RecordSet{cname}))
+		assert.NoError(t, planRecordSets(newMeta(t), "example.com", []zonefile.RecordSet{txt}))
+	})
+
+	t.Run("record sets of other zones are not checked", func(t *testing.T) {
+		m := newMeta(t)
+		assert.NoError(t, planRecordSets(m, "example.com", []zonefile.RecordSet{cname}))
+		assert.NoError(t, planRecordSets(m, "example.org", []zonefile.RecordSet{{Name: "www.example.com", Type: "TXT", Rdata: []string{`"text"`}}}))
+	})
+}
@@ -38,6 +38,7 @@ func resourceDNSv2Record() *schema.Resource {
 		ReadContext:   resourceDNSRecordRead,
 		UpdateContext: resourceDNSRecordUpdate,
 		DeleteContext: resourceDNSRecordDelete,
+		CustomizeDiff: validateRecordDiff,
 		Importer: &schema.ResourceImporter{
 			State: resourceDNSRecordImport,
 		},
@@ -2151,7 +2152,7 @@ func buildRecordsList(target []interface{}, recordType string, logger akalog.Int
 	return records, nil
 }
 
-func validateRecord(d *schema.ResourceData) error {
+func validateRecord(d tf.ResourceDataFetcher) error {
 	recordType, err := tf.GetStringValue("recordtype", d)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return err
@@ -2208,7 +2209,7 @@ func validateRecord(d *schema.ResourceData) error {
 	}
 }
 
-func checkBasicRecordTypes(d *schema.ResourceData) error {
+func checkBasicRecordTypes(d tf.ResourceDataFetcher) error {
 	_, err := tf.GetStringValue("name", d)
 	if err != nil {
 		if !errors.Is(err, tf.ErrNotFound) {
@@ -2233,7 +2234,7 @@ func checkBasicRecordTypes(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkTargets(d *schema.ResourceData) error {
+func checkTargets(d tf.ResourceDataFetcher) error {
 	target, err := tf.GetListValue("target", d)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return err
@@ -2250,7 +2251,7 @@ func checkTargets(d *schema.ResourceData) error {
 	return nil
 }
 
-func isSRVTargetOldFormat(d *schema.ResourceData) (bool, error) {
+func isSRVTargetOldFormat(d tf.ResourceDataFetcher) (bool, error) {
 	target, err := tf.GetListValue("target", d)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return false, err
@@ -2283,7 +2284,7 @@ func isSRVTargetOldFormat(d *schema.ResourceData) (bool, error) {
 	return false, nil
 }
 
-func checkAAAARecord(d *schema.ResourceData) error {
+func checkAAAARecord(d tf.ResourceDataFetcher) error {
 	if err := checkBasicRecordTypes(d); err != nil {
 		return err
 	}
@@ -2307,7 +2308,7 @@ func checkAAAARecord(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkAsdfRecord(d *schema.ResourceData) error {
+func checkAsdfRecord(d tf.ResourceDataFetcher) error {
 	subtype, err := tf.GetIntValue("subtype", d)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return err
@@ -2319,7 +2320,7 @@ func checkAsdfRecord(d *schema.ResourceData) error {
 	return checkTargets(d)
 }
 
-func checkDnskeyRecord(d *schema.ResourceData) error {
+func checkDnskeyRecord(d tf.ResourceDataFetcher) error {
 	flags, err := tf.GetIntValue("flags", d)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return err
@@ -2365,7 +2366,7 @@ func checkDnskeyRecord(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkDsRecord(d *schema.ResourceData) error {
+func checkDsRecord(d tf.ResourceDataFetcher) error {
 	digestType, err := tf.GetIntValue("digest_type", d)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return err
@@ -2402,7 +2403,7 @@ func checkDsRecord(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkHinfoRecord(d *schema.ResourceData) error {
+func checkHinfoRecord(d tf.ResourceDataFetcher) error {
 	hardware, err := tf.GetStringValue("hardware", d)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return err
@@ -2423,7 +2424,7 @@ func checkHinfoRecord(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkMxRecord(d *schema.ResourceData) error {
+func checkMxRecord(d tf.ResourceDataFetcher) error {
 	priority, err := tf.GetIntValue("priority", d)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return err
@@ -2440,7 +2441,7 @@ func checkMxRecord(d *schema.ResourceData) error {
 	return checkTargets(d)
 }
 
-func checkNaptrRecord(d *schema.ResourceData) error {
+func checkNaptrRecord(d tf.ResourceDataFetcher) error {
 	flagsnaptr, err := tf.GetStringValue("flagsnaptr", d)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return err
@@ -2497,7 +2498,7 @@ func checkNaptrRecord(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkNsec3Record(d *schema.ResourceData) error {
+func checkNsec3Record(d tf.ResourceDataFetcher) error {
 	flags, err := tf.GetIntValue("flags", d)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return err
@@ -2550,7 +2551,7 @@ func checkNsec3Record(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkNsec3ParamRecord(d *schema.ResourceData) error {
+func checkNsec3ParamRecord(d tf.ResourceDataFetcher) error {
 	flags, err := tf.GetIntValue("flags", d)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return err
@@ -2591,7 +2592,7 @@ func checkNsec3ParamRecord(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkRpRecord(d *schema.ResourceData) error {
+func checkRpRecord(d tf.ResourceDataFetcher) error {
 	mailbox, err := tf.GetStringValue("mailbox", d)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return err
@@ -2616,7 +2617,7 @@ func checkRpRecord(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkRrsigRecord(d *schema.ResourceData) error {
+func checkRrsigRecord(d tf.ResourceDataFetcher) error {
 	expiration, err := tf.GetStringValue("expiration", d)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return err
@@ -2697,7 +2698,7 @@ func checkRrsigRecord(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkSrvRecord(d *schema.ResourceData) error {
+func checkSrvRecord(d tf.ResourceDataFetcher) error {
 	priority, err := tf.GetIntValue("priority", d)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return err
@@ -2751,7 +2752,7 @@ func checkSrvRecord(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkSshfpRecord(d *schema.ResourceData) error {
+func checkSshfpRecord(d tf.ResourceDataFetcher) error {
 	algorithm, err := tf.GetIntValue("algorithm", d)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return err
@@ -2784,7 +2785,7 @@ func checkSshfpRecord(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkSoaRecord(d *schema.ResourceData) error {
+func checkSoaRecord(d tf.ResourceDataFetcher) error {
 	nameserver, err := tf.GetStringValue("name_server", d)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return err
@@ -2837,11 +2838,11 @@ func checkSoaRecord(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkAkamaiTlcRecord(_ *schema.ResourceData) error {
+func checkAkamaiTlcRecord(_ tf.ResourceDataFetcher) error {
 	return fmt.Errorf("AKAMAITLC is a READ ONLY record")
 }
 
-func checkCaaRecord(d *schema.ResourceData) error {
+func checkCaaRecord(d tf.ResourceDataFetcher) error {
 	if err := checkBasicRecordTypes(d); err != nil {
 		return err
 	}
@@ -2850,7 +2851,7 @@ func checkCaaRecord(d *schema.ResourceData) error {
 		return err
 	}
 
-	caatarget := d.Get("target").([]interface{})
+	caatarget, _ := tf.GetListValue("target", d)
 	for _, caa := range caatarget {
 		caaStr, ok := caa.(string)
 		if !ok {
@@ -2875,7 +2876,7 @@ func checkCaaRecord(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkCertRecord(d *schema.ResourceData) error {
+func checkCertRecord(d tf.ResourceDataFetcher) error {
 	typemnemonic, err := tf.GetStringValue("type_mnemonic", d)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return err
@@ -2908,7 +2909,7 @@ func checkCertRecord(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkTlsaRecord(d *schema.ResourceData) error {
+func checkTlsaRecord(d tf.ResourceDataFetcher) error {
 	usage, err := tf.GetIntValue("usage", d)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return err
@@ -2933,15 +2934,15 @@ func checkTlsaRecord(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkSvcbRecord(d *schema.ResourceData) error {
+func checkSvcbRecord(d tf.ResourceDataFetcher) error {
 	return checkServiceRecord(d, "SVCB")
 }
 
-func checkHTTPSRecord(d *schema.ResourceData) error {
+func checkHTTPSRecord(d tf.ResourceDataFetcher) error {
 	return checkServiceRecord(d, "HTTPS")
 }
 
-func checkServiceRecord(d *schema.ResourceData, rtype string) error {
+func checkServiceRecord(d tf.ResourceDataFetcher, rtype string) error {
 	pri, err := tf.GetIntValue("svc_priority", d)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return err
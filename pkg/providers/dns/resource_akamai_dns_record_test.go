@@ -640,6 +640,51 @@ func TestResDnsRecord(t *testing.T) {
 
 		client.AssertExpectations(t)
 	})
+	t.Run("invalid records are reported at plan time", func(t *testing.T) {
+		tests := map[string]struct {
+			givenTF       string
+			expectedError *regexp.Regexp
+		}{
+			"TLSA certificate not matching the matching type": {
+				givenTF:       "tlsa_digest_length.tf",
+				expectedError: regexp.MustCompile("invalid TLSA record _443._tcp.www.exampleterraform.io: certificate has to have 128 hex digits, got 64"),
+			},
+			"HTTPS record without mandatory parameter": {
+				givenTF:       "https_params.tf",
+				expectedError: regexp.MustCompile(`invalid HTTPS record www.exampleterraform.io: svc_params: mandatory key "port" is missing`),
+			},
+			"CNAME record at zone apex": {
+				givenTF:       "cname_apex.tf",
+				expectedError: regexp.MustCompile("CNAME record is not allowed at the zone apex exampleterraform.io"),
+			},
+			"CNAME record coexisting with other records": {
+				givenTF:       "cname_coexisting.tf",
+				expectedError: regexp.MustCompile("CNAME record of www.exampleterraform.io cannot coexist with TXT records"),
+			},
+			"SRV record pointing at CNAME record": {
+				givenTF:       "srv_target_cname.tf",
+				expectedError: regexp.MustCompile("SRV record of _sip._udp.exampleterraform.io points at sip.exampleterraform.io., which has a CNAME record"),
+			},
+		}
+
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				client := &dns.Mock{}
+				useClient(client, func() {
+					resource.UnitTest(t, resource.TestCase{
+						ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+						Steps: []resource.TestStep{
+							{
+								Config:      testutils.LoadFixtureStringf(t, "testdata/TestResDnsRecord/validation/%s", test.givenTF),
+								ExpectError: test.expectedError,
+							},
+						},
+					})
+				})
+				client.AssertExpectations(t)
+			})
+		}
+	})
 
 }
 
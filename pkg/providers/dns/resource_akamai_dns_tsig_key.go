@@ -0,0 +1,309 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/dns"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/meta"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &tsigKeyResource{}
+	_ resource.ResourceWithConfigure   = &tsigKeyResource{}
+	_ resource.ResourceWithImportState = &tsigKeyResource{}
+)
+
+// tsigKeyAlgorithms are the algorithms of the TSIG keys supported by Edge DNS
+var tsigKeyAlgorithms = []string{"hmac-md5", "hmac-sha1", "hmac-sha224", "hmac-sha256", "hmac-sha384", "hmac-sha512", "HMAC-MD5.SIG-ALG.REG.INT"}
+
+type (
+	tsigKeyResource struct {
+		meta meta.Meta
+	}
+
+	tsigKeyResourceModel struct {
+		Name      types.String `tfsdk:"name"`
+		Algorithm types.String `tfsdk:"algorithm"`
+		Secret    types.String `tfsdk:"secret"`
+		Zones     types.Set    `tfsdk:"zones"`
+	}
+)
+
+// NewTSIGKeyResource returns a new TSIG key resource
+func NewTSIGKeyResource() resource.Resource { return &tsigKeyResource{} }
+
+func (r *tsigKeyResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "akamai_dns_tsig_key"
+}
+
+func (r *tsigKeyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			resp.Diagnostics.AddError(
+				"Unexpected Resource Configure Type",
+				fmt.Sprintf("Expected meta.Meta, got: %T. Please report this issue to the provider developers.",
+					req.ProviderData))
+		}
+	}()
+	r.meta = meta.Must(req.ProviderData)
+}
+
+func (r *tsigKeyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "TSIG key shared by secondary zones, used to authenticate the zone transfers from their masters. " +
+			"Changing the key rotates it in place in all its zones.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the key.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"algorithm": schema.StringAttribute{
+				Required:    true,
+				Description: fmt.Sprintf("The algorithm used to encode the secret of the key, one of %s.", strings.Join(tsigKeyAlgorithms, ", ")),
+				Validators: []validator.String{
+					stringvalidator.OneOf(tsigKeyAlgorithms...),
+				},
+			},
+			"secret": schema.StringAttribute{
+				Required:  true,
+				Sensitive: true,
+				Description: "A Base64-encoded string of data. When decoded, it needs to contain the correct number of bits for the chosen algorithm. " +
+					"If the input isn't correctly padded, the server applies the padding.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"zones": schema.SetAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Description: "The names of the secondary zones using the key. The key is removed from the zones removed from the set.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *tsigKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Creating DNS TSIG Key resource")
+	var plan tsigKeyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.apply(ctx, &plan, nil); err != nil {
+		resp.Diagnostics.AddError("create DNS TSIG key failed", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *tsigKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "Reading DNS TSIG Key resource")
+	var state tsigKeyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var zones []string
+	resp.Diagnostics.Append(state.Zones.ElementsAs(ctx, &zones, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	found, err := r.read(ctx, &state, zones)
+	if err != nil {
+		resp.Diagnostics.AddError("read DNS TSIG key failed", err.Error())
+		return
+	}
+	if !found {
+		tflog.Warn(ctx, fmt.Sprintf("TSIG key %s is not used by any zone, removing from state", state.Name.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *tsigKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Debug(ctx, "Updating DNS TSIG Key resource")
+	var plan, state tsigKeyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var oldZones []string
+	resp.Diagnostics.Append(state.Zones.ElementsAs(ctx, &oldZones, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := r.apply(ctx, &plan, oldZones); err != nil {
+		resp.Diagnostics.AddError("update DNS TSIG key failed", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *tsigKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Deleting DNS TSIG Key resource")
+	var state tsigKeyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var zones []string
+	resp.Diagnostics.Append(state.Zones.ElementsAs(ctx, &zones, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := deleteTSIGKeys(ctx, inst.Client(r.meta), zones); err != nil {
+		resp.Diagnostics.AddError("delete DNS TSIG key failed", err.Error())
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *tsigKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	tflog.Debug(ctx, "Importing DNS TSIG Key resource")
+	client := inst.Client(r.meta)
+
+	// the key is imported by the name of any zone using it
+	key, err := client.GetTSIGKey(ctx, dns.GetTSIGKeyRequest{Zone: req.ID})
+	if err != nil {
+		resp.Diagnostics.AddError("import DNS TSIG key failed", err.Error())
+		return
+	}
+	usedBy, err := client.GetTSIGKeyZones(ctx, dns.GetTSIGKeyZonesRequest{TsigKey: &key.TSIGKey})
+	if err != nil {
+		resp.Diagnostics.AddError("import DNS TSIG key failed", err.Error())
+		return
+	}
+	zones := usedBy.Zones
+	if !slices.ContainsFunc(zones, func(zone string) bool { return strings.EqualFold(zone, req.ID) }) {
+		zones = append(zones, req.ID)
+	}
+	slices.Sort(zones)
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), key.Name)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("algorithm"), key.Algorithm)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("secret"), key.Secret)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zones"), zones)...)
+}
+
+// apply sets the key in the zones of the plan and removes it from the zones of the state no longer using it
+func (r *tsigKeyResource) apply(ctx context.Context, plan *tsigKeyResourceModel, oldZones []string) error {
+	client := inst.Client(r.meta)
+
+	var zones []string
+	if diags := plan.Zones.ElementsAs(ctx, &zones, false); diags.HasError() {
+		return fmt.Errorf("could not read zones: %v", diags.Errors())
+	}
+	slices.Sort(zones)
+
+	err := client.UpdateTSIGKeyBulk(ctx, dns.UpdateTSIGKeyBulkRequest{
+		TSIGKeyBulk: &dns.TSIGKeyBulkPost{
+			Key: &dns.TSIGKey{
+				Name:      plan.Name.ValueString(),
+				Algorithm: plan.Algorithm.ValueString(),
+				Secret:    plan.Secret.ValueString(),
+			},
+			Zones: zones,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	var removed []string
+	for _, zone := range oldZones {
+		if !slices.ContainsFunc(zones, func(z string) bool { return strings.EqualFold(z, zone) }) {
+			removed = append(removed, zone)
+		}
+	}
+	return deleteTSIGKeys(ctx, client, removed)
+}
+
+// read sets the key as used by the given zones, and returns false if none of the zones uses a key with the name of the key
+func (r *tsigKeyResource) read(ctx context.Context, data *tsigKeyResourceModel, zones []string) (bool, error) {
+	client := inst.Client(r.meta)
+	slices.Sort(zones)
+
+	var usedBy []string
+	var key *dns.TSIGKey
+	for _, zone := range zones {
+		resp, err := client.GetTSIGKey(ctx, dns.GetTSIGKeyRequest{Zone: zone})
+		if err != nil {
+			var apiError *dns.Error
+			if errors.As(err, &apiError) && apiError.StatusCode == http.StatusNotFound {
+				tflog.Debug(ctx, fmt.Sprintf("zone %s has no TSIG key", zone))
+				continue
+			}
+			return false, err
+		}
+		if !strings.EqualFold(resp.Name, data.Name.ValueString()) {
+			tflog.Debug(ctx, fmt.Sprintf("zone %s uses TSIG key %s", zone, resp.Name))
+			continue
+		}
+		if key == nil {
+			key = &resp.TSIGKey
+		}
+		usedBy = append(usedBy, zone)
+	}
+	if key == nil {
+		return false, nil
+	}
+
+	data.Algorithm = types.StringValue(key.Algorithm)
+	data.Secret = types.StringValue(key.Secret)
+	zonesValue, diags := types.SetValueFrom(ctx, types.StringType, usedBy)
+	if diags.HasError() {
+		return false, fmt.Errorf("could not set zones: %v", diags.Errors())
+	}
+	data.Zones = zonesValue
+	return true, nil
+}
+
+// deleteTSIGKeys removes the TSIG key from the zones
+func deleteTSIGKeys(ctx context.Context, client dns.DNS, zones []string) error {
+	for _, zone := range zones {
+		if err := client.DeleteTSIGKey(ctx, dns.DeleteTSIGKeyRequest{Zone: zone}); err != nil {
+			var apiError *dns.Error
+			if errors.As(err, &apiError) && apiError.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return fmt.Errorf("could not remove the TSIG key of zone %s: %w", zone, err)
+		}
+	}
+	return nil
+}
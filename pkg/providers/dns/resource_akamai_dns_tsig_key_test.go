@@ -0,0 +1,124 @@
+package dns
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/dns"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/testutils"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestResDnsTSIGKey(t *testing.T) {
+	anyContext := mock.AnythingOfType("*context.valueCtx")
+	firstKey := dns.TSIGKey{
+		Name:      "transfer.example.com.",
+		Algorithm: "hmac-sha256",
+		Secret:    "c2VjcmV0LTEtc2VjcmV0LTEtc2VjcmV0LTEtc2VjcmV0LTE=",
+	}
+	rotatedKey := dns.TSIGKey{
+		Name:      "transfer.example.com.",
+		Algorithm: "hmac-sha512",
+		Secret:    "c2VjcmV0LTItc2VjcmV0LTItc2VjcmV0LTItc2VjcmV0LTI=",
+	}
+	notFound := &dns.Error{StatusCode: http.StatusNotFound}
+
+	// mockZoneKeys returns the keys of the zones, as set by the expected calls of UpdateTSIGKeyBulk and DeleteTSIGKey
+	mockZoneKeys := func(m *dns.Mock, updates []dns.TSIGKeyBulkPost, deletedZones ...string) {
+		calls := make(map[string]*mock.Call)
+		for _, zone := range []string{"secondary1.example.com", "secondary2.example.com"} {
+			calls[zone] = m.On("GetTSIGKey", anyContext, dns.GetTSIGKeyRequest{Zone: zone}).Return(nil, notFound)
+		}
+		for _, update := range updates {
+			m.On("UpdateTSIGKeyBulk", anyContext, dns.UpdateTSIGKeyBulkRequest{TSIGKeyBulk: &update}).Run(func(mock.Arguments) {
+				for _, zone := range update.Zones {
+					calls[zone].ReturnArguments = mock.Arguments{&dns.GetTSIGKeyResponse{TSIGKey: *update.Key, ZoneCount: int64(len(update.Zones))}, nil}
+				}
+			}).Return(nil).Once()
+		}
+		for _, zone := range deletedZones {
+			m.On("DeleteTSIGKey", anyContext, dns.DeleteTSIGKeyRequest{Zone: zone}).Run(func(mock.Arguments) {
+				calls[zone].ReturnArguments = mock.Arguments{nil, notFound}
+			}).Return(nil).Once()
+		}
+	}
+
+	tests := map[string]struct {
+		init  func(*dns.Mock)
+		steps []resource.TestStep
+	}{
+		"create, import, rotate and delete key": {
+			init: func(m *dns.Mock) {
+				mockZoneKeys(m, []dns.TSIGKeyBulkPost{
+					{Key: &firstKey, Zones: []string{"secondary1.example.com", "secondary2.example.com"}},
+					{Key: &rotatedKey, Zones: []string{"secondary1.example.com"}},
+				}, "secondary2.example.com", "secondary1.example.com")
+				m.On("GetTSIGKeyZones", anyContext, dns.GetTSIGKeyZonesRequest{TsigKey: &firstKey}).Return(&dns.GetTSIGKeyZonesResponse{
+					Zones: []string{"secondary2.example.com", "secondary1.example.com"},
+				}, nil).Once()
+			},
+			steps: []resource.TestStep{
+				{
+					Config: testutils.LoadFixtureString(t, "testdata/TestResDnsTSIGKey/create.tf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("akamai_dns_tsig_key.test", "name", "transfer.example.com."),
+						resource.TestCheckResourceAttr("akamai_dns_tsig_key.test", "algorithm", "hmac-sha256"),
+						resource.TestCheckResourceAttr("akamai_dns_tsig_key.test", "secret", firstKey.Secret),
+						resource.TestCheckResourceAttr("akamai_dns_tsig_key.test", "zones.#", "2"),
+					),
+				},
+				{
+					ImportState:                          true,
+					ImportStateId:                        "secondary2.example.com",
+					ResourceName:                         "akamai_dns_tsig_key.test",
+					ImportStateVerify:                    true,
+					ImportStateVerifyIdentifierAttribute: "name",
+				},
+				{
+					Config: testutils.LoadFixtureString(t, "testdata/TestResDnsTSIGKey/rotate.tf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("akamai_dns_tsig_key.test", "algorithm", "hmac-sha512"),
+						resource.TestCheckResourceAttr("akamai_dns_tsig_key.test", "secret", rotatedKey.Secret),
+						resource.TestCheckResourceAttr("akamai_dns_tsig_key.test", "zones.#", "1"),
+						resource.TestCheckTypeSetElemAttr("akamai_dns_tsig_key.test", "zones.*", "secondary1.example.com"),
+					),
+				},
+			},
+		},
+		"unsupported algorithm": {
+			steps: []resource.TestStep{
+				{
+					Config:      testutils.LoadFixtureString(t, "testdata/TestResDnsTSIGKey/invalid_algorithm.tf"),
+					ExpectError: regexp.MustCompile(`Attribute algorithm value must be one of`),
+				},
+			},
+		},
+		"no zones": {
+			steps: []resource.TestStep{
+				{
+					Config:      testutils.LoadFixtureString(t, "testdata/TestResDnsTSIGKey/no_zones.tf"),
+					ExpectError: regexp.MustCompile(`Attribute zones set must contain at least 1 elements`),
+				},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &dns.Mock{}
+			if tc.init != nil {
+				tc.init(client)
+			}
+			useClient(client, func() {
+				resource.UnitTest(t, resource.TestCase{
+					ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+					IsUnitTest:               true,
+					Steps:                    tc.steps,
+				})
+			})
+			client.AssertExpectations(t)
+		})
+	}
+}
@@ -29,6 +29,7 @@ func resourceDNSv2Zone() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceDNSv2ZoneImport,
 		},
+		CustomizeDiff: customizeDNSv2ZoneTypeDiff,
 		Schema: map[string]*schema.Schema{
 			"contract": {
 				Type:             schema.TypeString,
@@ -42,8 +43,9 @@ func resourceDNSv2Zone() *schema.Resource {
 			"type": {
 				Type:             schema.TypeString,
 				Required:         true,
-				ForceNew:         true,
 				ValidateDiagFunc: validateZoneType,
+				Description: "The type of the zone: PRIMARY, SECONDARY or ALIAS. A secondary zone is converted to a primary zone " +
+					"and vice versa in place, keeping its records. Any other change of the type replaces the zone.",
 				StateFunc: func(val interface{}) string {
 					return strings.ToUpper(val.(string))
 				},
@@ -65,12 +67,15 @@ func resourceDNSv2Zone() *schema.Resource {
 				DiffSuppressFunc: tf.FieldPrefixSuppress("grp_"),
 			},
 			"sign_and_serve": {
-				Type:     schema.TypeBool,
-				Optional: true,
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the zone is signed. If not set, the signing is kept, e.g. when managed by the akamai_dns_zone_dnssec resource.",
 			},
 			"sign_and_serve_algorithm": {
 				Type:     schema.TypeString,
 				Optional: true,
+				Computed: true,
 			},
 			"end_customer_id": {
 				Type:     schema.TypeString,
@@ -81,9 +86,11 @@ func resourceDNSv2Zone() *schema.Resource {
 				Optional: true,
 			},
 			"tsig_key": {
-				Type:     schema.TypeList,
-				Optional: true,
-				MaxItems: 1,
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Description: "The TSIG key of the secondary zone. If not set, the key is kept, e.g. when managed by the akamai_dns_tsig_key resource.",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"name": {
@@ -215,7 +222,7 @@ func resourceDNSv2ZoneCreate(ctx context.Context, d *schema.ResourceData, m inte
 	group, err := tf.GetStringValue("group", d)
 	if err != nil {
 		if errors.Is(err, tf.ErrNotFound) {
-			groupList, err := inst.Client(meta).ListGroups(ctx, dns.ListGroupRequest{})
+			groupList, err := listGroupsCached(ctx, meta)
 			if err != nil {
 				return diag.FromErr(err)
 			}
@@ -460,6 +467,14 @@ func resourceDNSv2ZoneUpdate(ctx context.Context, d *schema.ResourceData, m inte
 	if err := populateDNSv2ZoneObject(d, zoneCreate, logger); err != nil {
 		return diag.FromErr(err)
 	}
+	if d.HasChange("type") {
+		logger.Infof("Converting zone %s from %s to %s", hostname, zone.Type, zoneType)
+	}
+	if !strings.EqualFold(zoneType, "SECONDARY") {
+		// the masters and the TSIG key of a secondary zone converted to a primary zone are dropped
+		zoneCreate.Masters = nil
+		zoneCreate.TSIGKey = nil
+	}
 	// Save the zone to the API
 	logger.Debugf("Saving zone %v", zoneCreate)
 	e = inst.Client(meta).UpdateZone(ctx, dns.UpdateZoneRequest{
@@ -554,6 +569,29 @@ func resourceDNSv2ZoneDelete(_ context.Context, d *schema.ResourceData, m interf
 	return diag.Errorf("DNS zone deletion is not supported via this sub provider")
 }
 
+// customizeDNSv2ZoneTypeDiff replaces the zone when its type changes, except when a secondary zone is converted
+// to a primary zone or vice versa, which is done in place and keeps the records of the zone
+func customizeDNSv2ZoneTypeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.HasChange("type") {
+		return nil
+	}
+	oldType, newType := d.GetChange("type")
+	if !isZoneConversion(oldType.(string), newType.(string)) {
+		return d.ForceNew("type")
+	}
+	if !strings.EqualFold(newType.(string), "SECONDARY") {
+		// only secondary zones use a TSIG key
+		return d.SetNew("tsig_key", []interface{}{})
+	}
+	return nil
+}
+
+// isZoneConversion returns true if the zone type changes from SECONDARY to PRIMARY or vice versa
+func isZoneConversion(oldType, newType string) bool {
+	oldType, newType = strings.ToUpper(oldType), strings.ToUpper(newType)
+	return (oldType == "PRIMARY" && newType == "SECONDARY") || (oldType == "SECONDARY" && newType == "PRIMARY")
+}
+
 // validateZoneType is a SchemaValidateDiagFunc to validate the Zone type.
 func validateZoneType(v interface{}, _ cty.Path) diag.Diagnostics {
 	value := strings.ToUpper(v.(string))
@@ -806,9 +844,7 @@ func checkZoneSOAandNSRecords(ctx context.Context, meta meta.Meta, zone *dns.Get
 	}
 
 	logger.Warnf("SOA and NS records don't exist. Creating ...")
-	nameservers, err := inst.Client(meta).GetNameServerRecordList(ctx, dns.GetNameServerRecordListRequest{
-		ContractIDs: zone.ContractID,
-	})
+	nameservers, err := getNameServerRecordListCached(ctx, inst.Client(meta), zone.ContractID)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,544 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/dns"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/ptr"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/providers/dns/internal/dnssec"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/spf13/cast"
+)
+
+var (
+	_ resource.Resource                   = &zoneDNSSecResource{}
+	_ resource.ResourceWithConfigure      = &zoneDNSSecResource{}
+	_ resource.ResourceWithImportState    = &zoneDNSSecResource{}
+	_ resource.ResourceWithValidateConfig = &zoneDNSSecResource{}
+)
+
+// kskRolloverEnabledEnv is the environment variable which enables ksk_rollover_trigger. The key rollover endpoint is not
+// part of the published Edge DNS API yet, so the rollover is only started when it is explicitly enabled.
+const kskRolloverEnabledEnv = "AKAMAI_DNS_KSK_ROLLOVER_ENABLED"
+
+var (
+	// DNSSecStatusPollInterval is the interval of reading the DNSSEC status, while waiting for the keys of the zone
+	DNSSecStatusPollInterval = time.Second * 10
+
+	// DNSSecStatusTimeout is the time to wait for the keys of the zone after signing is enabled or the algorithm is changed
+	DNSSecStatusTimeout = time.Minute * 30
+
+	// dnsSecAlgorithms are the algorithms Edge DNS signs the zones with
+	dnsSecAlgorithms = []string{"RSA_SHA1", "RSA_SHA256", "RSA_SHA512", "ECDSA_P256_SHA256", "ECDSA_P384_SHA384"}
+
+	// dsRecordAttributeTypes are the types of the attributes of the ds_records elements
+	dsRecordAttributeTypes = map[string]attr.Type{
+		"key_tag":     types.Int64Type,
+		"algorithm":   types.Int64Type,
+		"digest_type": types.Int64Type,
+		"digest":      types.StringType,
+		"ds_record":   types.StringType,
+	}
+)
+
+type (
+	zoneDNSSecResource struct {
+		meta meta.Meta
+	}
+
+	zoneDNSSecResourceModel struct {
+		Zone               types.String     `tfsdk:"zone"`
+		Enabled            types.Bool       `tfsdk:"enabled"`
+		Algorithm          types.String     `tfsdk:"algorithm"`
+		KSKRolloverTrigger types.String     `tfsdk:"ksk_rollover_trigger"`
+		CurrentRecords     *securityRecords `tfsdk:"current_records"`
+		NewRecords         *securityRecords `tfsdk:"new_records"`
+		RolloverInProgress types.Bool       `tfsdk:"rollover_in_progress"`
+		DSRecords          types.List       `tfsdk:"ds_records"`
+		Alerts             types.Set        `tfsdk:"alerts"`
+	}
+
+	// dsRecordModel represents the fields of a DS record, as required by the domain registrars
+	dsRecordModel struct {
+		KeyTag     types.Int64  `tfsdk:"key_tag"`
+		Algorithm  types.Int64  `tfsdk:"algorithm"`
+		DigestType types.Int64  `tfsdk:"digest_type"`
+		Digest     types.String `tfsdk:"digest"`
+		DSRecord   types.String `tfsdk:"ds_record"`
+	}
+)
+
+// NewZoneDNSSecResource returns a new zone DNSSEC resource
+func NewZoneDNSSecResource() resource.Resource { return &zoneDNSSecResource{} }
+
+func (r *zoneDNSSecResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "akamai_dns_zone_dnssec"
+}
+
+func (r *zoneDNSSecResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			resp.Diagnostics.AddError(
+				"Unexpected Resource Configure Type",
+				fmt.Sprintf("Expected meta.Meta, got: %T. Please report this issue to the provider developers.",
+					req.ProviderData))
+		}
+	}()
+	r.meta = meta.Must(req.ProviderData)
+}
+
+func (r *zoneDNSSecResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	recordsAttributes := func() map[string]schema.Attribute {
+		return map[string]schema.Attribute{
+			"dnskey_record": schema.StringAttribute{
+				Computed:    true,
+				Description: "The generated DNSKEY record for this zone.",
+			},
+			"ds_record": schema.StringAttribute{
+				Computed:    true,
+				Description: "The generated DS record for this zone.",
+			},
+			"expected_ttl": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The TTL on the NS record for this zone. This should match the TTL on the DS or DNSKEY record.",
+			},
+			"last_modified_date": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ISO 8601 timestamp on which these records were generated.",
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "DNSSEC signing of a primary zone. Edge DNS rolls the key signing key (KSK) over when the algorithm " +
+			"or `ksk_rollover_trigger` changes; during the rollover both the current and the new keys are returned, and the DS records of both have to be published by the registrar.",
+		Attributes: map[string]schema.Attribute{
+			"zone": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the primary zone.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Required:    true,
+				Description: "Whether Edge DNS signs the zone. Destroying the resource also disables signing.",
+			},
+			"algorithm": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Description: fmt.Sprintf("The algorithm the zone is signed with, one of %s. Changing it starts a KSK rollover. "+
+					"If not set, the algorithm chosen by Edge DNS is used.", strings.Join(dnsSecAlgorithms, ", ")),
+				Validators: []validator.String{
+					stringvalidator.OneOf(dnsSecAlgorithms...),
+				},
+			},
+			"ksk_rollover_trigger": schema.StringAttribute{
+				Optional: true,
+				Description: "Any value, e.g. a date, which starts a KSK rollover with the current algorithm when it changes " +
+					"while signing is enabled. Setting it when the resource is created, or removing it, does not start a rollover. " +
+					"The rollover uses an Edge DNS endpoint which is not published yet, so it has to be enabled by setting the " +
+					kskRolloverEnabledEnv + " environment variable to true.",
+			},
+			"current_records": schema.SingleNestedAttribute{
+				Computed:    true,
+				Description: "The currently active set of generated DNSSEC records.",
+				Attributes:  recordsAttributes(),
+			},
+			"new_records": schema.SingleNestedAttribute{
+				Computed:    true,
+				Description: "The newly generated set of DNSSEC records, while a KSK rollover is in progress.",
+				Attributes:  recordsAttributes(),
+			},
+			"rollover_in_progress": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether a KSK rollover is in progress, i.e. new records were generated and are not active yet.",
+			},
+			"ds_records": schema.ListNestedAttribute{
+				Computed: true,
+				Description: "The DS records to be published by the registrar of the parent zone: the record of the current key " +
+					"and, during a KSK rollover, the record of the new key.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key_tag": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The key tag of the DNSKEY record.",
+						},
+						"algorithm": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The number of the algorithm of the DNSKEY record.",
+						},
+						"digest_type": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The number of the digest algorithm.",
+						},
+						"digest": schema.StringAttribute{
+							Computed:    true,
+							Description: "The digest of the DNSKEY record, hex encoded.",
+						},
+						"ds_record": schema.StringAttribute{
+							Computed:    true,
+							Description: "The DS record, as generated by Edge DNS.",
+						},
+					},
+				},
+			},
+			"alerts": schema.SetAttribute{
+				Computed:    true,
+				Description: "A set of existing problems with the current DNSSEC configuration.",
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (r *zoneDNSSecResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data zoneDNSSecResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !data.Enabled.IsUnknown() && !data.Enabled.ValueBool() && !data.Algorithm.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("algorithm"), "invalid configuration",
+			"algorithm cannot be set when signing is disabled")
+	}
+	if !data.Enabled.IsUnknown() && !data.Enabled.ValueBool() && !data.KSKRolloverTrigger.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("ksk_rollover_trigger"), "invalid configuration",
+			"ksk_rollover_trigger cannot be set when signing is disabled")
+	}
+	if !data.KSKRolloverTrigger.IsNull() && !cast.ToBool(os.Getenv(kskRolloverEnabledEnv)) {
+		resp.Diagnostics.AddAttributeError(path.Root("ksk_rollover_trigger"), "invalid configuration",
+			fmt.Sprintf("ksk_rollover_trigger requires the %s environment variable to be set to true", kskRolloverEnabledEnv))
+	}
+}
+
+func (r *zoneDNSSecResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Creating DNS Zone DNSSEC resource")
+	var plan zoneDNSSecResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.apply(ctx, &plan); err != nil {
+		resp.Diagnostics.AddError("create DNS zone DNSSEC failed", err.Error())
+		return
+	}
+	warnings, err := r.read(ctx, &plan)
+	resp.Diagnostics.Append(warnings...)
+	if err != nil {
+		resp.Diagnostics.AddError("read DNS zone DNSSEC failed", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *zoneDNSSecResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "Reading DNS Zone DNSSEC resource")
+	var state zoneDNSSecResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	warnings, err := r.read(ctx, &state)
+	resp.Diagnostics.Append(warnings...)
+	if err != nil {
+		var apiError *dns.Error
+		if errors.As(err, &apiError) && apiError.StatusCode == http.StatusNotFound {
+			tflog.Warn(ctx, fmt.Sprintf("zone %s not found, removing from state", state.Zone.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("read DNS zone DNSSEC failed", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *zoneDNSSecResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Debug(ctx, "Updating DNS Zone DNSSEC resource")
+	var plan, state zoneDNSSecResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.apply(ctx, &plan); err != nil {
+		resp.Diagnostics.AddError("update DNS zone DNSSEC failed", err.Error())
+		return
+	}
+	if rolloverTriggered(&state, &plan) {
+		if err := r.rolloverKSK(ctx, plan.Zone.ValueString()); err != nil {
+			resp.Diagnostics.AddError("KSK rollover of DNS zone failed", err.Error())
+			return
+		}
+	}
+	warnings, err := r.read(ctx, &plan)
+	resp.Diagnostics.Append(warnings...)
+	if err != nil {
+		resp.Diagnostics.AddError("read DNS zone DNSSEC failed", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *zoneDNSSecResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Deleting DNS Zone DNSSEC resource")
+	var state zoneDNSSecResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Enabled = types.BoolValue(false)
+	state.Algorithm = types.StringNull()
+	if err := r.apply(ctx, &state); err != nil {
+		resp.Diagnostics.AddError("delete DNS zone DNSSEC failed", err.Error())
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *zoneDNSSecResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	tflog.Debug(ctx, "Importing DNS Zone DNSSEC resource")
+
+	resource.ImportStatePassthroughID(ctx, path.Root("zone"), req, resp)
+}
+
+// apply updates the signing of the zone and waits for the keys, if signing is enabled or the algorithm changed
+func (r *zoneDNSSecResource) apply(ctx context.Context, data *zoneDNSSecResourceModel) error {
+	client := inst.Client(r.meta)
+	zoneName := data.Zone.ValueString()
+
+	zone, err := client.GetZone(ctx, dns.GetZoneRequest{Zone: zoneName})
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(zone.Type, "PRIMARY") {
+		return fmt.Errorf("DNSSEC signing is only supported for primary zones, zone %s is %s", zoneName, zone.Type)
+	}
+
+	enabled := data.Enabled.ValueBool()
+	algorithm := zone.SignAndServeAlgorithm
+	if !data.Algorithm.IsNull() && !data.Algorithm.IsUnknown() {
+		algorithm = data.Algorithm.ValueString()
+	}
+	if !enabled {
+		algorithm = ""
+	}
+	if zone.SignAndServe == enabled && zone.SignAndServeAlgorithm == algorithm {
+		tflog.Debug(ctx, fmt.Sprintf("DNSSEC signing of zone %s is up to date", zoneName))
+		return nil
+	}
+
+	err = client.UpdateZone(ctx, dns.UpdateZoneRequest{
+		CreateZone: &dns.ZoneCreate{
+			Zone:                  zone.Zone,
+			Type:                  zone.Type,
+			Comment:               zone.Comment,
+			SignAndServe:          enabled,
+			SignAndServeAlgorithm: algorithm,
+			EndCustomerID:         zone.EndCustomerID,
+			ContractID:            zone.ContractID,
+			OutboundZoneTransfer:  zone.OutboundZoneTransfer,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return nil
+	}
+	return waitForDNSSecKeys(ctx, client, zoneName, false)
+}
+
+// rolloverTriggered returns whether the KSK of the zone has to be rolled over, i.e. ksk_rollover_trigger changed
+// to a new value of a signed zone, and neither signing was enabled nor the algorithm changed, which generate new keys anyway
+func rolloverTriggered(state, plan *zoneDNSSecResourceModel) bool {
+	if plan.KSKRolloverTrigger.IsNull() || plan.KSKRolloverTrigger.Equal(state.KSKRolloverTrigger) {
+		return false
+	}
+	if !state.Enabled.ValueBool() || !plan.Enabled.ValueBool() {
+		return false
+	}
+	// the algorithm is unknown if it is not configured, and then the current algorithm is kept
+	return plan.Algorithm.IsUnknown() || plan.Algorithm.Equal(state.Algorithm)
+}
+
+// rolloverKSK starts a KSK rollover of the zone and waits for the new keys
+func (r *zoneDNSSecResource) rolloverKSK(ctx context.Context, zone string) error {
+	if err := inst.DNSSecClient(r.meta).RolloverKSK(ctx, dnssec.RolloverKSKRequest{Zone: zone}); err != nil {
+		return err
+	}
+	return waitForDNSSecKeys(ctx, inst.Client(r.meta), zone, true)
+}
+
+// read sets the signing configuration and the DNSSEC records of the zone. The returned diagnostics hold the warnings
+// about records which could not be read.
+func (r *zoneDNSSecResource) read(ctx context.Context, data *zoneDNSSecResourceModel) (diag.Diagnostics, error) {
+	client := inst.Client(r.meta)
+	zoneName := data.Zone.ValueString()
+
+	zone, err := client.GetZone(ctx, dns.GetZoneRequest{Zone: zoneName})
+	if err != nil {
+		return nil, err
+	}
+	data.Enabled = types.BoolValue(zone.SignAndServe)
+	data.Algorithm = types.StringNull()
+	if zone.SignAndServeAlgorithm != "" {
+		data.Algorithm = types.StringValue(zone.SignAndServeAlgorithm)
+	}
+
+	var status *dns.SecStatus
+	if zone.SignAndServe {
+		status, err = getDNSSecStatus(ctx, client, zoneName)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return data.setStatus(ctx, status)
+}
+
+// setStatus sets the DNSSEC records of the status. DS records which cannot be parsed are left out of ds_records
+// with a warning, as they are still returned in current_records and new_records.
+func (m *zoneDNSSecResourceModel) setStatus(ctx context.Context, status *dns.SecStatus) (diag.Diagnostics, error) {
+	var warnings diag.Diagnostics
+	m.CurrentRecords, m.NewRecords = nil, nil
+	m.RolloverInProgress = types.BoolValue(false)
+	dsRecords := make([]dsRecordModel, 0, 2)
+	var alerts []string
+
+	if status != nil {
+		m.CurrentRecords = ptr.To(newSecurityRecords(status.CurrentRecords))
+		records := []dns.SecRecords{status.CurrentRecords}
+		if status.NewRecords != nil {
+			m.NewRecords = ptr.To(newSecurityRecords(*status.NewRecords))
+			m.RolloverInProgress = types.BoolValue(true)
+			records = append(records, *status.NewRecords)
+		}
+		for _, record := range records {
+			dsRecord, err := parseDSRecord(record.DSRecord)
+			if err != nil {
+				warnings.AddAttributeWarning(path.Root("ds_records"), "could not parse DS record",
+					fmt.Sprintf("DS record %q returned by Edge DNS is not included in ds_records: %s", record.DSRecord, err))
+				continue
+			}
+			dsRecords = append(dsRecords, dsRecord)
+		}
+		alerts = status.Alerts
+	}
+
+	var diags diag.Diagnostics
+	m.DSRecords, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: dsRecordAttributeTypes}, dsRecords)
+	if diags.HasError() {
+		return nil, fmt.Errorf("could not set ds_records: %v", diags.Errors())
+	}
+	m.Alerts, diags = types.SetValueFrom(ctx, types.StringType, alerts)
+	if diags.HasError() {
+		return nil, fmt.Errorf("could not set alerts: %v", diags.Errors())
+	}
+	return warnings, nil
+}
+
+// getDNSSecStatus returns the DNSSEC status of the zone, or nil if Edge DNS returned no status for the zone
+func getDNSSecStatus(ctx context.Context, client dns.DNS, zone string) (*dns.SecStatus, error) {
+	resp, err := client.GetZonesDNSSecStatus(ctx, dns.GetZonesDNSSecStatusRequest{Zones: []string{zone}})
+	if err != nil {
+		return nil, err
+	}
+	for _, status := range resp.DNSSecStatuses {
+		if strings.EqualFold(strings.TrimSuffix(status.Zone, "."), strings.TrimSuffix(zone, ".")) {
+			return &status, nil
+		}
+	}
+	return nil, nil
+}
+
+// waitForDNSSecKeys waits until Edge DNS generates the DNSSEC records of the zone, or the new records of a KSK rollover
+func waitForDNSSecKeys(ctx context.Context, client dns.DNS, zone string, rollover bool) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, DNSSecStatusTimeout)
+	defer cancel()
+
+	for {
+		status, err := getDNSSecStatus(ctx, client, zone)
+		if err != nil {
+			return err
+		}
+		if status != nil && status.CurrentRecords.DSRecord != "" && (!rollover || status.NewRecords != nil) {
+			return nil
+		}
+		tflog.Debug(ctx, fmt.Sprintf("waiting for the DNSSEC records of zone %s", zone))
+
+		select {
+		case <-time.After(DNSSecStatusPollInterval):
+			continue
+		case <-timeoutCtx.Done():
+			return fmt.Errorf("timed out waiting for the DNSSEC records of zone %s: %w", zone, timeoutCtx.Err())
+		}
+	}
+}
+
+// parseDSRecord returns the fields of the DS record, which is either the record data, or the whole record
+// in zone file format, e.g. 'example.com. 86400 IN DS 12345 13 2 <digest>'
+func parseDSRecord(record string) (dsRecordModel, error) {
+	fields := strings.Fields(record)
+	for i, field := range fields {
+		if strings.EqualFold(field, "DS") {
+			fields = fields[i+1:]
+			break
+		}
+	}
+	if len(fields) < 4 {
+		return dsRecordModel{}, fmt.Errorf("expected at least 4 fields, got %d", len(fields))
+	}
+
+	numbers := make([]int64, 3)
+	for i := range numbers {
+		n, err := strconv.ParseInt(fields[i], 10, 64)
+		if err != nil {
+			return dsRecordModel{}, fmt.Errorf("field %d is not a number: %w", i+1, err)
+		}
+		numbers[i] = n
+	}
+	return dsRecordModel{
+		KeyTag:     types.Int64Value(numbers[0]),
+		Algorithm:  types.Int64Value(numbers[1]),
+		DigestType: types.Int64Value(numbers[2]),
+		Digest:     types.StringValue(strings.ToUpper(strings.Join(fields[3:], ""))),
+		DSRecord:   types.StringValue(record),
+	}, nil
+}
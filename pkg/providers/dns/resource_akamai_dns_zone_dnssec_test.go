@@ -0,0 +1,360 @@
+package dns
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/dns"
+	"github.com/akamai/terraform-provider-akamai/v7/internal/test"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/testutils"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/providers/dns/internal/dnssec"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResDnsZoneDnssec(t *testing.T) {
+	anyContext := mock.AnythingOfType("*context.valueCtx")
+	pollInterval := DNSSecStatusPollInterval
+	DNSSecStatusPollInterval = time.Millisecond
+	defer func() { DNSSecStatusPollInterval = pollInterval }()
+
+	currentRecords := dns.SecRecords{
+		DNSKeyRecord:     "example.com. 7200 IN DNSKEY 257 3 13 mdsswUyr3DPW132mOi8V9xESWE8jTo0d",
+		DSRecord:         "example.com. 86400 IN DS 2371 13 2 1f987cc6583e92df0890718c42 1d2890be5cd4ab48c7f7a0d3b6b2c5d4e2f1a0",
+		ExpectedTTL:      86400,
+		LastModifiedDate: test.NewTimeFromString(t, "2024-05-28T06:58:26Z"),
+	}
+	newRecords := dns.SecRecords{
+		DNSKeyRecord:     "example.com. 7200 IN DNSKEY 257 3 8 AwEAAagAIKlVZrpC6Ia7gEzahOR+9W29",
+		DSRecord:         "example.com. 86400 IN DS 31589 8 2 cde0d742d6998aa554a92d890f8184c698cfac8a26fa59875a990c03e576343c",
+		ExpectedTTL:      86400,
+		LastModifiedDate: test.NewTimeFromString(t, "2024-05-31T13:27:55Z"),
+	}
+	rolledRecords := dns.SecRecords{
+		DNSKeyRecord:     "example.com. 7200 IN DNSKEY 257 3 13 oJMRESz5E4gYzS/q6XDrvU1qMPYIjCWz",
+		DSRecord:         "example.com. 86400 IN DS 40216 13 2 5a1c7c8e2f6d4b3a9e0f1d2c3b4a5968778695a4b3c2d1e0f9a8b7c6d5e4f3a2",
+		ExpectedTTL:      86400,
+		LastModifiedDate: test.NewTimeFromString(t, "2024-06-03T09:12:40Z"),
+	}
+	statusRequest := dns.GetZonesDNSSecStatusRequest{Zones: []string{"example.com"}}
+
+	// mockZone returns the unsigned zone and its DNSSEC status, as updated by the previous calls of UpdateZone and RolloverKSK
+	mockZone := func(m *dns.Mock, rollover *dnssec.Mock) {
+		zone := &dns.GetZoneResponse{
+			Zone:       "example.com",
+			Type:       "PRIMARY",
+			ContractID: "1-2ABCDE",
+			Comment:    "test zone",
+		}
+		status := &dns.GetZonesDNSSecStatusResponse{}
+		m.On("GetZone", anyContext, dns.GetZoneRequest{Zone: "example.com"}).Return(zone, nil)
+		m.On("GetZonesDNSSecStatus", anyContext, statusRequest).Return(status, nil)
+		m.On("UpdateZone", anyContext, mock.AnythingOfType("dns.UpdateZoneRequest")).Run(func(args mock.Arguments) {
+			update := args.Get(1).(dns.UpdateZoneRequest).CreateZone
+			switch {
+			case !update.SignAndServe:
+				status.DNSSecStatuses = nil
+			case !zone.SignAndServe:
+				status.DNSSecStatuses = []dns.SecStatus{{Zone: "example.com", CurrentRecords: currentRecords}}
+			default:
+				status.DNSSecStatuses = []dns.SecStatus{{Zone: "example.com", CurrentRecords: currentRecords, NewRecords: &newRecords}}
+			}
+			zone.SignAndServe = update.SignAndServe
+			zone.SignAndServeAlgorithm = update.SignAndServeAlgorithm
+		}).Return(nil)
+		rollover.On("RolloverKSK", anyContext, dnssec.RolloverKSKRequest{Zone: "example.com"}).Run(func(mock.Arguments) {
+			status.DNSSecStatuses = []dns.SecStatus{{Zone: "example.com", CurrentRecords: currentRecords, NewRecords: &rolledRecords}}
+		}).Return(nil).Once()
+	}
+
+	tests := map[string]struct {
+		init            func(*dns.Mock, *dnssec.Mock)
+		steps           []resource.TestStep
+		rolloverEnabled string
+	}{
+		"enable signing, roll the key over and disable signing": {
+			rolloverEnabled: "true",
+			init: func(m *dns.Mock, rollover *dnssec.Mock) {
+				mockZone(m, rollover)
+			},
+			steps: []resource.TestStep{
+				{
+					Config: testutils.LoadFixtureString(t, "testdata/TestResDnsZoneDnssec/create.tf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("akamai_dns_zone_dnssec.test", "enabled", "true"),
+						resource.TestCheckResourceAttr("akamai_dns_zone_dnssec.test", "algorithm", "ECDSA_P256_SHA256"),
+						resource.TestCheckResourceAttr("akamai_dns_zone_dnssec.test", "rollover_in_progress", "false"),
+						resource.TestCheckResourceAttr("akamai_dns_zone_dnssec.test", "current_records.expected_ttl", "86400"),
+						resource.TestCheckNoResourceAttr("akamai_dns_zone_dnssec.test", "new_records"),
+						resource.TestCheckResourceAttr("akamai_dns_zone_dnssec.test", "ds_records.#", "1"),
+						resource.TestCheckResourceAttr("akamai_dns_zone_dnssec.test", "ds_records.0.key_tag", "2371"),
+						resource.TestCheckResourceAttr("akamai_dns_zone_dnssec.test", "ds_records.0.algorithm", "13"),
+						resource.TestCheckResourceAttr("akamai_dns_zone_dnssec.test", "ds_records.0.digest_type", "2"),
+						resource.TestCheckResourceAttr("akamai_dns_zone_dnssec.test", "ds_records.0.digest", "1F987CC6583E92DF0890718C421D2890BE5CD4AB48C7F7A0D3B6B2C5D4E2F1A0"),
+					),
+				},
+				{
+					ImportState:                          true,
+					ImportStateId:                        "example.com",
+					ResourceName:                         "akamai_dns_zone_dnssec.test",
+					ImportStateVerify:                    true,
+					ImportStateVerifyIdentifierAttribute: "zone",
+				},
+				{
+					Config: testutils.LoadFixtureString(t, "testdata/TestResDnsZoneDnssec/rollover.tf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("akamai_dns_zone_dnssec.test", "algorithm", "ECDSA_P256_SHA256"),
+						resource.TestCheckResourceAttr("akamai_dns_zone_dnssec.test", "ksk_rollover_trigger", "2026-10"),
+						resource.TestCheckResourceAttr("akamai_dns_zone_dnssec.test", "rollover_in_progress", "true"),
+						resource.TestCheckResourceAttr("akamai_dns_zone_dnssec.test", "new_records.ds_record", rolledRecords.DSRecord),
+						resource.TestCheckResourceAttr("akamai_dns_zone_dnssec.test", "ds_records.#", "2"),
+						resource.TestCheckResourceAttr("akamai_dns_zone_dnssec.test", "ds_records.1.key_tag", "40216"),
+						resource.TestCheckResourceAttr("akamai_dns_zone_dnssec.test", "ds_records.1.algorithm", "13"),
+					),
+				},
+				{
+					Config: testutils.LoadFixtureString(t, "testdata/TestResDnsZoneDnssec/update_algorithm.tf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("akamai_dns_zone_dnssec.test", "algorithm", "RSA_SHA256"),
+						resource.TestCheckResourceAttr("akamai_dns_zone_dnssec.test", "rollover_in_progress", "true"),
+						resource.TestCheckResourceAttr("akamai_dns_zone_dnssec.test", "new_records.ds_record", newRecords.DSRecord),
+						resource.TestCheckResourceAttr("akamai_dns_zone_dnssec.test", "ds_records.#", "2"),
+						resource.TestCheckResourceAttr("akamai_dns_zone_dnssec.test", "ds_records.1.key_tag", "31589"),
+						resource.TestCheckResourceAttr("akamai_dns_zone_dnssec.test", "ds_records.1.algorithm", "8"),
+					),
+				},
+				{
+					Config: testutils.LoadFixtureString(t, "testdata/TestResDnsZoneDnssec/disabled.tf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("akamai_dns_zone_dnssec.test", "enabled", "false"),
+						resource.TestCheckNoResourceAttr("akamai_dns_zone_dnssec.test", "algorithm"),
+						resource.TestCheckNoResourceAttr("akamai_dns_zone_dnssec.test", "current_records"),
+						resource.TestCheckResourceAttr("akamai_dns_zone_dnssec.test", "ds_records.#", "0"),
+					),
+				},
+			},
+		},
+		"secondary zone cannot be signed": {
+			init: func(m *dns.Mock, _ *dnssec.Mock) {
+				m.On("GetZone", anyContext, dns.GetZoneRequest{Zone: "example.com"}).Return(&dns.GetZoneResponse{
+					Zone: "example.com",
+					Type: "SECONDARY",
+				}, nil)
+			},
+			steps: []resource.TestStep{
+				{
+					Config:      testutils.LoadFixtureString(t, "testdata/TestResDnsZoneDnssec/create.tf"),
+					ExpectError: regexp.MustCompile("DNSSEC signing is only supported for primary zones, zone example.com is SECONDARY"),
+				},
+			},
+		},
+		"algorithm set with signing disabled": {
+			steps: []resource.TestStep{
+				{
+					Config:      testutils.LoadFixtureString(t, "testdata/TestResDnsZoneDnssec/algorithm_disabled.tf"),
+					ExpectError: regexp.MustCompile("algorithm cannot be set when signing is disabled"),
+				},
+			},
+		},
+		"rollover triggered with signing disabled": {
+			rolloverEnabled: "true",
+			steps: []resource.TestStep{
+				{
+					Config:      testutils.LoadFixtureString(t, "testdata/TestResDnsZoneDnssec/rollover_disabled.tf"),
+					ExpectError: regexp.MustCompile("ksk_rollover_trigger cannot be set when signing is disabled"),
+				},
+			},
+		},
+		"rollover not enabled": {
+			steps: []resource.TestStep{
+				{
+					Config:      testutils.LoadFixtureString(t, "testdata/TestResDnsZoneDnssec/rollover.tf"),
+					ExpectError: regexp.MustCompile("ksk_rollover_trigger requires the AKAMAI_DNS_KSK_ROLLOVER_ENABLED environment"),
+				},
+			},
+		},
+		"unsupported algorithm": {
+			steps: []resource.TestStep{
+				{
+					Config:      testutils.LoadFixtureString(t, "testdata/TestResDnsZoneDnssec/invalid_algorithm.tf"),
+					ExpectError: regexp.MustCompile(`Attribute algorithm value must be one of`),
+				},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(kskRolloverEnabledEnv, tc.rolloverEnabled)
+			client := &dns.Mock{}
+			rollover := &dnssec.Mock{}
+			if tc.init != nil {
+				tc.init(client, rollover)
+			}
+			useClient(client, func() {
+				useDNSSecClient(rollover, func() {
+					resource.UnitTest(t, resource.TestCase{
+						ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+						IsUnitTest:               true,
+						Steps:                    tc.steps,
+					})
+				})
+			})
+			client.AssertExpectations(t)
+			rollover.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRolloverTriggered(t *testing.T) {
+	signed := zoneDNSSecResourceModel{
+		Enabled:            types.BoolValue(true),
+		Algorithm:          types.StringValue("ECDSA_P256_SHA256"),
+		KSKRolloverTrigger: types.StringValue("2026-01"),
+	}
+	with := func(m zoneDNSSecResourceModel, f func(*zoneDNSSecResourceModel)) zoneDNSSecResourceModel {
+		f(&m)
+		return m
+	}
+
+	tests := map[string]struct {
+		state, plan zoneDNSSecResourceModel
+		expected    bool
+	}{
+		"trigger changed": {
+			state: signed,
+			plan: with(signed, func(m *zoneDNSSecResourceModel) {
+				m.KSKRolloverTrigger = types.StringValue("2026-10")
+			}),
+			expected: true,
+		},
+		"trigger set": {
+			state:    with(signed, func(m *zoneDNSSecResourceModel) { m.KSKRolloverTrigger = types.StringNull() }),
+			plan:     signed,
+			expected: true,
+		},
+		"trigger changed, algorithm not configured": {
+			state: signed,
+			plan: with(signed, func(m *zoneDNSSecResourceModel) {
+				m.Algorithm = types.StringUnknown()
+				m.KSKRolloverTrigger = types.StringValue("2026-10")
+			}),
+			expected: true,
+		},
+		"trigger unchanged": {
+			state: signed,
+			plan:  signed,
+		},
+		"trigger removed": {
+			state: signed,
+			plan:  with(signed, func(m *zoneDNSSecResourceModel) { m.KSKRolloverTrigger = types.StringNull() }),
+		},
+		"algorithm changed": {
+			state: signed,
+			plan: with(signed, func(m *zoneDNSSecResourceModel) {
+				m.Algorithm = types.StringValue("RSA_SHA256")
+				m.KSKRolloverTrigger = types.StringValue("2026-10")
+			}),
+		},
+		"signing enabled": {
+			state: with(signed, func(m *zoneDNSSecResourceModel) {
+				m.Enabled = types.BoolValue(false)
+				m.Algorithm = types.StringNull()
+				m.KSKRolloverTrigger = types.StringNull()
+			}),
+			plan: signed,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, rolloverTriggered(&tc.state, &tc.plan))
+		})
+	}
+}
+
+func TestSetStatus(t *testing.T) {
+	records := dns.SecRecords{
+		DNSKeyRecord: "example.com. 7200 IN DNSKEY 257 3 13 mdsswUyr3DPW132mOi8V9xESWE8jTo0d",
+		DSRecord:     "example.com. 86400 IN DS 2371 13 2 1f987cc6583e92df0890718c42",
+		ExpectedTTL:  86400,
+	}
+	invalidRecords := records
+	invalidRecords.DSRecord = "DUMMY_DS_RECORD"
+
+	var data zoneDNSSecResourceModel
+	warnings, err := data.setStatus(context.Background(), &dns.SecStatus{
+		Zone:           "example.com",
+		CurrentRecords: records,
+		NewRecords:     &invalidRecords,
+	})
+	require.NoError(t, err)
+	assert.Len(t, data.DSRecords.Elements(), 1)
+	require.Len(t, warnings, 1)
+	assert.Equal(t, diag.SeverityWarning, warnings[0].Severity())
+	assert.Equal(t, "could not parse DS record", warnings[0].Summary())
+	assert.Contains(t, warnings[0].Detail(), `DS record "DUMMY_DS_RECORD" returned by Edge DNS is not included in ds_records`)
+
+	warnings, err = data.setStatus(context.Background(), &dns.SecStatus{Zone: "example.com", CurrentRecords: records})
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+}
+
+func TestParseDSRecord(t *testing.T) {
+	tests := map[string]struct {
+		record        string
+		expected      dsRecordModel
+		expectedError string
+	}{
+		"record data": {
+			record: "2371 13 2 1f987cc6583e92df0890718c42",
+			expected: dsRecordModel{
+				KeyTag:     types.Int64Value(2371),
+				Algorithm:  types.Int64Value(13),
+				DigestType: types.Int64Value(2),
+				Digest:     types.StringValue("1F987CC6583E92DF0890718C42"),
+				DSRecord:   types.StringValue("2371 13 2 1f987cc6583e92df0890718c42"),
+			},
+		},
+		"whole record with split digest": {
+			record: "example.com. 86400 IN DS 31589 8 2 cde0d742 d6998aa5",
+			expected: dsRecordModel{
+				KeyTag:     types.Int64Value(31589),
+				Algorithm:  types.Int64Value(8),
+				DigestType: types.Int64Value(2),
+				Digest:     types.StringValue("CDE0D742D6998AA5"),
+				DSRecord:   types.StringValue("example.com. 86400 IN DS 31589 8 2 cde0d742 d6998aa5"),
+			},
+		},
+		"missing digest": {
+			record:        "example.com. 86400 IN DS 31589 8 2",
+			expectedError: "expected at least 4 fields, got 3",
+		},
+		"not a DS record": {
+			record:        "DUMMY_DS_RECORD",
+			expectedError: "expected at least 4 fields, got 1",
+		},
+		"invalid algorithm": {
+			record:        "31589 RSASHA256 2 cde0d742",
+			expectedError: "field 2 is not a number",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			record, err := parseDSRecord(tc.record)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, record)
+		})
+	}
+}
@@ -0,0 +1,383 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/dns"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/log"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/providers/dns/internal/zonefile"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// caseSensitiveTypes are the record types whose data is compared with case, as it holds text rather than names
+var caseSensitiveTypes = []string{RRTypeCaa, RRTypeHinfo, RRTypeNaptr, RRTypeSpf, RRTypeTxt}
+
+// zoneRecordsChanges is the changeset turning the record sets of a zone into the desired ones
+type zoneRecordsChanges struct {
+	Add    []dns.RecordSet
+	Update []dns.RecordSet
+	Delete []dns.RecordSet
+}
+
+func resourceDNSZoneRecords() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDNSZoneRecordsCreate,
+		ReadContext:   resourceDNSZoneRecordsRead,
+		UpdateContext: resourceDNSZoneRecordsUpdate,
+		DeleteContext: resourceDNSZoneRecordsDelete,
+		CustomizeDiff: validateZoneRecordsDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDNSZoneRecordsImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the zone whose record sets are managed.",
+			},
+			"recordset": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Description: "All record sets of the zone, except the SOA record and the NS records of the zone apex, " +
+					"which are managed by Edge DNS. Record sets of the zone not listed here are deleted.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The fully qualified name of the records, without the trailing dot.",
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+								RRTypeA, RRTypeAaaa, RRTypeAfsdb, RRTypeAkamaiCdn, RRTypeAkamaiTlc, RRTypeCaa, RRTypeCert,
+								RRTypeCname, RRTypeDnskey, RRTypeDs, RRTypeHinfo, RRTypeHTTPS, RRTypeLoc, RRTypeMx, RRTypeNaptr,
+								RRTypeNs, RRTypeNsec3, RRTypeNsec3Param, RRTypePtr, RRTypeRp, RRTypeRrsig, RRTypeSpf, RRTypeSrv,
+								RRTypeSshfp, RRTypeSvcb, RRTypeTlsa, RRTypeTxt,
+							}, false)),
+							Description: "The type of the records.",
+						},
+						"ttl": {
+							Type:             schema.TypeInt,
+							Required:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+							Description:      "The TTL of the record set.",
+						},
+						"rdata": {
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The data of the records.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceDNSZoneRecordsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("AkamaiDNS", "resourceDNSZoneRecordsCreate")
+	ctx = session.ContextWithOptions(ctx, session.WithContextLog(logger))
+
+	zone, err := tf.GetStringValue("zone", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	logger.Info("Zone Records Create", "zone", zone)
+
+	if err = applyZoneRecords(ctx, meta, zone, getZoneRecordSets(d), logger); err != nil {
+		return diag.Errorf("creating record sets of zone %s: %s", zone, err)
+	}
+	d.SetId(zone)
+
+	return resourceDNSZoneRecordsRead(ctx, d, m)
+}
+
+func resourceDNSZoneRecordsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("AkamaiDNS", "resourceDNSZoneRecordsRead")
+	ctx = session.ContextWithOptions(ctx, session.WithContextLog(logger))
+
+	zone := d.Id()
+	logger.Info("Zone Records Read", "zone", zone)
+
+	current, err := listZoneRecordSets(ctx, meta, zone)
+	if err != nil {
+		var apiError *dns.Error
+		if errors.As(err, &apiError) && apiError.StatusCode == http.StatusNotFound {
+			logger.Warnf("Zone %s not found, removing record sets from state", zone)
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("reading record sets of zone %s: %s", zone, err)
+	}
+	managed := managedZoneRecordSets(zone, current)
+
+	// keep the configured form of the record sets whose data differs only in formatting, e.g. relative names
+	configured := getZoneRecordSets(d)
+	recordSets := make([]interface{}, 0, len(managed))
+	for _, recordSet := range managed {
+		for _, c := range configured {
+			if equalRecordSets(zone, recordSet, c) {
+				recordSet = c
+				break
+			}
+		}
+		recordSets = append(recordSets, map[string]interface{}{
+			"name":  recordSet.Name,
+			"type":  recordSet.Type,
+			"ttl":   recordSet.TTL,
+			"rdata": recordSet.Rdata,
+		})
+	}
+
+	if err = d.Set("zone", zone); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("recordset", recordSets); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceDNSZoneRecordsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("AkamaiDNS", "resourceDNSZoneRecordsUpdate")
+	ctx = session.ContextWithOptions(ctx, session.WithContextLog(logger))
+
+	zone := d.Id()
+	logger.Info("Zone Records Update", "zone", zone)
+
+	if err := applyZoneRecords(ctx, meta, zone, getZoneRecordSets(d), logger); err != nil {
+		return diag.Errorf("updating record sets of zone %s: %s", zone, err)
+	}
+
+	return resourceDNSZoneRecordsRead(ctx, d, m)
+}
+
+func resourceDNSZoneRecordsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("AkamaiDNS", "resourceDNSZoneRecordsDelete")
+	ctx = session.ContextWithOptions(ctx, session.WithContextLog(logger))
+
+	zone := d.Id()
+	logger.Info("Zone Records Delete", "zone", zone)
+
+	if err := applyZoneRecords(ctx, meta, zone, nil, logger); err != nil {
+		return diag.Errorf("deleting record sets of zone %s: %s", zone, err)
+	}
+	d.SetId("")
+	return nil
+}
+
+func resourceDNSZoneRecordsImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	if err := d.Set("zone", d.Id()); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// validateZoneRecordsDiff reports the errors in the record sets before the plan is applied
+func validateZoneRecordsDiff(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("zone") || !d.NewValueKnown("recordset") {
+		return nil
+	}
+	zone := strings.TrimSuffix(d.Get("zone").(string), ".")
+
+	seen := make(map[string]struct{})
+	recordSets := recordSetsFromSet(d.Get("recordset").(*schema.Set))
+	for _, recordSet := range recordSets {
+		if recordSet.Name == "" {
+			// the name is not known yet
+			continue
+		}
+		if !strings.EqualFold(recordSet.Name, zone) && !strings.HasSuffix(strings.ToLower(recordSet.Name), "."+strings.ToLower(zone)) {
+			return fmt.Errorf("record set %s %s is not in zone %s", recordSet.Name, recordSet.Type, zone)
+		}
+		if recordSet.Type == RRTypeNs && strings.EqualFold(recordSet.Name, zone) {
+			return fmt.Errorf("NS records of the zone apex %s are managed by Edge DNS and cannot be set", zone)
+		}
+		key := recordSetKey(recordSet)
+		if _, ok := seen[key]; ok {
+			return fmt.Errorf("record set %s %s is defined more than once", recordSet.Name, recordSet.Type)
+		}
+		seen[key] = struct{}{}
+		for _, rdata := range recordSet.Rdata {
+			if err := zonefile.Validate(recordSet.Type, rdata, zone); err != nil {
+				return fmt.Errorf("record set %s %s: %w", recordSet.Name, recordSet.Type, err)
+			}
+		}
+	}
+	return planRecordSets(m, zone, plannedZoneRecordSets(recordSets))
+}
+
+// applyZoneRecords submits the minimal changeset turning the record sets of the zone into the desired ones.
+// Removed and changed record sets are deleted and updated one by one, before the added ones are created
+// in a single call, so that the record sets outside of the changeset are never rewritten.
+func applyZoneRecords(ctx context.Context, meta meta.Meta, zone string, desired []dns.RecordSet, logger log.Interface) error {
+	current, err := listZoneRecordSets(ctx, meta, zone)
+	if err != nil {
+		return err
+	}
+	managed := managedZoneRecordSets(zone, current)
+	changes := diffZoneRecordSets(zone, managed, desired)
+	logger.Debugf("Record sets of zone %s to add: %d, to update: %d, to delete: %d",
+		zone, len(changes.Add), len(changes.Update), len(changes.Delete))
+
+	client := inst.Client(meta)
+	for _, recordSet := range changes.Delete {
+		if err = client.DeleteRecord(ctx, dns.DeleteRecordRequest{
+			Zone:       zone,
+			Name:       recordSet.Name,
+			RecordType: recordSet.Type,
+			RecLock:    []bool{true},
+		}); err != nil {
+			return err
+		}
+	}
+	for _, recordSet := range changes.Update {
+		if err = client.UpdateRecord(ctx, dns.UpdateRecordRequest{
+			Zone: zone,
+			Record: &dns.RecordBody{
+				Name:       recordSet.Name,
+				RecordType: recordSet.Type,
+				TTL:        recordSet.TTL,
+				Target:     recordSet.Rdata,
+			},
+			RecLock: []bool{true},
+		}); err != nil {
+			return err
+		}
+	}
+	if len(changes.Add) == 0 {
+		return nil
+	}
+	return client.CreateRecordSets(ctx, dns.CreateRecordSetsRequest{
+		Zone:       zone,
+		RecordSets: &dns.RecordSets{RecordSets: changes.Add},
+		RecLock:    []bool{true},
+	})
+}
+
+// listZoneRecordSets returns all record sets of the zone
+func listZoneRecordSets(ctx context.Context, meta meta.Meta, zone string) ([]dns.RecordSet, error) {
+	resp, err := inst.Client(meta).GetRecordSets(ctx, dns.GetRecordSetsRequest{
+		Zone:      zone,
+		QueryArgs: &dns.RecordSetQueryArgs{ShowAll: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.RecordSets, nil
+}
+
+// managedZoneRecordSets returns the record sets managed by the resource, i.e. all but the SOA and NS records
+// of the zone apex, which are managed by Edge DNS
+func managedZoneRecordSets(zone string, recordSets []dns.RecordSet) []dns.RecordSet {
+	var managed []dns.RecordSet
+	for _, recordSet := range recordSets {
+		apex := strings.EqualFold(recordSet.Name, zone)
+		if recordSet.Type == RRTypeSoa || (apex && recordSet.Type == RRTypeNs) {
+			continue
+		}
+		managed = append(managed, recordSet)
+	}
+	return managed
+}
+
+// diffZoneRecordSets returns the minimal changeset turning the current record sets into the desired ones
+func diffZoneRecordSets(zone string, current, desired []dns.RecordSet) zoneRecordsChanges {
+	var changes zoneRecordsChanges
+	currentByKey := make(map[string]dns.RecordSet, len(current))
+	for _, recordSet := range current {
+		currentByKey[recordSetKey(recordSet)] = recordSet
+	}
+	desiredKeys := make(map[string]struct{}, len(desired))
+	for _, recordSet := range desired {
+		key := recordSetKey(recordSet)
+		desiredKeys[key] = struct{}{}
+		existing, ok := currentByKey[key]
+		switch {
+		case !ok:
+			changes.Add = append(changes.Add, recordSet)
+		case !equalRecordSets(zone, existing, recordSet):
+			changes.Update = append(changes.Update, recordSet)
+		}
+	}
+	for _, recordSet := range current {
+		if _, ok := desiredKeys[recordSetKey(recordSet)]; !ok {
+			changes.Delete = append(changes.Delete, recordSet)
+		}
+	}
+	return changes
+}
+
+func recordSetKey(recordSet dns.RecordSet) string {
+	return strings.ToLower(strings.TrimSuffix(recordSet.Name, ".")) + " " + strings.ToUpper(recordSet.Type)
+}
+
+// equalRecordSets reports whether the record sets have the same name, type, TTL and data, regardless of the order
+// and formatting of the data
+func equalRecordSets(zone string, a, b dns.RecordSet) bool {
+	if recordSetKey(a) != recordSetKey(b) || a.TTL != b.TTL || len(a.Rdata) != len(b.Rdata) {
+		return false
+	}
+	normalize := func(recordSet dns.RecordSet) []string {
+		rdata := make([]string, 0, len(recordSet.Rdata))
+		for _, r := range recordSet.Rdata {
+			normalized, err := zonefile.NormalizeRdata(recordSet.Type, r, zone)
+			if err != nil {
+				normalized = r
+			}
+			if !slices.Contains(caseSensitiveTypes, recordSet.Type) {
+				normalized = strings.ToLower(normalized)
+			}
+			rdata = append(rdata, normalized)
+		}
+		slices.Sort(rdata)
+		return rdata
+	}
+	return slices.Equal(normalize(a), normalize(b))
+}
+
+func getZoneRecordSets(d *schema.ResourceData) []dns.RecordSet {
+	recordSets, ok := d.Get("recordset").(*schema.Set)
+	if !ok {
+		return nil
+	}
+	return recordSetsFromSet(recordSets)
+}
+
+func recordSetsFromSet(set *schema.Set) []dns.RecordSet {
+	recordSets := make([]dns.RecordSet, 0, set.Len())
+	for _, item := range set.List() {
+		recordSetMap := item.(map[string]interface{})
+		recordSet := dns.RecordSet{
+			Name: recordSetMap["name"].(string),
+			Type: recordSetMap["type"].(string),
+			TTL:  recordSetMap["ttl"].(int),
+		}
+		for _, rdata := range recordSetMap["rdata"].([]interface{}) {
+			if r, ok := rdata.(string); ok {
+				recordSet.Rdata = append(recordSet.Rdata, r)
+			}
+		}
+		recordSets = append(recordSets, recordSet)
+	}
+	return recordSets
+}
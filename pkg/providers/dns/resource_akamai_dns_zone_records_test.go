@@ -0,0 +1,187 @@
+package dns
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/dns"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/testutils"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestResDNSZoneRecords(t *testing.T) {
+	soa := dns.RecordSet{Name: "example.com", Type: "SOA", TTL: 86400, Rdata: []string{"a1-1.akam.net. hostmaster.example.com. 1 14400 7200 604800 1200"}}
+	ns := dns.RecordSet{Name: "example.com", Type: "NS", TTL: 86400, Rdata: []string{"a1-1.akam.net."}}
+	www := dns.RecordSet{Name: "www.example.com", Type: "A", TTL: 300, Rdata: []string{"192.0.2.1", "192.0.2.2"}}
+	mx := dns.RecordSet{Name: "example.com", Type: "MX", TTL: 3600, Rdata: []string{"10 mail"}}
+	api := dns.RecordSet{Name: "api.example.com", Type: "CNAME", TTL: 300, Rdata: []string{"www"}}
+	manual := dns.RecordSet{Name: "old.example.com", Type: "A", TTL: 300, Rdata: []string{"192.0.2.9"}}
+
+	recordSetsMatch := func(expected ...dns.RecordSet) func(*dns.RecordSets) bool {
+		return func(recordSets *dns.RecordSets) bool {
+			sortRecordSets := func(rs []dns.RecordSet) []dns.RecordSet {
+				rs = slices.Clone(rs)
+				slices.SortFunc(rs, func(a, b dns.RecordSet) int { return strings.Compare(recordSetKey(a), recordSetKey(b)) })
+				return rs
+			}
+			return assert.ObjectsAreEqual(sortRecordSets(expected), sortRecordSets(recordSets.RecordSets))
+		}
+	}
+
+	t.Run("lifecycle test", func(t *testing.T) {
+		client := &dns.Mock{}
+		live := &dns.GetRecordSetsResponse{RecordSets: []dns.RecordSet{soa, ns, manual}}
+
+		client.On("GetRecordSets", testutils.MockContext, dns.GetRecordSetsRequest{
+			Zone:      "example.com",
+			QueryArgs: &dns.RecordSetQueryArgs{ShowAll: true},
+		}).Return(live, nil)
+
+		mxCreated := dns.RecordSet{Name: "example.com", Type: "MX", TTL: 3600, Rdata: []string{"10 mail.example.com."}}
+		wwwUpdated := dns.RecordSet{Name: "www.example.com", Type: "A", TTL: 600, Rdata: []string{"192.0.2.1", "192.0.2.2"}}
+		apiCreated := dns.RecordSet{Name: "api.example.com", Type: "CNAME", TTL: 300, Rdata: []string{"www.example.com."}}
+		expectDeleteRecord := func(recordSet dns.RecordSet, remaining ...dns.RecordSet) {
+			client.On("DeleteRecord", testutils.MockContext, dns.DeleteRecordRequest{
+				Zone:       "example.com",
+				Name:       recordSet.Name,
+				RecordType: recordSet.Type,
+				RecLock:    []bool{true},
+			}).Run(func(_ mock.Arguments) {
+				live.RecordSets = remaining
+			}).Return(nil).Once()
+		}
+
+		// create deletes the manually created record set and adds the configured ones
+		expectDeleteRecord(manual, soa, ns)
+		client.On("CreateRecordSets", testutils.MockContext, mock.MatchedBy(func(req dns.CreateRecordSetsRequest) bool {
+			return req.Zone == "example.com" && recordSetsMatch(www, mx)(req.RecordSets)
+		})).Run(func(_ mock.Arguments) {
+			live.RecordSets = []dns.RecordSet{soa, ns, www, mxCreated}
+		}).Return(nil).Once()
+
+		// update changes a record set and adds another one, leaving the rest as it is
+		client.On("UpdateRecord", testutils.MockContext, dns.UpdateRecordRequest{
+			Zone:    "example.com",
+			Record:  &dns.RecordBody{Name: "www.example.com", RecordType: "A", TTL: 600, Target: []string{"192.0.2.1", "192.0.2.2"}},
+			RecLock: []bool{true},
+		}).Run(func(_ mock.Arguments) {
+			live.RecordSets = []dns.RecordSet{soa, ns, wwwUpdated, mxCreated}
+		}).Return(nil).Once()
+		client.On("CreateRecordSets", testutils.MockContext, mock.MatchedBy(func(req dns.CreateRecordSetsRequest) bool {
+			return req.Zone == "example.com" && recordSetsMatch(api)(req.RecordSets)
+		})).Run(func(_ mock.Arguments) {
+			live.RecordSets = append(live.RecordSets, apiCreated)
+		}).Return(nil).Once()
+
+		// delete removes the managed record sets, keeping the SOA and NS records of the zone apex
+		expectDeleteRecord(wwwUpdated, soa, ns, mxCreated, apiCreated)
+		expectDeleteRecord(mxCreated, soa, ns, apiCreated)
+		expectDeleteRecord(apiCreated, soa, ns)
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config: testutils.LoadFixtureString(t, "testdata/TestResDnsZoneRecords/create.tf"),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_dns_zone_records.test", "id", "example.com"),
+							resource.TestCheckResourceAttr("akamai_dns_zone_records.test", "recordset.#", "2"),
+							resource.TestCheckTypeSetElemNestedAttrs("akamai_dns_zone_records.test", "recordset.*", map[string]string{
+								"name":    "example.com",
+								"type":    "MX",
+								"rdata.0": "10 mail",
+							}),
+						),
+					},
+					{
+						Config: testutils.LoadFixtureString(t, "testdata/TestResDnsZoneRecords/update.tf"),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_dns_zone_records.test", "recordset.#", "3"),
+							resource.TestCheckTypeSetElemNestedAttrs("akamai_dns_zone_records.test", "recordset.*", map[string]string{
+								"name":    "api.example.com",
+								"type":    "CNAME",
+								"rdata.0": "www",
+							}),
+						),
+					},
+					{
+						ImportState:       true,
+						ImportStateId:     "example.com",
+						ResourceName:      "akamai_dns_zone_records.test",
+						ImportStateVerify: true,
+						// imported record sets are in the form returned by Edge DNS
+						ImportStateVerifyIgnore: []string{"recordset"},
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+
+	tests := map[string]struct {
+		givenTF       string
+		expectedError *regexp.Regexp
+	}{
+		"record set outside of zone": {
+			givenTF:       "outside_zone.tf",
+			expectedError: regexp.MustCompile("record set www.example.net A is not in zone example.com"),
+		},
+		"NS records of zone apex": {
+			givenTF:       "apex_ns.tf",
+			expectedError: regexp.MustCompile("NS records of the zone apex example.com are managed by Edge DNS"),
+		},
+		"invalid record data": {
+			givenTF:       "invalid_rdata.tf",
+			expectedError: regexp.MustCompile(`record set www.example.com A: invalid IPv4 address "2001:db8::1"`),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &dns.Mock{}
+			useClient(client, func() {
+				resource.UnitTest(t, resource.TestCase{
+					ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+					Steps: []resource.TestStep{
+						{
+							Config:      testutils.LoadFixtureString(t, "testdata/TestResDnsZoneRecords/"+test.givenTF),
+							ExpectError: test.expectedError,
+						},
+					},
+				})
+			})
+			client.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDiffZoneRecordSets(t *testing.T) {
+	current := []dns.RecordSet{
+		{Name: "www.example.com", Type: "A", TTL: 300, Rdata: []string{"192.0.2.1", "192.0.2.2"}},
+		{Name: "example.com", Type: "MX", TTL: 3600, Rdata: []string{"10 mail.example.com."}},
+		{Name: "old.example.com", Type: "A", TTL: 300, Rdata: []string{"192.0.2.9"}},
+		{Name: "example.com", Type: "TXT", TTL: 300, Rdata: []string{`"v=spf1 -all"`}},
+	}
+	desired := []dns.RecordSet{
+		// same data in other order and format
+		{Name: "WWW.example.com", Type: "A", TTL: 300, Rdata: []string{"192.0.2.2", "192.0.2.1"}},
+		{Name: "example.com", Type: "MX", TTL: 3600, Rdata: []string{"10 MAIL"}},
+		{Name: "example.com", Type: "TXT", TTL: 300, Rdata: []string{`"V=SPF1 -all"`}},
+		{Name: "new.example.com", Type: "AAAA", TTL: 300, Rdata: []string{"2001:db8::1"}},
+	}
+
+	changes := diffZoneRecordSets("example.com", current, desired)
+	assert.Equal(t, zoneRecordsChanges{
+		Add:    []dns.RecordSet{desired[3]},
+		Update: []dns.RecordSet{desired[2]},
+		Delete: []dns.RecordSet{current[2]},
+	}, changes)
+
+	assert.Equal(t, zoneRecordsChanges{}, diffZoneRecordSets("example.com", current, current))
+}
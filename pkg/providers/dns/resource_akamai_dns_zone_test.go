@@ -4,11 +4,13 @@ import (
 	"net/http"
 	"os"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/dns"
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/testutils"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -384,4 +386,80 @@ func TestResDNSZone(t *testing.T) {
 
 		client.AssertExpectations(t)
 	})
+
+	t.Run("secondary zone converted to primary in place", func(t *testing.T) {
+		client := &dns.Mock{}
+		convertedZone := *secondaryZone
+		convertedZone.Comment = "This is a test secondary zone"
+
+		getCall := client.On("GetZone",
+			testutils.MockContext,
+			mock.AnythingOfType("dns.GetZoneRequest"),
+		).Return(nil, &dns.Error{
+			StatusCode: http.StatusNotFound,
+		})
+
+		client.On("CreateZone",
+			testutils.MockContext,
+			mock.AnythingOfType("dns.CreateZoneRequest"),
+		).Return(nil).Run(func(_ mock.Arguments) {
+			getCall.ReturnArguments = mock.Arguments{&convertedZone, nil}
+		})
+
+		client.On("UpdateZone",
+			testutils.MockContext,
+			mock.MatchedBy(func(req dns.UpdateZoneRequest) bool {
+				return strings.EqualFold(req.CreateZone.Type, "PRIMARY") && len(req.CreateZone.Masters) == 0 && req.CreateZone.TSIGKey == nil &&
+					req.CreateZone.OutboundZoneTransfer != nil
+			}),
+		).Return(nil).Run(func(_ mock.Arguments) {
+			convertedZone.Type = "PRIMARY"
+			convertedZone.Masters = nil
+			convertedZone.TSIGKey = nil
+		}).Once()
+
+		client.On("GetRecordSets",
+			testutils.MockContext,
+			mock.AnythingOfType("dns.GetRecordSetsRequest"),
+		).Return(recordSetsResp, nil)
+
+		resourceName := "akamai_dns_zone.secondary_test_zone"
+
+		// work around to skip Delete which fails intentionally
+		err := os.Setenv("DNS_ZONE_SKIP_DELETE", "")
+		require.NoError(t, err)
+		defer func() {
+			err = os.Unsetenv("DNS_ZONE_SKIP_DELETE")
+			require.NoError(t, err)
+		}()
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config: testutils.LoadFixtureString(t, "testdata/TestResDnsZone/create_secondary.tf"),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr(resourceName, "type", "SECONDARY"),
+							resource.TestCheckResourceAttr(resourceName, "tsig_key.#", "1"),
+						),
+					},
+					{
+						Config: testutils.LoadFixtureString(t, "testdata/TestResDnsZone/convert_to_primary.tf"),
+						ConfigPlanChecks: resource.ConfigPlanChecks{
+							PreApply: []plancheck.PlanCheck{
+								plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+							},
+						},
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr(resourceName, "type", "PRIMARY"),
+							resource.TestCheckResourceAttr(resourceName, "masters.#", "0"),
+							resource.TestCheckResourceAttr(resourceName, "tsig_key.#", "0"),
+						),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
 }
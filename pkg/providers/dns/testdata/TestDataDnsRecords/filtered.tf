@@ -0,0 +1,11 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+data "akamai_dns_records" "test" {
+  zone         = "example.com"
+  record_types = ["txt", "SRV"]
+  name_pattern = "*._TCP.example.com"
+  min_ttl      = 300
+  max_ttl      = 3600
+}
@@ -0,0 +1,8 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+data "akamai_dns_records" "test" {
+  zone         = "example.com"
+  name_pattern = "[a-"
+}
@@ -0,0 +1,9 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+data "akamai_dns_records" "test" {
+  zone    = "example.com"
+  min_ttl = 3600
+  max_ttl = 300
+}
@@ -0,0 +1,14 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+data "akamai_dns_zone_file" "test" {
+  zone        = "example.com"
+  default_ttl = 300
+  content     = <<-EOT
+    @     IN SOA ns1 hostmaster ( 2024010101 1h 15m 1w 300 )
+          IN NS  ns1
+    www   CNAME  @
+    _sip._tcp 600 SRV 10 60 5060 sip
+  EOT
+}
@@ -0,0 +1,8 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+data "akamai_dns_zone_file" "test" {
+  zone    = "example.com"
+  content = "www 300 IN DNAME other.example.com."
+}
@@ -0,0 +1,7 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+data "akamai_dns_zone_file" "test" {
+  content = "www 300 IN A 192.0.2.1"
+}
@@ -0,0 +1,14 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+data "akamai_dns_zone_file" "test" {
+  zone        = "example.com"
+  default_ttl = 300
+  content     = <<-EOT
+    _svc  SVCB 1 svc1 alpn=h2,h3 port=8443
+          SVCB 2 svc2
+    txt   TXT  "v=spf1" "-all"
+          TXT  "second"
+  EOT
+}
@@ -0,0 +1,11 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_dns_record" "cname_record" {
+  zone       = "exampleterraform.io"
+  name       = "exampleterraform.io"
+  recordtype = "CNAME"
+  ttl        = 300
+  target     = ["www.example.net"]
+}
@@ -0,0 +1,19 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_dns_record" "cname_record" {
+  zone       = "exampleterraform.io"
+  name       = "www.exampleterraform.io"
+  recordtype = "CNAME"
+  ttl        = 300
+  target     = ["www.example.net"]
+}
+
+resource "akamai_dns_record" "txt_record" {
+  zone       = "exampleterraform.io"
+  name       = "www.exampleterraform.io"
+  recordtype = "TXT"
+  ttl        = 300
+  target     = ["verification"]
+}
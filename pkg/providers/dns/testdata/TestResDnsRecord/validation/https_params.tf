@@ -0,0 +1,13 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_dns_record" "https_record" {
+  zone         = "exampleterraform.io"
+  name         = "www.exampleterraform.io"
+  recordtype   = "HTTPS"
+  ttl          = 300
+  svc_priority = 1
+  target_name  = "."
+  svc_params   = "mandatory=port alpn=h2,h3"
+}
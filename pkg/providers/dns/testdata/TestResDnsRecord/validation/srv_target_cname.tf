@@ -0,0 +1,19 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_dns_record" "srv_record" {
+  zone       = "exampleterraform.io"
+  name       = "_sip._udp.exampleterraform.io"
+  recordtype = "SRV"
+  ttl        = 300
+  target     = ["10 60 5060 sip.exampleterraform.io"]
+}
+
+resource "akamai_dns_record" "cname_record" {
+  zone       = "exampleterraform.io"
+  name       = "sip.exampleterraform.io"
+  recordtype = "CNAME"
+  ttl        = 300
+  target     = ["sip.example.net"]
+}
@@ -0,0 +1,14 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_dns_record" "tlsa_record" {
+  zone        = "exampleterraform.io"
+  name        = "_443._tcp.www.exampleterraform.io"
+  recordtype  = "TLSA"
+  ttl         = 300
+  usage       = 3
+  selector    = 1
+  match_type  = 2
+  certificate = "D2ABDE240D7CD3EE6B4B28C54DF034B97983A1D16E8A410E4561CB106618E971"
+}
@@ -0,0 +1,10 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_dns_tsig_key" "test" {
+  name      = "transfer.example.com."
+  algorithm = "hmac-sha256"
+  secret    = "c2VjcmV0LTEtc2VjcmV0LTEtc2VjcmV0LTEtc2VjcmV0LTE="
+  zones     = []
+}
@@ -0,0 +1,10 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_dns_tsig_key" "test" {
+  name      = "transfer.example.com."
+  algorithm = "hmac-sha512"
+  secret    = "c2VjcmV0LTItc2VjcmV0LTItc2VjcmV0LTItc2VjcmV0LTI="
+  zones     = ["secondary1.example.com"]
+}
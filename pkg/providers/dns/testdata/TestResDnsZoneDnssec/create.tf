@@ -0,0 +1,9 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_dns_zone_dnssec" "test" {
+  zone      = "example.com"
+  enabled   = true
+  algorithm = "ECDSA_P256_SHA256"
+}
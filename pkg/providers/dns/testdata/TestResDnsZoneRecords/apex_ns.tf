@@ -0,0 +1,14 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_dns_zone_records" "test" {
+  zone = "example.com"
+
+  recordset {
+    name  = "example.com"
+    type  = "NS"
+    ttl   = 86400
+    rdata = ["a1-1.akam.net."]
+  }
+}
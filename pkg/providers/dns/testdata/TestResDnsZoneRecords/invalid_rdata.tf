@@ -0,0 +1,14 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_dns_zone_records" "test" {
+  zone = "example.com"
+
+  recordset {
+    name  = "www.example.com"
+    type  = "A"
+    ttl   = 300
+    rdata = ["2001:db8::1"]
+  }
+}
@@ -0,0 +1,14 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_dns_zone_records" "test" {
+  zone = "example.com"
+
+  recordset {
+    name  = "www.example.net"
+    type  = "A"
+    ttl   = 300
+    rdata = ["192.0.2.1"]
+  }
+}
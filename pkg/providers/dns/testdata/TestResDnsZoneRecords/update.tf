@@ -0,0 +1,28 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_dns_zone_records" "test" {
+  zone = "example.com"
+
+  recordset {
+    name  = "www.example.com"
+    type  = "A"
+    ttl   = 600
+    rdata = ["192.0.2.1", "192.0.2.2"]
+  }
+
+  recordset {
+    name  = "example.com"
+    type  = "MX"
+    ttl   = 3600
+    rdata = ["10 mail"]
+  }
+
+  recordset {
+    name  = "api.example.com"
+    type  = "CNAME"
+    ttl   = 300
+    rdata = ["www"]
+  }
+}
@@ -0,0 +1,221 @@
+package gtm
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/gtm"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/meta"
+)
+
+// When batch updates are enabled in the provider, the changes of properties, datacenters and geographic maps are not
+// submitted one by one. Each change is added to the batch of its domain, and the operation blocks until the batch is
+// submitted as a single domain update. The batch is submitted once no change was added to it for batchWindow,
+// or when it is older than maxBatchDelay. Terraform runs up to `-parallelism` operations at a time, which limits
+// the size of a batch.
+
+const (
+	// batchWindow is the time without new changes after which the batch of a domain is submitted
+	batchWindow = 2 * time.Second
+	// maxBatchDelay is the longest time the first change of a batch waits for the batch to be submitted
+	maxBatchDelay = 30 * time.Second
+)
+
+type (
+	// domainChange applies a change of a domain object to the domain
+	domainChange func(*gtm.Domain)
+
+	// domainBatch is the set of changes of a domain submitted in a single update
+	domainBatch struct {
+		domain  string
+		meta    meta.Meta
+		changes []domainChange
+		created time.Time
+		timer   *time.Timer
+		done    chan struct{}
+		status  *gtm.ResponseStatus
+		err     error
+	}
+
+	// domainBatcher is the coordinator of the batches of changes. At most one batch of a domain is accepting changes,
+	// and the batches of the same domain are submitted one at a time.
+	domainBatcher struct {
+		window   time.Duration
+		maxDelay time.Duration
+		mu       sync.Mutex
+		batches  map[string]*domainBatch
+		submits  map[string]*sync.Mutex
+	}
+)
+
+var batcher = newDomainBatcher(batchWindow, maxBatchDelay)
+
+func newDomainBatcher(window, maxDelay time.Duration) *domainBatcher {
+	return &domainBatcher{
+		window:   window,
+		maxDelay: maxDelay,
+		batches:  make(map[string]*domainBatch),
+		submits:  make(map[string]*sync.Mutex),
+	}
+}
+
+// batchUpdates returns true if the changes of the domain objects should be submitted in batches
+func batchUpdates(meta meta.Meta) bool {
+	return meta.GTMSettings().BatchUpdates
+}
+
+// submitDomainChange adds the change to the batch of the domain and waits until the batch is submitted.
+// It returns the status of the domain update. If ctx is done before, the change may still be submitted.
+func submitDomainChange(ctx context.Context, meta meta.Meta, domain string, change domainChange) (*gtm.ResponseStatus, error) {
+	batch := batcher.add(ctx, meta, domain, change)
+	select {
+	case <-batch.done:
+		return batch.status, batch.err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("waiting for the batch update of domain %s: %w", domain, ctx.Err())
+	}
+}
+
+func (b *domainBatcher) add(ctx context.Context, meta meta.Meta, domain string, change domainChange) *domainBatch {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// domain names are unique across accounts, the account key only keeps apart the sessions used to submit the batch
+	key := meta.AccountKey() + ":" + domain
+	batch, ok := b.batches[key]
+	if !ok {
+		batch = &domainBatch{
+			domain:  domain,
+			meta:    meta,
+			created: time.Now(),
+			done:    make(chan struct{}),
+		}
+		// the batch outlives the operation adding its first change
+		submitCtx := context.WithoutCancel(ctx)
+		batch.timer = time.AfterFunc(b.window, func() {
+			b.submit(submitCtx, key, batch)
+		})
+		b.batches[key] = batch
+	} else if time.Since(batch.created) < b.maxDelay {
+		batch.timer.Reset(b.window)
+	}
+	batch.changes = append(batch.changes, change)
+	meta.Log("Akamai GTM", "submitDomainChange").Debugf("Change added to batch of domain %s, %d changes pending", domain, len(batch.changes))
+	return batch
+}
+
+func (b *domainBatcher) submit(ctx context.Context, key string, batch *domainBatch) {
+	b.mu.Lock()
+	if b.batches[key] != batch {
+		// the timer was reset after it fired, and the batch is already submitted
+		b.mu.Unlock()
+		return
+	}
+	delete(b.batches, key)
+	submitLock, ok := b.submits[key]
+	if !ok {
+		submitLock = &sync.Mutex{}
+		b.submits[key] = submitLock
+	}
+	b.mu.Unlock()
+
+	submitLock.Lock()
+	defer submitLock.Unlock()
+	defer close(batch.done)
+	batch.status, batch.err = batch.submit(ctx)
+}
+
+// submit reads the domain, applies the changes of the batch and updates the domain
+func (batch *domainBatch) submit(ctx context.Context) (*gtm.ResponseStatus, error) {
+	logger := batch.meta.Log("Akamai GTM", "submitDomainChange")
+	logger.Infof("Submitting %d changes of domain %s", len(batch.changes), batch.domain)
+
+	dom, err := Client(batch.meta).GetDomain(ctx, gtm.GetDomainRequest{
+		DomainName: batch.domain,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("batch update of domain %s: domain read error: %w", batch.domain, err)
+	}
+	domain := gtm.Domain(*dom)
+	for _, change := range batch.changes {
+		change(&domain)
+	}
+
+	resp, err := Client(batch.meta).UpdateDomain(ctx, gtm.UpdateDomainRequest{
+		Domain: &domain,
+	})
+	invalidateDomainCache(batch.meta, batch.domain)
+	if err != nil {
+		return nil, fmt.Errorf("batch update of domain %s with %d changes: %w", batch.domain, len(batch.changes), err)
+	}
+	if resp.Status == nil {
+		return nil, fmt.Errorf("batch update of domain %s: no status returned", batch.domain)
+	}
+	logger.Debugf("Batch update of domain %s status: %v", batch.domain, resp.Status)
+	return resp.Status, nil
+}
+
+// upsertProperty returns the change replacing the property of the same name, or adding it to the domain
+func upsertProperty(property gtm.Property) domainChange {
+	return func(domain *gtm.Domain) {
+		domain.Properties = upsertByKey(domain.Properties, property, func(p gtm.Property) string { return p.Name })
+	}
+}
+
+// removeProperty returns the change removing the property of given name from the domain
+func removeProperty(name string) domainChange {
+	return func(domain *gtm.Domain) {
+		domain.Properties = removeByKey(domain.Properties, name, func(p gtm.Property) string { return p.Name })
+	}
+}
+
+// upsertGeoMap returns the change replacing the geographic map of the same name, or adding it to the domain
+func upsertGeoMap(geoMap gtm.GeoMap) domainChange {
+	return func(domain *gtm.Domain) {
+		domain.GeographicMaps = upsertByKey(domain.GeographicMaps, geoMap, func(m gtm.GeoMap) string { return m.Name })
+	}
+}
+
+// removeGeoMap returns the change removing the geographic map of given name from the domain
+func removeGeoMap(name string) domainChange {
+	return func(domain *gtm.Domain) {
+		domain.GeographicMaps = removeByKey(domain.GeographicMaps, name, func(m gtm.GeoMap) string { return m.Name })
+	}
+}
+
+// upsertDatacenter returns the change replacing the datacenter of the same ID in the domain.
+// Datacenters are created one by one, as their IDs are assigned by the API.
+func upsertDatacenter(datacenter gtm.Datacenter) domainChange {
+	return func(domain *gtm.Domain) {
+		domain.Datacenters = upsertByKey(domain.Datacenters, datacenter, func(dc gtm.Datacenter) int { return dc.DatacenterID })
+	}
+}
+
+// removeDatacenter returns the change removing the datacenter of given ID from the domain
+func removeDatacenter(id int) domainChange {
+	return func(domain *gtm.Domain) {
+		domain.Datacenters = removeByKey(domain.Datacenters, id, func(dc gtm.Datacenter) int { return dc.DatacenterID })
+	}
+}
+
+func upsertByKey[T any, K comparable](items []T, item T, key func(T) K) []T {
+	for i := range items {
+		if key(items[i]) == key(item) {
+			items[i] = item
+			return items
+		}
+	}
+	return append(items, item)
+}
+
+func removeByKey[T any, K comparable](items []T, k K, key func(T) K) []T {
+	result := make([]T, 0, len(items))
+	for _, item := range items {
+		if key(item) != k {
+			result = append(result, item)
+		}
+	}
+	return result
+}
@@ -0,0 +1,162 @@
+package gtm
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/gtm"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/testutils"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/meta"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubmitDomainChange(t *testing.T) {
+	existingDomain := gtm.GetDomainResponse{
+		Name: testDomainName,
+		Type: "weighted",
+		Properties: []gtm.Property{
+			{Name: "prop1", Type: "weighted-round-robin", ScoreAggregationType: "median"},
+			{Name: "prop2", Type: "weighted-round-robin"},
+		},
+		Datacenters: []gtm.Datacenter{
+			{DatacenterID: 3131, Nickname: "dc1"},
+			{DatacenterID: 3132, Nickname: "dc2"},
+		},
+		GeographicMaps: []gtm.GeoMap{
+			{Name: "geo1"},
+		},
+	}
+
+	tests := map[string]struct {
+		changes        []domainChange
+		init           func(*gtm.Mock)
+		expectedDomain *gtm.Domain
+		expectedStatus *gtm.ResponseStatus
+		withError      string
+	}{
+		"changes of domain objects submitted in a single update": {
+			changes: []domainChange{
+				upsertProperty(gtm.Property{Name: "prop1", Type: "failover"}),
+				upsertProperty(gtm.Property{Name: "prop3", Type: "failover"}),
+				removeProperty("prop2"),
+				upsertDatacenter(gtm.Datacenter{DatacenterID: 3131, Nickname: "dc1", City: "Krakow"}),
+				removeDatacenter(3132),
+				upsertGeoMap(gtm.GeoMap{Name: "geo2"}),
+				removeGeoMap("geo1"),
+			},
+			expectedDomain: &gtm.Domain{
+				Name: testDomainName,
+				Type: "weighted",
+				Properties: []gtm.Property{
+					{Name: "prop1", Type: "failover"},
+					{Name: "prop3", Type: "failover"},
+				},
+				Datacenters: []gtm.Datacenter{
+					{DatacenterID: 3131, Nickname: "dc1", City: "Krakow"},
+				},
+				GeographicMaps: []gtm.GeoMap{
+					{Name: "geo2"},
+				},
+			},
+			expectedStatus: &gtm.ResponseStatus{PropagationStatus: "PENDING"},
+		},
+		"update error returned for every change of the batch": {
+			changes: []domainChange{
+				upsertProperty(gtm.Property{Name: "prop3", Type: "failover"}),
+				removeProperty("prop1"),
+			},
+			init: func(client *gtm.Mock) {
+				client.On("UpdateDomain", testutils.MockContext, mock.Anything).Return(nil, errors.New("oops")).Once()
+			},
+			withError: "batch update of domain gtm_terra_testdomain.akadns.net with 2 changes: oops",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &gtm.Mock{}
+			domain := existingDomain
+			domain.Properties = append([]gtm.Property{}, existingDomain.Properties...)
+			domain.Datacenters = append([]gtm.Datacenter{}, existingDomain.Datacenters...)
+			domain.GeographicMaps = append([]gtm.GeoMap{}, existingDomain.GeographicMaps...)
+			client.On("GetDomain", testutils.MockContext, gtm.GetDomainRequest{DomainName: testDomainName}).Return(&domain, nil).Once()
+			if test.init != nil {
+				test.init(client)
+			} else {
+				client.On("UpdateDomain", testutils.MockContext, mock.Anything).Return(&gtm.UpdateDomainResponse{
+					Status: &gtm.ResponseStatus{PropagationStatus: "PENDING"},
+				}, nil).Once()
+			}
+
+			providerMeta, err := meta.New(session.Must(session.New()), hclog.NewNullLogger(), "opID",
+				meta.WithGTMSettings(meta.GTMSettings{BatchUpdates: true}))
+			require.NoError(t, err)
+
+			origBatcher := batcher
+			batcher = newDomainBatcher(50*time.Millisecond, time.Second)
+			defer func() { batcher = origBatcher }()
+
+			useClient(client, func() {
+				var wg sync.WaitGroup
+				for _, change := range test.changes {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						status, err := submitDomainChange(context.Background(), providerMeta, testDomainName, change)
+						if test.withError != "" {
+							assert.EqualError(t, err, test.withError)
+							return
+						}
+						assert.NoError(t, err)
+						assert.Equal(t, test.expectedStatus, status)
+					}()
+					// changes are added while the batch is open, some of them after the previous ones are applied
+					time.Sleep(5 * time.Millisecond)
+				}
+				wg.Wait()
+			})
+
+			client.AssertExpectations(t)
+			if test.expectedDomain != nil {
+				req := client.Calls[1].Arguments[1].(gtm.UpdateDomainRequest)
+				// the order of the changes within the batch is not deterministic
+				assert.ElementsMatch(t, test.expectedDomain.Properties, req.Domain.Properties)
+				assert.ElementsMatch(t, test.expectedDomain.Datacenters, req.Domain.Datacenters)
+				assert.ElementsMatch(t, test.expectedDomain.GeographicMaps, req.Domain.GeographicMaps)
+				assert.Equal(t, test.expectedDomain.Name, req.Domain.Name)
+			}
+		})
+	}
+}
+
+func TestSubmitDomainChangeSeparateBatches(t *testing.T) {
+	client := &gtm.Mock{}
+	client.On("GetDomain", testutils.MockContext, gtm.GetDomainRequest{DomainName: testDomainName}).
+		Return(&gtm.GetDomainResponse{Name: testDomainName}, nil).Twice()
+	client.On("UpdateDomain", testutils.MockContext, mock.Anything).Return(&gtm.UpdateDomainResponse{
+		Status: &gtm.ResponseStatus{PropagationStatus: "PENDING"},
+	}, nil).Twice()
+
+	providerMeta, err := meta.New(session.Must(session.New()), hclog.NewNullLogger(), "opID",
+		meta.WithGTMSettings(meta.GTMSettings{BatchUpdates: true}))
+	require.NoError(t, err)
+
+	origBatcher := batcher
+	batcher = newDomainBatcher(10*time.Millisecond, time.Second)
+	defer func() { batcher = origBatcher }()
+
+	useClient(client, func() {
+		for _, name := range []string{"prop1", "prop2"} {
+			_, err := submitDomainChange(context.Background(), providerMeta, testDomainName, upsertProperty(gtm.Property{Name: name}))
+			require.NoError(t, err)
+		}
+	})
+	client.AssertExpectations(t)
+}
@@ -0,0 +1,70 @@
+package gtm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/gtm"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/cache"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/meta"
+)
+
+// List data sources are read through the cache, so that configurations reading the same domain
+// multiple times send a single request per domain. Every write to a domain has to call invalidateDomainCache.
+
+var cacheBucket = cache.BucketName("GTM")
+
+// listDomainsCached returns the domains available to the account of meta, reading them from cache if possible
+func listDomainsCached(ctx context.Context, meta meta.Meta) ([]gtm.DomainItem, error) {
+	return cache.GetOrFetch(accountCacheBucket(meta), "ListDomains", func() ([]gtm.DomainItem, error) {
+		return Client(meta).ListDomains(ctx)
+	})
+}
+
+// listDatacentersCached returns the datacenters of the given domain, reading them from cache if possible
+func listDatacentersCached(ctx context.Context, client gtm.GTM, domain string) ([]gtm.Datacenter, error) {
+	return cache.GetOrFetch(cacheBucket, listDatacentersCacheKey(domain), func() ([]gtm.Datacenter, error) {
+		return client.ListDatacenters(ctx, gtm.ListDatacentersRequest{DomainName: domain})
+	})
+}
+
+// listResourcesCached returns the resources of the given domain, reading them from cache if possible
+func listResourcesCached(ctx context.Context, client gtm.GTM, domain string) ([]gtm.Resource, error) {
+	return cache.GetOrFetch(cacheBucket, listResourcesCacheKey(domain), func() ([]gtm.Resource, error) {
+		return client.ListResources(ctx, gtm.ListResourcesRequest{DomainName: domain})
+	})
+}
+
+// listGeoMapsCached returns the geographic maps of the given domain, reading them from cache if possible
+func listGeoMapsCached(ctx context.Context, client gtm.GTM, domain string) ([]gtm.GeoMap, error) {
+	return cache.GetOrFetch(cacheBucket, listGeoMapsCacheKey(domain), func() ([]gtm.GeoMap, error) {
+		return client.ListGeoMaps(ctx, gtm.ListGeoMapsRequest{DomainName: domain})
+	})
+}
+
+// invalidateDomainCache removes the cached lists of the given domain. The list of domains of the account of meta
+// is removed as well, as it holds the last modification details of every domain.
+func invalidateDomainCache(meta meta.Meta, domain string) {
+	cache.Delete(accountCacheBucket(meta), "ListDomains")
+	cache.Delete(cacheBucket, listDatacentersCacheKey(domain))
+	cache.Delete(cacheBucket, listResourcesCacheKey(domain))
+	cache.Delete(cacheBucket, listGeoMapsCacheKey(domain))
+}
+
+// accountCacheBucket returns the bucket of lists specific to the account of meta.
+// Domain names are unique across accounts, so the lists of domain objects are kept in cacheBucket.
+func accountCacheBucket(meta meta.Meta) cache.Bucket {
+	return cache.AccountBucket("GTM", meta.AccountKey())
+}
+
+func listDatacentersCacheKey(domain string) string {
+	return fmt.Sprintf("ListDatacenters:%s", domain)
+}
+
+func listResourcesCacheKey(domain string) string {
+	return fmt.Sprintf("ListResources:%s", domain)
+}
+
+func listGeoMapsCacheKey(domain string) string {
+	return fmt.Sprintf("ListGeoMaps:%s", domain)
+}
@@ -3,7 +3,6 @@ package gtm
 import (
 	"context"
 
-	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/gtm"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/session"
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/tf"
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/meta"
@@ -162,7 +161,7 @@ func dataGTMDatacentersRead(ctx context.Context, d *schema.ResourceData, m inter
 		return diag.FromErr(err)
 	}
 
-	datacenters, err := client.ListDatacenters(ctx, gtm.ListDatacentersRequest{DomainName: domain})
+	datacenters, err := listDatacentersCached(ctx, client, domain)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -155,8 +155,7 @@ func (d *domainsDataSource) Read(ctx context.Context, request datasource.ReadReq
 		return
 	}
 
-	client := Client(d.meta)
-	domains, err := client.ListDomains(ctx)
+	domains, err := listDomainsCached(ctx, d.meta)
 	if err != nil {
 		response.Diagnostics.AddError("fetching domains failed", err.Error())
 		return
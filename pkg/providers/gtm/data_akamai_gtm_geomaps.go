@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/gtm"
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/meta"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -136,8 +135,7 @@ func (d *geoMapsDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	}
 
 	client := Client(d.meta)
-	geoMaps, err := client.ListGeoMaps(ctx, gtm.ListGeoMapsRequest{
-		DomainName: data.Domain.ValueString()})
+	geoMaps, err := listGeoMapsCached(ctx, client, data.Domain.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("fetching GTM Geographic maps failed: ", err.Error())
 		return
@@ -0,0 +1,248 @@
+package gtm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// defaultLivenessTestTimeout is the timeout of the liveness test check, unless set in test_timeout
+const defaultLivenessTestTimeout = 10 * time.Second
+
+type livenessTestCheckDataSource struct{}
+
+type livenessTestCheckDataSourceModel struct {
+	ID                            types.String                  `tfsdk:"id"`
+	Target                        types.String                  `tfsdk:"target"`
+	TestObjectProtocol            types.String                  `tfsdk:"test_object_protocol"`
+	TestObjectPort                types.Int64                   `tfsdk:"test_object_port"`
+	TestObject                    types.String                  `tfsdk:"test_object"`
+	HTTPMethod                    types.String                  `tfsdk:"http_method"`
+	HTTPRequestBody               types.String                  `tfsdk:"http_request_body"`
+	HTTPHeaders                   []livenessTestCheckHTTPHeader `tfsdk:"http_header"`
+	HTTPError3xx                  types.Bool                    `tfsdk:"http_error3xx"`
+	HTTPError4xx                  types.Bool                    `tfsdk:"http_error4xx"`
+	HTTPError5xx                  types.Bool                    `tfsdk:"http_error5xx"`
+	RequestString                 types.String                  `tfsdk:"request_string"`
+	ResponseString                types.String                  `tfsdk:"response_string"`
+	PeerCertificateVerification   types.Bool                    `tfsdk:"peer_certificate_verification"`
+	DisableNonstandardPortWarning types.Bool                    `tfsdk:"disable_nonstandard_port_warning"`
+	TestTimeout                   types.Float64                 `tfsdk:"test_timeout"`
+	Success                       types.Bool                    `tfsdk:"success"`
+	StatusCode                    types.Int64                   `tfsdk:"status_code"`
+	Duration                      types.Float64                 `tfsdk:"duration"`
+	Error                         types.String                  `tfsdk:"error"`
+}
+
+type livenessTestCheckHTTPHeader struct {
+	Name  types.String `tfsdk:"name"`
+	Value types.String `tfsdk:"value"`
+}
+
+var (
+	_ datasource.DataSource = &livenessTestCheckDataSource{}
+)
+
+// NewGTMLivenessTestCheckDataSource returns a new GTM liveness test check data source
+func NewGTMLivenessTestCheckDataSource() datasource.DataSource {
+	return &livenessTestCheckDataSource{}
+}
+
+func (d *livenessTestCheckDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = "akamai_gtm_liveness_test_check"
+}
+
+func (d *livenessTestCheckDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Runs a GTM liveness test from the Terraform host against a target, e.g. a local stand-in server, " +
+			"to check the test before it is configured on a property. The API is not called.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Identifier of the data source.",
+			},
+			"target": schema.StringAttribute{
+				Required:    true,
+				Description: "The host name or IP address of the server the test is run against.",
+			},
+			"test_object_protocol": schema.StringAttribute{
+				Required:    true,
+				Description: "The protocol of the test, one of 'HTTP', 'HTTPS' or 'TCP'.",
+				Validators: []validator.String{
+					stringvalidator.OneOfCaseInsensitive("HTTP", "HTTPS", "TCP"),
+				},
+			},
+			"test_object_port": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The port of the test. Defaults to 80 for HTTP and 443 for HTTPS, required for TCP.",
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+				},
+			},
+			"test_object": schema.StringAttribute{
+				Optional:    true,
+				Description: "The path of the object requested by HTTP and HTTPS tests, '/' by default.",
+			},
+			"http_method": schema.StringAttribute{
+				Optional:    true,
+				Description: "The HTTP method of HTTP and HTTPS tests, 'GET' by default.",
+			},
+			"http_request_body": schema.StringAttribute{
+				Optional:    true,
+				Description: "The body of the request of HTTP and HTTPS tests, sent with the POST and PUT methods.",
+			},
+			"http_header": schema.ListNestedAttribute{
+				Optional:    true,
+				Description: "The headers of the request of HTTP and HTTPS tests.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:    true,
+							Description: "Name of the HTTP header.",
+						},
+						"value": schema.StringAttribute{
+							Optional:    true,
+							Description: "Value of the HTTP header.",
+						},
+					},
+				},
+			},
+			"http_error3xx": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether a 3xx response status fails the test, true by default.",
+			},
+			"http_error4xx": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether a 4xx response status fails the test, true by default.",
+			},
+			"http_error5xx": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether a 5xx response status fails the test, true by default.",
+			},
+			"request_string": schema.StringAttribute{
+				Optional:    true,
+				Description: "The string sent after connecting by TCP tests.",
+			},
+			"response_string": schema.StringAttribute{
+				Optional:    true,
+				Description: "The string which has to be found in the response for the test to succeed.",
+			},
+			"peer_certificate_verification": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether the certificate of the target is verified by HTTPS tests, true by default.",
+			},
+			"disable_nonstandard_port_warning": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether a port which is standard for another protocol is allowed.",
+			},
+			"test_timeout": schema.Float64Attribute{
+				Optional:    true,
+				Description: "The time in seconds after which the test fails, 10 by default.",
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0.001),
+				},
+			},
+			"success": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the test succeeded.",
+			},
+			"status_code": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The response status of HTTP and HTTPS tests.",
+			},
+			"duration": schema.Float64Attribute{
+				Computed:    true,
+				Description: "The time in seconds the test took.",
+			},
+			"error": schema.StringAttribute{
+				Computed:    true,
+				Description: "The reason the test failed, empty if it succeeded.",
+			},
+		},
+	}
+}
+
+func (d *livenessTestCheckDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "GTM Liveness Test Check DataSource Read")
+
+	var data livenessTestCheckDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	test := data.livenessTestConfig()
+	if test.Port == 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("test_object_port"), "missing test_object_port",
+			fmt.Sprintf("'test_object_port' is required for %s tests", test.Protocol))
+		return
+	}
+	for _, err := range test.validate() {
+		resp.Diagnostics.AddError("invalid liveness test", err.Error())
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	target := data.Target.ValueString()
+	result, err := test.run(ctx, target)
+	if err != nil {
+		resp.Diagnostics.AddError("running liveness test failed", err.Error())
+		return
+	}
+	tflog.Debug(ctx, "GTM liveness test check result", map[string]any{"success": result.Success, "error": result.Error})
+
+	data.ID = types.StringValue(fmt.Sprintf("%s:%s:%d", test.Protocol, target, test.Port))
+	data.Success = types.BoolValue(result.Success)
+	data.StatusCode = types.Int64Value(int64(result.StatusCode))
+	data.Duration = types.Float64Value(result.Duration.Seconds())
+	data.Error = types.StringValue(result.Error)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// livenessTestConfig returns the liveness test of the data source, with the defaults the GTM servers use
+func (m livenessTestCheckDataSourceModel) livenessTestConfig() livenessTestConfig {
+	test := livenessTestConfig{
+		Protocol:                      strings.ToUpper(m.TestObjectProtocol.ValueString()),
+		Port:                          int(m.TestObjectPort.ValueInt64()),
+		TestObject:                    m.TestObject.ValueString(),
+		HTTPMethod:                    m.HTTPMethod.ValueString(),
+		HTTPRequestBody:               m.HTTPRequestBody.ValueString(),
+		HTTPError3xx:                  boolValueOrDefault(m.HTTPError3xx, true),
+		HTTPError4xx:                  boolValueOrDefault(m.HTTPError4xx, true),
+		HTTPError5xx:                  boolValueOrDefault(m.HTTPError5xx, true),
+		RequestString:                 m.RequestString.ValueString(),
+		ResponseString:                m.ResponseString.ValueString(),
+		PeerCertificateVerification:   boolValueOrDefault(m.PeerCertificateVerification, true),
+		DisableNonstandardPortWarning: m.DisableNonstandardPortWarning.ValueBool(),
+		Timeout:                       defaultLivenessTestTimeout,
+	}
+	if test.Port == 0 {
+		test.Port = standardPorts[test.Protocol]
+	}
+	if !m.TestTimeout.IsNull() {
+		test.Timeout = time.Duration(m.TestTimeout.ValueFloat64() * float64(time.Second))
+	}
+	for _, h := range m.HTTPHeaders {
+		test.HTTPHeaders = append(test.HTTPHeaders, livenessTestHeader{Name: h.Name.ValueString(), Value: h.Value.ValueString()})
+	}
+	return test
+}
+
+func boolValueOrDefault(value types.Bool, defaultValue bool) bool {
+	if value.IsNull() || value.IsUnknown() {
+		return defaultValue
+	}
+	return value.ValueBool()
+}
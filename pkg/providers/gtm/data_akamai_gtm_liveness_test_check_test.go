@@ -0,0 +1,96 @@
+package gtm
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/testutils"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataGTMLivenessTestCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/health" || r.Header.Get("X-Test") != "abc" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = fmt.Fprint(w, "status: OK")
+	}))
+	defer server.Close()
+	host, portString, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+	port, err := strconv.Atoi(portString)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		givenTF            string
+		withServer         bool
+		expectedAttributes map[string]string
+		expectError        *regexp.Regexp
+	}{
+		"successful HTTP test": {
+			givenTF:    "http.tf",
+			withServer: true,
+			expectedAttributes: map[string]string{
+				"id":          fmt.Sprintf("HTTP:%s:%d", host, port),
+				"success":     "true",
+				"status_code": "200",
+				"error":       "",
+			},
+		},
+		"failed HTTP test": {
+			givenTF:    "http_error.tf",
+			withServer: true,
+			expectedAttributes: map[string]string{
+				"success":     "false",
+				"status_code": "404",
+				"error":       "response status 404 Not Found",
+			},
+		},
+		"missing port of TCP test": {
+			givenTF:     "tcp_without_port.tf",
+			expectError: regexp.MustCompile(`'test_object_port' is required for TCP tests`),
+		},
+		"invalid liveness test": {
+			givenTF:     "invalid_liveness_test.tf",
+			expectError: regexp.MustCompile(`'http_request_body' is not sent with 'http_method' GET, use POST or PUT`),
+		},
+		"unsupported protocol": {
+			givenTF:     "unsupported_protocol.tf",
+			expectError: regexp.MustCompile(`Attribute test_object_protocol value must be one of`),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var checkFuncs []resource.TestCheckFunc
+			for k, v := range test.expectedAttributes {
+				checkFuncs = append(checkFuncs, resource.TestCheckResourceAttr("data.akamai_gtm_liveness_test_check.test", k, v))
+			}
+			if test.expectError == nil {
+				checkFuncs = append(checkFuncs, resource.TestCheckResourceAttrSet("data.akamai_gtm_liveness_test_check.test", "duration"))
+			}
+
+			config := testutils.LoadFixtureStringf(t, "testdata/TestDataGTMLivenessTestCheck/%s", test.givenTF)
+			if test.withServer {
+				config = fmt.Sprintf(config, host, port)
+			}
+
+			resource.Test(t, resource.TestCase{
+				IsUnitTest:               true,
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{{
+					Config:      config,
+					Check:       resource.ComposeAggregateTestCheckFunc(checkFuncs...),
+					ExpectError: test.expectError,
+				}},
+			})
+		})
+	}
+}
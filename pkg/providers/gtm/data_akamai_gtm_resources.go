@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/gtm"
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/meta"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -142,9 +141,7 @@ func (d *resourcesDataSource) Read(ctx context.Context, request datasource.ReadR
 	}
 
 	client := Client(d.meta)
-	resources, err := client.ListResources(ctx, gtm.ListResourcesRequest{
-		DomainName: data.Domain.ValueString(),
-	})
+	resources, err := listResourcesCached(ctx, client, data.Domain.ValueString())
 	if err != nil {
 		response.Diagnostics.AddError("fetching GTM resources failed: ", err.Error())
 		return
@@ -0,0 +1,294 @@
+package gtm
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// livenessTestConfig holds the attributes of a liveness test which are checked before the test is submitted, and used to run it
+	livenessTestConfig struct {
+		Protocol                      string
+		Port                          int
+		TestObject                    string
+		HTTPMethod                    string
+		HTTPRequestBody               string
+		HTTPHeaders                   []livenessTestHeader
+		HTTPError3xx                  bool
+		HTTPError4xx                  bool
+		HTTPError5xx                  bool
+		RequestString                 string
+		ResponseString                string
+		ResourceType                  string
+		PeerCertificateVerification   bool
+		DisableNonstandardPortWarning bool
+		Timeout                       time.Duration
+	}
+
+	livenessTestHeader struct {
+		Name  string
+		Value string
+	}
+
+	// livenessTestResult is the outcome of a liveness test run from the Terraform host
+	livenessTestResult struct {
+		Success    bool
+		StatusCode int
+		Duration   time.Duration
+		Error      string
+	}
+)
+
+// maxResponseStringSearch is the number of bytes of the response searched for the response string
+const maxResponseStringSearch = 64 * 1024
+
+var (
+	// standardPorts are the ports the liveness tests use by default for each protocol
+	standardPorts = map[string]int{
+		"DNS":   53,
+		"FTP":   21,
+		"HTTP":  80,
+		"HTTPS": 443,
+		"POP":   110,
+		"POPS":  995,
+		"SIP":   5060,
+		"SIPS":  5061,
+		"SMTP":  25,
+		"SMTPS": 465,
+		"SNMP":  161,
+		"SSH":   22,
+	}
+
+	httpProtocols           = []string{"HTTP", "HTTPS"}
+	requestStringProtocols  = []string{"TCP", "TCPS"}
+	responseStringProtocols = []string{"HTTP", "HTTPS", "TCP", "TCPS"}
+	httpBodyMethods         = []string{"POST", "PUT"}
+)
+
+// livenessTestFromMap returns the liveness test from an element of the liveness_test list of akamai_gtm_property
+func livenessTestFromMap(item map[string]interface{}) livenessTestConfig {
+	test := livenessTestConfig{}
+	test.Protocol, _ = item["test_object_protocol"].(string)
+	test.Port, _ = item["test_object_port"].(int)
+	test.TestObject, _ = item["test_object"].(string)
+	test.HTTPMethod, _ = item["http_method"].(string)
+	test.HTTPRequestBody, _ = item["http_request_body"].(string)
+	test.RequestString, _ = item["request_string"].(string)
+	test.ResponseString, _ = item["response_string"].(string)
+	test.ResourceType, _ = item["resource_type"].(string)
+	test.DisableNonstandardPortWarning, _ = item["disable_nonstandard_port_warning"].(bool)
+	headers, _ := item["http_header"].([]interface{})
+	for _, h := range headers {
+		header, ok := h.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := header["name"].(string)
+		value, _ := header["value"].(string)
+		test.HTTPHeaders = append(test.HTTPHeaders, livenessTestHeader{Name: name, Value: value})
+	}
+	return test
+}
+
+// validate returns the problems of the attributes of the liveness test which are only reported after its propagation.
+// Zero values are treated as not set, as values unknown during plan are zero.
+func (t livenessTestConfig) validate() []error {
+	var errs []error
+	protocol := strings.ToUpper(t.Protocol)
+	isHTTP := slices.Contains(httpProtocols, protocol)
+
+	if t.Port < 0 || t.Port > 65535 {
+		errs = append(errs, fmt.Errorf("'test_object_port' %d is not a valid port number", t.Port))
+	} else if standard, ok := standardPorts[protocol]; ok && t.Port != 0 && t.Port != standard && !t.DisableNonstandardPortWarning {
+		for other, port := range standardPorts {
+			if port == t.Port {
+				errs = append(errs, fmt.Errorf("'test_object_port' %d is the port of %s, not of %s (%d); "+
+					"set 'disable_nonstandard_port_warning' if the port is intended", t.Port, other, protocol, standard))
+				break
+			}
+		}
+	}
+
+	if !isHTTP {
+		if t.HTTPMethod != "" {
+			errs = append(errs, fmt.Errorf("'http_method' is only used by HTTP and HTTPS tests, not by %s", protocol))
+		}
+		if t.HTTPRequestBody != "" {
+			errs = append(errs, fmt.Errorf("'http_request_body' is only used by HTTP and HTTPS tests, not by %s", protocol))
+		}
+	} else if t.HTTPRequestBody != "" && !slices.Contains(httpBodyMethods, strings.ToUpper(t.httpMethod())) {
+		errs = append(errs, fmt.Errorf("'http_request_body' is not sent with 'http_method' %s, use POST or PUT", strings.ToUpper(t.httpMethod())))
+	}
+
+	if t.RequestString != "" && !slices.Contains(requestStringProtocols, protocol) {
+		errs = append(errs, fmt.Errorf("'request_string' is only used by TCP and TCPS tests, not by %s", protocol))
+	}
+	if t.ResponseString != "" && !slices.Contains(responseStringProtocols, protocol) {
+		errs = append(errs, fmt.Errorf("'response_string' is only used by HTTP, HTTPS, TCP and TCPS tests, not by %s", protocol))
+	}
+	if t.ResourceType != "" && protocol != "DNS" {
+		errs = append(errs, fmt.Errorf("'resource_type' is only used by DNS tests, not by %s", protocol))
+	}
+
+	return errs
+}
+
+// validateLivenessTests validates the liveness_test list of akamai_gtm_property
+func validateLivenessTests(livenessTests []interface{}) error {
+	var errs []error
+	for i, itemRaw := range livenessTests {
+		item, ok := itemRaw.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("could not cast the value of type %T to map[string]interface{}", itemRaw)
+		}
+		name, _ := item["name"].(string)
+		for _, err := range livenessTestFromMap(item).validate() {
+			errs = append(errs, fmt.Errorf("liveness_test %d (%s): %w", i, name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (t livenessTestConfig) httpMethod() string {
+	if t.HTTPMethod == "" {
+		return http.MethodGet
+	}
+	return t.HTTPMethod
+}
+
+// run runs the liveness test against the target, the way the GTM servers would. Only HTTP, HTTPS and TCP tests are supported.
+// A failed test is reported in the result, the error is returned only if the test cannot be run.
+func (t livenessTestConfig) run(ctx context.Context, target string) (*livenessTestResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	var result *livenessTestResult
+	switch strings.ToUpper(t.Protocol) {
+	case "HTTP", "HTTPS":
+		result = t.runHTTP(ctx, target)
+	case "TCP":
+		result = t.runTCP(ctx, target)
+	default:
+		return nil, fmt.Errorf("liveness tests with protocol %s cannot be run", t.Protocol)
+	}
+	result.Duration = time.Since(start)
+	result.Success = result.Error == ""
+	return result, nil
+}
+
+func (t livenessTestConfig) runHTTP(ctx context.Context, target string) *livenessTestResult {
+	scheme := strings.ToLower(t.Protocol)
+	path := t.TestObject
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	url := fmt.Sprintf("%s://%s%s", scheme, net.JoinHostPort(target, strconv.Itoa(t.Port)), path)
+
+	var body io.Reader
+	if t.HTTPRequestBody != "" {
+		body = strings.NewReader(t.HTTPRequestBody)
+	}
+	req, err := http.NewRequestWithContext(ctx, strings.ToUpper(t.httpMethod()), url, body)
+	if err != nil {
+		return &livenessTestResult{Error: err.Error()}
+	}
+	for _, h := range t.HTTPHeaders {
+		if strings.EqualFold(h.Name, "Host") {
+			req.Host = h.Value
+			continue
+		}
+		req.Header.Add(h.Name, h.Value)
+	}
+
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: !t.PeerCertificateVerification, // #nosec G402 -- the test is run as configured
+			},
+		},
+		// the liveness tests do not follow redirects
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return &livenessTestResult{Error: err.Error()}
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	result := &livenessTestResult{StatusCode: resp.StatusCode}
+	switch {
+	case resp.StatusCode >= 300 && resp.StatusCode < 400 && t.HTTPError3xx,
+		resp.StatusCode >= 400 && resp.StatusCode < 500 && t.HTTPError4xx,
+		resp.StatusCode >= 500 && t.HTTPError5xx:
+		result.Error = fmt.Sprintf("response status %s", resp.Status)
+		return result
+	}
+
+	if t.ResponseString != "" {
+		content, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseStringSearch))
+		if err != nil {
+			result.Error = fmt.Sprintf("reading response: %s", err)
+		} else if !bytes.Contains(content, []byte(t.ResponseString)) {
+			result.Error = fmt.Sprintf("response string %q not found in the response", t.ResponseString)
+		}
+	}
+	return result
+}
+
+func (t livenessTestConfig) runTCP(ctx context.Context, target string) *livenessTestResult {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(target, strconv.Itoa(t.Port)))
+	if err != nil {
+		return &livenessTestResult{Error: err.Error()}
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return &livenessTestResult{Error: err.Error()}
+		}
+	}
+
+	if t.RequestString != "" {
+		if _, err := conn.Write([]byte(t.RequestString)); err != nil {
+			return &livenessTestResult{Error: fmt.Sprintf("sending request string: %s", err)}
+		}
+	}
+	if t.ResponseString == "" {
+		return &livenessTestResult{}
+	}
+
+	// read until the response string is found, the connection is closed or the timeout passes
+	var content []byte
+	buf := make([]byte, 4096)
+	for len(content) < maxResponseStringSearch {
+		n, err := conn.Read(buf)
+		content = append(content, buf[:n]...)
+		if bytes.Contains(content, []byte(t.ResponseString)) {
+			return &livenessTestResult{}
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return &livenessTestResult{Error: fmt.Sprintf("reading response: %s", err)}
+		}
+	}
+	return &livenessTestResult{Error: fmt.Sprintf("response string %q not found in the response", t.ResponseString)}
+}
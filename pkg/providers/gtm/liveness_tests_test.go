@@ -0,0 +1,217 @@
+package gtm
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLivenessTestValidate(t *testing.T) {
+	tests := map[string]struct {
+		test     livenessTestConfig
+		expected []string
+	}{
+		"valid HTTP test": {
+			test: livenessTestConfig{Protocol: "HTTP", Port: 80, TestObject: "/", ResponseString: "OK"},
+		},
+		"valid HTTPS test with nonstandard port": {
+			test: livenessTestConfig{Protocol: "HTTPS", Port: 8443, HTTPMethod: "POST", HTTPRequestBody: "ping"},
+		},
+		"port of HTTP used by HTTPS test": {
+			test:     livenessTestConfig{Protocol: "HTTPS", Port: 80},
+			expected: []string{"'test_object_port' 80 is the port of HTTP, not of HTTPS (443); set 'disable_nonstandard_port_warning' if the port is intended"},
+		},
+		"port of HTTP used by HTTPS test with nonstandard port warning disabled": {
+			test: livenessTestConfig{Protocol: "HTTPS", Port: 80, DisableNonstandardPortWarning: true},
+		},
+		"invalid port": {
+			test:     livenessTestConfig{Protocol: "TCP", Port: 70000},
+			expected: []string{"'test_object_port' 70000 is not a valid port number"},
+		},
+		"request body sent with GET": {
+			test:     livenessTestConfig{Protocol: "HTTP", Port: 80, HTTPRequestBody: "body"},
+			expected: []string{"'http_request_body' is not sent with 'http_method' GET, use POST or PUT"},
+		},
+		"HTTP attributes of FTP test": {
+			test: livenessTestConfig{Protocol: "FTP", Port: 21, HTTPMethod: "GET", HTTPRequestBody: "body"},
+			expected: []string{
+				"'http_method' is only used by HTTP and HTTPS tests, not by FTP",
+				"'http_request_body' is only used by HTTP and HTTPS tests, not by FTP",
+			},
+		},
+		"request and response strings ignored by protocol": {
+			test: livenessTestConfig{Protocol: "SMTP", Port: 25, RequestString: "HELO", ResponseString: "250"},
+			expected: []string{
+				"'request_string' is only used by TCP and TCPS tests, not by SMTP",
+				"'response_string' is only used by HTTP, HTTPS, TCP and TCPS tests, not by SMTP",
+			},
+		},
+		"resource type of HTTP test": {
+			test:     livenessTestConfig{Protocol: "http", Port: 80, ResourceType: "A"},
+			expected: []string{"'resource_type' is only used by DNS tests, not by HTTP"},
+		},
+		"unknown values during plan": {
+			test: livenessTestConfig{Protocol: "HTTPS"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var messages []string
+			for _, err := range test.test.validate() {
+				messages = append(messages, err.Error())
+			}
+			assert.Equal(t, test.expected, messages)
+		})
+	}
+}
+
+func TestValidateLivenessTests(t *testing.T) {
+	err := validateLivenessTests([]interface{}{
+		map[string]interface{}{"name": "lt1", "test_object_protocol": "HTTP", "test_object_port": 80},
+		map[string]interface{}{"name": "lt2", "test_object_protocol": "HTTP", "test_object_port": 443, "http_method": "GET", "http_request_body": "body"},
+	})
+	assert.EqualError(t, err, "liveness_test 1 (lt2): 'test_object_port' 443 is the port of HTTPS, not of HTTP (80); "+
+		"set 'disable_nonstandard_port_warning' if the port is intended\n"+
+		"liveness_test 1 (lt2): 'http_request_body' is not sent with 'http_method' GET, use POST or PUT")
+}
+
+func TestLivenessTestRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/health":
+			_, _ = fmt.Fprint(w, "status: OK")
+		case "/echo":
+			body, _ := io.ReadAll(r.Body)
+			_, _ = fmt.Fprintf(w, "%s %s %s", r.Method, r.Header.Get("X-Test"), body)
+		case "/moved":
+			http.Redirect(w, r, "/health", http.StatusFound)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	host, portString, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+	port, err := strconv.Atoi(portString)
+	require.NoError(t, err)
+
+	tcpListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() {
+		_ = tcpListener.Close()
+	}()
+	go func() {
+		for {
+			conn, err := tcpListener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer func() {
+					_ = conn.Close()
+				}()
+				line, _ := bufio.NewReader(conn).ReadString('\n')
+				_, _ = fmt.Fprintf(conn, "+OK %s", line)
+			}()
+		}
+	}()
+	tcpPort := tcpListener.Addr().(*net.TCPAddr).Port
+
+	httpTest := func(test livenessTestConfig) livenessTestConfig {
+		test.Protocol = "HTTP"
+		test.Port = port
+		test.HTTPError3xx, test.HTTPError4xx, test.HTTPError5xx = true, true, true
+		test.Timeout = 5 * time.Second
+		return test
+	}
+	tcpTest := func(test livenessTestConfig) livenessTestConfig {
+		test.Protocol = "TCP"
+		test.Port = tcpPort
+		test.Timeout = 500 * time.Millisecond
+		return test
+	}
+
+	tests := map[string]struct {
+		test               livenessTestConfig
+		expectedStatusCode int
+		expectedError      string
+	}{
+		"HTTP test with response string": {
+			test:               httpTest(livenessTestConfig{TestObject: "/health", ResponseString: "OK"}),
+			expectedStatusCode: http.StatusOK,
+		},
+		"HTTP test with request body and headers": {
+			test: httpTest(livenessTestConfig{TestObject: "echo", HTTPMethod: "POST", HTTPRequestBody: "ping",
+				HTTPHeaders: []livenessTestHeader{{Name: "X-Test", Value: "abc"}}, ResponseString: "POST abc ping"}),
+			expectedStatusCode: http.StatusOK,
+		},
+		"HTTP test with response string not found": {
+			test:               httpTest(livenessTestConfig{TestObject: "/health", ResponseString: "FAILED"}),
+			expectedStatusCode: http.StatusOK,
+			expectedError:      `response string "FAILED" not found in the response`,
+		},
+		"HTTP test with error status": {
+			test:               httpTest(livenessTestConfig{TestObject: "/missing"}),
+			expectedStatusCode: http.StatusNotFound,
+			expectedError:      "response status 404 Not Found",
+		},
+		"HTTP test with error status allowed": {
+			test: func() livenessTestConfig {
+				test := httpTest(livenessTestConfig{TestObject: "/missing"})
+				test.HTTPError4xx = false
+				return test
+			}(),
+			expectedStatusCode: http.StatusNotFound,
+		},
+		"HTTP test with redirect not followed": {
+			test:               httpTest(livenessTestConfig{TestObject: "/moved"}),
+			expectedStatusCode: http.StatusFound,
+			expectedError:      "response status 302 Found",
+		},
+		"TCP test with request and response strings": {
+			test: tcpTest(livenessTestConfig{RequestString: "PING\n", ResponseString: "+OK PING"}),
+		},
+		"TCP test with response string not found": {
+			test:          tcpTest(livenessTestConfig{RequestString: "PING\n", ResponseString: "PONG"}),
+			expectedError: `response string "PONG" not found in the response`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := test.test.run(context.Background(), host)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedError, result.Error)
+			assert.Equal(t, test.expectedError == "", result.Success)
+			assert.Equal(t, test.expectedStatusCode, result.StatusCode)
+		})
+	}
+
+	t.Run("TCP test with connection refused", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		closedPort := listener.Addr().(*net.TCPAddr).Port
+		require.NoError(t, listener.Close())
+
+		result, err := livenessTestConfig{Protocol: "TCP", Port: closedPort, Timeout: time.Second}.run(context.Background(), "127.0.0.1")
+		require.NoError(t, err)
+		assert.False(t, result.Success)
+		assert.Contains(t, result.Error, "connection refused")
+	})
+
+	t.Run("protocol which cannot be run", func(t *testing.T) {
+		_, err := livenessTestConfig{Protocol: "DNS", Port: 53, Timeout: time.Second}.run(context.Background(), host)
+		assert.EqualError(t, err, "liveness tests with protocol DNS cannot be run")
+	})
+}
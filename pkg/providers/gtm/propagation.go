@@ -0,0 +1,134 @@
+package gtm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/gtm"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/timeouts"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/meta"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// defaultPollInterval is the interval between the checks of the propagation status, unless set in the provider
+	defaultPollInterval = 5 * time.Second
+	// readReserve is the part of the operation timeout left for reading the resource after waiting for the propagation
+	readReserve = 30 * time.Second
+)
+
+// ErrPropagationTimeout is returned when the changes of a domain are not propagated within the timeout of the operation
+var ErrPropagationTimeout = errors.New("propagation timeout")
+
+// propagationTimeouts returns the timeouts of the GTM resources, which limit the wait for the propagation of their changes
+func propagationTimeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Default: &timeouts.SDKDefaultTimeout,
+	}
+}
+
+// timeoutsSchema returns the schema of the timeouts block of the GTM resources
+func timeoutsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Enables to set timeout for processing, including the wait for the propagation of the changes",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"default": {
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: timeouts.ValidateDurationFormat,
+				},
+			},
+		},
+	}
+}
+
+// waitForCompletion waits until the changes of the domain are propagated, checking the propagation status
+// every poll interval set in the provider and logging it as the progress of the operation.
+// It returns true if the changes were propagated. If they are still pending when the timeout of the operation
+// is close, it returns false and no error, or ErrPropagationTimeout if the provider is set to fail on timeout.
+func waitForCompletion(ctx context.Context, domain string, m interface{}) (bool, error) {
+	meta := meta.Must(m)
+	logger := meta.Log("Akamai GTMv1", "waitForCompletion")
+	settings := meta.GTMSettings()
+
+	interval := settings.PollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	start := time.Now()
+	deadline := propagationDeadline(ctx, start)
+	if HashiAcc {
+		// Override for ACC tests
+		deadline = start.Add(interval)
+	}
+	logger.Debugf("WAIT: Poll Interval [%v]", interval)
+	logger.Debugf("WAIT: Deadline [%v]", deadline)
+
+	for {
+		propStat, err := Client(meta).GetDomainStatus(ctx, gtm.GetDomainStatusRequest{
+			DomainName: domain,
+		})
+		if err != nil {
+			return false, fmt.Errorf("GetDomainStatus error: %s", err.Error())
+		}
+		elapsed := time.Since(start).Round(time.Second)
+		progress := fmt.Sprintf("Propagation of domain %s changes is %s after %s", domain, propStat.PropagationStatus, elapsed)
+		if propStat.Message != "" {
+			progress += ": " + propStat.Message
+		}
+		logger.Info(progress)
+
+		switch propStat.PropagationStatus {
+		case "COMPLETE":
+			return true, nil
+		case "DENIED":
+			return false, errors.New(propStat.Message)
+		case "PENDING":
+			if !time.Now().Add(interval).Before(deadline) {
+				if settings.FailOnTimeout {
+					return false, fmt.Errorf("%w: changes of domain %s are still PENDING after %s", ErrPropagationTimeout, domain, elapsed)
+				}
+				logger.Warnf("Changes of domain %s are still PENDING after %s", domain, elapsed)
+				return false, nil
+			}
+			select {
+			case <-time.After(interval):
+			case <-ctx.Done():
+				return false, fmt.Errorf("waiting for propagation of domain %s changes: %w", domain, ctx.Err())
+			}
+		default:
+			return false, fmt.Errorf("unknown propagationStatus while waiting for change completion") // don't know how/why we would have broken out.
+		}
+	}
+}
+
+// propagationDeadline returns the time until which the propagation is waited for: the deadline of ctx,
+// set from the timeouts of the resource, less the time reserved for reading the resource afterwards
+func propagationDeadline(ctx context.Context, start time.Time) time.Time {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return start.Add(timeouts.SDKDefaultTimeout)
+	}
+	reserve := readReserve
+	if remaining := deadline.Sub(start); remaining < 2*reserve {
+		reserve = remaining / 2
+	}
+	return deadline.Add(-reserve)
+}
+
+// propagationPendingWarning warns that the operation completed before the changes of the domain were propagated
+func propagationPendingWarning(domain, operation string) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("%s: changes of domain %s are still being propagated", operation, domain),
+		Detail: "The changes were not propagated within the timeout of the operation. " +
+			"Increase the timeouts of the resource to wait longer, or set gtm_fail_on_timeout in the provider to fail in such case.",
+	}
+}
@@ -0,0 +1,112 @@
+package gtm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/gtm"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/testutils"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/timeouts"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/meta"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitForCompletion(t *testing.T) {
+	mockStatus := func(client *gtm.Mock, status string, times int) {
+		client.On("GetDomainStatus", testutils.MockContext, gtm.GetDomainStatusRequest{
+			DomainName: testDomainName,
+		}).Return(&gtm.GetDomainStatusResponse{PropagationStatus: status}, nil).Times(times)
+	}
+
+	tests := map[string]struct {
+		init          func(*gtm.Mock)
+		pollInterval  time.Duration
+		failOnTimeout bool
+		timeout       time.Duration
+		expected      bool
+		expectedErr   error
+		errorContains string
+	}{
+		"changes propagated after pending": {
+			init: func(client *gtm.Mock) {
+				mockStatus(client, "PENDING", 2)
+				mockStatus(client, "COMPLETE", 1)
+			},
+			pollInterval: time.Millisecond,
+			timeout:      time.Minute,
+			expected:     true,
+		},
+		"changes still pending at timeout": {
+			init: func(client *gtm.Mock) {
+				mockStatus(client, "PENDING", 1)
+			},
+			pollInterval: time.Second,
+			timeout:      20 * time.Millisecond,
+		},
+		"changes still pending at timeout with fail on timeout": {
+			init: func(client *gtm.Mock) {
+				mockStatus(client, "PENDING", 1)
+			},
+			pollInterval:  time.Second,
+			failOnTimeout: true,
+			timeout:       20 * time.Millisecond,
+			expectedErr:   ErrPropagationTimeout,
+		},
+		"changes denied": {
+			init: func(client *gtm.Mock) {
+				client.On("GetDomainStatus", testutils.MockContext, gtm.GetDomainStatusRequest{
+					DomainName: testDomainName,
+				}).Return(&gtm.GetDomainStatusResponse{PropagationStatus: "DENIED", Message: "denied by validation"}, nil).Once()
+			},
+			pollInterval:  time.Millisecond,
+			timeout:       time.Minute,
+			errorContains: "denied by validation",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &gtm.Mock{}
+			test.init(client)
+
+			providerMeta, err := meta.New(session.Must(session.New()), hclog.NewNullLogger(), "opID",
+				meta.WithGTMSettings(meta.GTMSettings{PollInterval: test.pollInterval, FailOnTimeout: test.failOnTimeout}))
+			require.NoError(t, err)
+
+			ctx, cancel := context.WithTimeout(context.Background(), test.timeout)
+			defer cancel()
+
+			useClient(client, func() {
+				completed, err := waitForCompletion(ctx, testDomainName, providerMeta)
+				switch {
+				case test.expectedErr != nil:
+					assert.ErrorIs(t, err, test.expectedErr)
+				case test.errorContains != "":
+					assert.ErrorContains(t, err, test.errorContains)
+				default:
+					assert.NoError(t, err)
+				}
+				assert.Equal(t, test.expected, completed)
+			})
+			client.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPropagationDeadline(t *testing.T) {
+	start := time.Now()
+
+	assert.Equal(t, start.Add(timeouts.SDKDefaultTimeout), propagationDeadline(context.Background(), start))
+
+	ctx, cancel := context.WithDeadline(context.Background(), start.Add(10*time.Minute))
+	defer cancel()
+	assert.Equal(t, start.Add(10*time.Minute-readReserve), propagationDeadline(ctx, start))
+
+	ctx, cancel = context.WithDeadline(context.Background(), start.Add(20*time.Second))
+	defer cancel()
+	assert.Equal(t, start.Add(10*time.Second), propagationDeadline(ctx, start))
+}
@@ -6,6 +6,8 @@ import (
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/meta"
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/subprovider"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -47,11 +49,22 @@ func (p *Subprovider) FrameworkDataSources() []func() datasource.DataSource {
 		NewGTMDomainsDataSource,
 		NewGTMGeoMapDataSource,
 		NewGTMGeoMapsDataSource,
+		NewGTMLivenessTestCheckDataSource,
 		NewGTMResourceDataSource,
 		NewGTMResourcesDataSource,
 	}
 }
 
+// EphemeralResources returns the gtm ephemeral resources implemented using terraform-plugin-framework
+func (p *Subprovider) EphemeralResources() []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{}
+}
+
+// Functions returns the gtm provider-defined functions implemented using terraform-plugin-framework
+func (p *Subprovider) Functions() []func() function.Function {
+	return []func() function.Function{}
+}
+
 // SDKResources returns the gtm resources implemented using terraform-plugin-sdk
 func (p *Subprovider) SDKResources() map[string]*schema.Resource {
 	return map[string]*schema.Resource{
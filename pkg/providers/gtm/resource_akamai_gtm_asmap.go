@@ -27,6 +27,7 @@ func resourceGTMv1ASMap() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceGTMv1ASMapImport,
 		},
+		Timeouts: propagationTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"domain": {
 				Type:     schema.TypeString,
@@ -81,6 +82,7 @@ func resourceGTMv1ASMap() *schema.Resource {
 					},
 				},
 			},
+			"timeouts": timeoutsSchema(),
 		},
 	}
 }
@@ -203,6 +205,7 @@ func resourceGTMv1ASMapCreate(ctx context.Context, d *schema.ResourceData, m int
 		ASMap:      newAS,
 		DomainName: domain,
 	})
+	invalidateDomainCache(meta, domain)
 	if err != nil {
 		logger.Errorf("asMap create error: %s", err.Error())
 		return append(diags, diag.Diagnostic{
@@ -218,6 +221,11 @@ func resourceGTMv1ASMapCreate(ctx context.Context, d *schema.ResourceData, m int
 			Summary:  cStatus.Status.Message,
 		})
 	}
+	// Give terraform the ID. Format domain:asMap
+	asMapID := fmt.Sprintf("%s:%s", domain, cStatus.Resource.Name)
+	logger.Debugf("Generated asMap Id: %s", asMapID)
+	d.SetId(asMapID)
+
 	waitOnComplete, err := tf.GetBoolValue("wait_on_complete", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -228,7 +236,8 @@ func resourceGTMv1ASMapCreate(ctx context.Context, d *schema.ResourceData, m int
 			logger.Infof("asMap create completed")
 		} else {
 			if err == nil {
-				logger.Infof("asMap create pending")
+				logger.Warnf("asMap create pending")
+				diags = append(diags, propagationPendingWarning(domain, "asMap create"))
 			} else {
 				logger.Errorf("asMap create error: %s", err.Error())
 				return append(diags, diag.Diagnostic{
@@ -240,11 +249,7 @@ func resourceGTMv1ASMapCreate(ctx context.Context, d *schema.ResourceData, m int
 		}
 	}
 
-	// Give terraform the ID. Format domain:asMap
-	asMapID := fmt.Sprintf("%s:%s", domain, cStatus.Resource.Name)
-	logger.Debugf("Generated asMap Id: %s", asMapID)
-	d.SetId(asMapID)
-	return resourceGTMv1ASMapRead(ctx, d, m)
+	return append(diags, resourceGTMv1ASMapRead(ctx, d, m)...)
 
 }
 
@@ -296,6 +301,10 @@ func resourceGTMv1ASMapUpdate(ctx context.Context, d *schema.ResourceData, m int
 	)
 
 	logger.Debugf("UPDATE asMap: %s", d.Id())
+	if !d.HasChangeExcept("timeouts") {
+		logger.Debug("Only timeouts were updated, skipping")
+		return nil
+	}
 	var diags diag.Diagnostics
 	// pull domain and asMap out of id
 	domain, asMap, err := parseResourceStringID(d.Id())
@@ -324,6 +333,7 @@ func resourceGTMv1ASMapUpdate(ctx context.Context, d *schema.ResourceData, m int
 		ASMap:      newAs,
 		DomainName: domain,
 	})
+	invalidateDomainCache(meta, domain)
 	if err != nil {
 		logger.Errorf("asMap update error: %s", err.Error())
 		return append(diags, diag.Diagnostic{
@@ -350,7 +360,8 @@ func resourceGTMv1ASMapUpdate(ctx context.Context, d *schema.ResourceData, m int
 			logger.Infof("asMap update completed")
 		} else {
 			if err == nil {
-				logger.Infof("asMap update pending")
+				logger.Warnf("asMap update pending")
+				diags = append(diags, propagationPendingWarning(domain, "asMap update"))
 			} else {
 				logger.Errorf("asMap update error: %s", err.Error())
 				return append(diags, diag.Diagnostic{
@@ -362,7 +373,7 @@ func resourceGTMv1ASMapUpdate(ctx context.Context, d *schema.ResourceData, m int
 		}
 	}
 
-	return resourceGTMv1ASMapRead(ctx, d, m)
+	return append(diags, resourceGTMv1ASMapRead(ctx, d, m)...)
 }
 
 func resourceGTMv1ASMapImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
@@ -435,6 +446,7 @@ func resourceGTMv1ASMapDelete(ctx context.Context, d *schema.ResourceData, m int
 		ASMapName:  asMap,
 		DomainName: domain,
 	})
+	invalidateDomainCache(meta, domain)
 	if err != nil {
 		logger.Errorf("asMap delete error: %s", err.Error())
 		return append(diags, diag.Diagnostic{
@@ -461,7 +473,8 @@ func resourceGTMv1ASMapDelete(ctx context.Context, d *schema.ResourceData, m int
 			logger.Infof("asMap delete completed")
 		} else {
 			if err == nil {
-				logger.Infof("asMap delete pending")
+				logger.Warnf("asMap delete pending")
+				diags = append(diags, propagationPendingWarning(domain, "asMap delete"))
 			} else {
 				logger.Errorf("asMap delete error: %s", err.Error())
 				return append(diags, diag.Diagnostic{
@@ -474,7 +487,7 @@ func resourceGTMv1ASMapDelete(ctx context.Context, d *schema.ResourceData, m int
 	}
 
 	d.SetId("")
-	return nil
+	return diags
 }
 
 // Create and populate a new asMap object from asMap data
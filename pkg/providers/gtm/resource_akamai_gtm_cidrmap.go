@@ -25,6 +25,7 @@ func resourceGTMv1CIDRMap() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceGTMv1CIDRMapImport,
 		},
+		Timeouts: propagationTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"domain": {
 				Type:     schema.TypeString,
@@ -78,6 +79,7 @@ func resourceGTMv1CIDRMap() *schema.Resource {
 					},
 				},
 			},
+			"timeouts": timeoutsSchema(),
 		},
 	}
 }
@@ -146,6 +148,7 @@ func resourceGTMv1CIDRMapCreate(ctx context.Context, d *schema.ResourceData, m i
 		CIDR:       newCidr,
 		DomainName: domain,
 	})
+	invalidateDomainCache(meta, domain)
 	if err != nil {
 		logger.Errorf("cidrMap create error: %s", err.Error())
 		return append(diags, diag.Diagnostic{
@@ -161,6 +164,11 @@ func resourceGTMv1CIDRMapCreate(ctx context.Context, d *schema.ResourceData, m i
 			Summary:  cStatus.Status.Message,
 		})
 	}
+	// Give terraform the ID. Format domain:cidrMap
+	cidrMapID := fmt.Sprintf("%s:%s", domain, cStatus.Resource.Name)
+	logger.Debugf("Generated cidrMap resource Id: %s", cidrMapID)
+	d.SetId(cidrMapID)
+
 	waitOnComplete, err := tf.GetBoolValue("wait_on_complete", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -171,7 +179,8 @@ func resourceGTMv1CIDRMapCreate(ctx context.Context, d *schema.ResourceData, m i
 			logger.Infof("cidrMap create completed")
 		} else {
 			if err == nil {
-				logger.Infof("cidrMap create pending")
+				logger.Warnf("cidrMap create pending")
+				diags = append(diags, propagationPendingWarning(domain, "cidrMap create"))
 			} else {
 				logger.Errorf("cidrMap create error: %s", err.Error())
 				return append(diags, diag.Diagnostic{
@@ -183,11 +192,7 @@ func resourceGTMv1CIDRMapCreate(ctx context.Context, d *schema.ResourceData, m i
 		}
 	}
 
-	// Give terraform the ID. Format domain:cidrMap
-	cidrMapID := fmt.Sprintf("%s:%s", domain, cStatus.Resource.Name)
-	logger.Debugf("Generated cidrMap resource Id: %s", cidrMapID)
-	d.SetId(cidrMapID)
-	return resourceGTMv1CIDRMapRead(ctx, d, m)
+	return append(diags, resourceGTMv1CIDRMapRead(ctx, d, m)...)
 
 }
 
@@ -239,6 +244,10 @@ func resourceGTMv1CIDRMapUpdate(ctx context.Context, d *schema.ResourceData, m i
 	)
 
 	logger.Debugf("Updating cidrMap: %s", d.Id())
+	if !d.HasChangeExcept("timeouts") {
+		logger.Debug("Only timeouts were updated, skipping")
+		return nil
+	}
 	var diags diag.Diagnostics
 	// pull domain and cidrMap out of id
 	domain, cidrMap, err := parseResourceStringID(d.Id())
@@ -267,6 +276,7 @@ func resourceGTMv1CIDRMapUpdate(ctx context.Context, d *schema.ResourceData, m i
 		CIDR:       newCidr,
 		DomainName: domain,
 	})
+	invalidateDomainCache(meta, domain)
 	if err != nil {
 		logger.Errorf("cidrMap update error: %s", err.Error())
 		return append(diags, diag.Diagnostic{
@@ -292,7 +302,8 @@ func resourceGTMv1CIDRMapUpdate(ctx context.Context, d *schema.ResourceData, m i
 			logger.Infof("cidrMap update completed")
 		} else {
 			if err == nil {
-				logger.Infof("cidrMap update pending")
+				logger.Warnf("cidrMap update pending")
+				diags = append(diags, propagationPendingWarning(domain, "cidrMap update"))
 			} else {
 				logger.Errorf("cidrMap update error: %s", err.Error())
 				return append(diags, diag.Diagnostic{
@@ -304,7 +315,7 @@ func resourceGTMv1CIDRMapUpdate(ctx context.Context, d *schema.ResourceData, m i
 		}
 	}
 
-	return resourceGTMv1CIDRMapRead(ctx, d, m)
+	return append(diags, resourceGTMv1CIDRMapRead(ctx, d, m)...)
 }
 
 func resourceGTMv1CIDRMapImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
@@ -378,6 +389,7 @@ func resourceGTMv1CIDRMapDelete(ctx context.Context, d *schema.ResourceData, m i
 		MapName:    cidrMap,
 		DomainName: domain,
 	})
+	invalidateDomainCache(meta, domain)
 	if err != nil {
 		logger.Errorf("cidrMap delete error: %s", err.Error())
 		return append(diags, diag.Diagnostic{
@@ -403,7 +415,8 @@ func resourceGTMv1CIDRMapDelete(ctx context.Context, d *schema.ResourceData, m i
 			logger.Infof("cidrMap delete completed")
 		} else {
 			if err == nil {
-				logger.Infof("cidrMap delete pending")
+				logger.Warnf("cidrMap delete pending")
+				diags = append(diags, propagationPendingWarning(domain, "cidrMap delete"))
 			} else {
 				logger.Errorf("cidrMap delete error: %s", err.Error())
 				return append(diags, diag.Diagnostic{
@@ -417,7 +430,7 @@ func resourceGTMv1CIDRMapDelete(ctx context.Context, d *schema.ResourceData, m i
 
 	// if successful ....
 	d.SetId("")
-	return nil
+	return diags
 }
 
 // Create and populate a new cidrMap object from cidrMap data
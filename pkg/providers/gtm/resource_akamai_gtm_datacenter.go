@@ -25,6 +25,7 @@ func resourceGTMv1Datacenter() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceGTMv1DatacenterImport,
 		},
+		Timeouts: propagationTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"domain": {
 				Type:     schema.TypeString,
@@ -130,6 +131,7 @@ func resourceGTMv1Datacenter() *schema.Resource {
 				Type:     schema.TypeBool,
 				Computed: true,
 			},
+			"timeouts": timeoutsSchema(),
 		},
 	}
 }
@@ -191,6 +193,7 @@ func resourceGTMv1DatacenterCreate(ctx context.Context, d *schema.ResourceData,
 		DomainName: domain,
 		Datacenter: newDC,
 	})
+	invalidateDomainCache(meta, domain)
 	if err != nil {
 		logger.Errorf("Datacenter create error: %s", err.Error())
 		return append(diags, diag.Diagnostic{
@@ -207,6 +210,11 @@ func resourceGTMv1DatacenterCreate(ctx context.Context, d *schema.ResourceData,
 			Summary:  cStatus.Status.Message,
 		})
 	}
+	// Give terraform the ID. Format domain::dcid
+	datacenterID := fmt.Sprintf("%s:%d", domain, cStatus.Resource.DatacenterID)
+	logger.Debugf("Generated DC resource ID: %s", datacenterID)
+	d.SetId(datacenterID)
+
 	waitOnComplete, err := tf.GetBoolValue("wait_on_complete", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -218,7 +226,8 @@ func resourceGTMv1DatacenterCreate(ctx context.Context, d *schema.ResourceData,
 			logger.Infof("Datacenter create completed")
 		} else {
 			if err == nil {
-				logger.Infof("Datacenter create pending")
+				logger.Warnf("Datacenter create pending")
+				diags = append(diags, propagationPendingWarning(domain, "Datacenter create"))
 			} else {
 				logger.Errorf("Datacenter create error: %s", err.Error())
 				return append(diags, diag.Diagnostic{
@@ -230,11 +239,7 @@ func resourceGTMv1DatacenterCreate(ctx context.Context, d *schema.ResourceData,
 		}
 	}
 
-	// Give terraform the ID. Format domain::dcid
-	datacenterID := fmt.Sprintf("%s:%d", domain, cStatus.Resource.DatacenterID)
-	logger.Debugf("Generated DC resource ID: %s", datacenterID)
-	d.SetId(datacenterID)
-	return resourceGTMv1DatacenterRead(ctx, d, m)
+	return append(diags, resourceGTMv1DatacenterRead(ctx, d, m)...)
 
 }
 
@@ -293,6 +298,10 @@ func resourceGTMv1DatacenterUpdate(ctx context.Context, d *schema.ResourceData,
 	)
 
 	logger.Debugf("Updating Datacenter: %s", d.Id())
+	if !d.HasChangeExcept("timeouts") {
+		logger.Debug("Only timeouts were updated, skipping")
+		return nil
+	}
 	var diags diag.Diagnostics
 	// pull domain and dcid out of resource id
 	domain, dcID, err := parseDatacenterResourceID(d.Id())
@@ -322,10 +331,20 @@ func resourceGTMv1DatacenterUpdate(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 	logger.Debugf("Updating Datacenter PROPOSED: %v", existDC)
-	uStat, err := Client(meta).UpdateDatacenter(ctx, gtm.UpdateDatacenterRequest{
-		Datacenter: existDC,
-		DomainName: domain,
-	})
+	var status *gtm.ResponseStatus
+	if batchUpdates(meta) {
+		status, err = submitDomainChange(ctx, meta, domain, upsertDatacenter(*existDC))
+	} else {
+		var uStat *gtm.UpdateDatacenterResponse
+		uStat, err = Client(meta).UpdateDatacenter(ctx, gtm.UpdateDatacenterRequest{
+			Datacenter: existDC,
+			DomainName: domain,
+		})
+		invalidateDomainCache(meta, domain)
+		if err == nil {
+			status = uStat.Status
+		}
+	}
 	if err != nil {
 		logger.Errorf("Datacenter update error: %s", err.Error())
 		return append(diags, diag.Diagnostic{
@@ -334,9 +353,9 @@ func resourceGTMv1DatacenterUpdate(ctx context.Context, d *schema.ResourceData,
 			Detail:   err.Error(),
 		})
 	}
-	logger.Debugf("Datacenter update status: %v", uStat)
-	if uStat.Status.PropagationStatus == "DENIED" {
-		logger.Errorf(uStat.Status.Message)
+	logger.Debugf("Datacenter update status: %v", status)
+	if status.PropagationStatus == "DENIED" {
+		logger.Errorf(status.Message)
 
 	}
 
@@ -351,7 +370,8 @@ func resourceGTMv1DatacenterUpdate(ctx context.Context, d *schema.ResourceData,
 			logger.Infof("Datacenter update completed")
 		} else {
 			if err == nil {
-				logger.Infof("Datacenter update pending")
+				logger.Warnf("Datacenter update pending")
+				diags = append(diags, propagationPendingWarning(domain, "Datacenter update"))
 			} else {
 				logger.Errorf("Datacenter update error: %s", err.Error())
 				return diag.FromErr(fmt.Errorf("Datacenter update error: %s", err.Error()))
@@ -359,7 +379,7 @@ func resourceGTMv1DatacenterUpdate(ctx context.Context, d *schema.ResourceData,
 		}
 	}
 
-	return resourceGTMv1DatacenterRead(ctx, d, m)
+	return append(diags, resourceGTMv1DatacenterRead(ctx, d, m)...)
 }
 
 func resourceGTMv1DatacenterImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
@@ -433,10 +453,20 @@ func resourceGTMv1DatacenterDelete(ctx context.Context, d *schema.ResourceData,
 		})
 	}
 	logger.Debugf("Deleting Datacenter: %v", existDC)
-	uStat, err := Client(meta).DeleteDatacenter(ctx, gtm.DeleteDatacenterRequest{
-		DatacenterID: dcID,
-		DomainName:   domain,
-	})
+	var status *gtm.ResponseStatus
+	if batchUpdates(meta) {
+		status, err = submitDomainChange(ctx, meta, domain, removeDatacenter(dcID))
+	} else {
+		var uStat *gtm.DeleteDatacenterResponse
+		uStat, err = Client(meta).DeleteDatacenter(ctx, gtm.DeleteDatacenterRequest{
+			DatacenterID: dcID,
+			DomainName:   domain,
+		})
+		invalidateDomainCache(meta, domain)
+		if err == nil {
+			status = uStat.Status
+		}
+	}
 	if err != nil {
 		logger.Errorf("Datacenter delete error: %s", err.Error())
 		return append(diags, diag.Diagnostic{
@@ -445,12 +475,12 @@ func resourceGTMv1DatacenterDelete(ctx context.Context, d *schema.ResourceData,
 			Detail:   err.Error(),
 		})
 	}
-	logger.Debugf("Datacenter delete status: %v", uStat)
-	if uStat.Status.PropagationStatus == "DENIED" {
-		logger.Errorf(uStat.Status.Message)
+	logger.Debugf("Datacenter delete status: %v", status)
+	if status.PropagationStatus == "DENIED" {
+		logger.Errorf(status.Message)
 		return append(diags, diag.Diagnostic{
 			Severity: diag.Error,
-			Summary:  uStat.Status.Message,
+			Summary:  status.Message,
 		})
 	}
 
@@ -465,7 +495,8 @@ func resourceGTMv1DatacenterDelete(ctx context.Context, d *schema.ResourceData,
 			logger.Infof("Datacenter delete completed")
 		} else {
 			if err == nil {
-				logger.Infof("Datacenter delete pending")
+				logger.Warnf("Datacenter delete pending")
+				diags = append(diags, propagationPendingWarning(domain, "Datacenter delete"))
 			} else {
 				logger.Errorf("Datacenter delete error: %s", err.Error())
 				return append(diags, diag.Diagnostic{
@@ -479,7 +510,7 @@ func resourceGTMv1DatacenterDelete(ctx context.Context, d *schema.ResourceData,
 
 	// if successful ....
 	d.SetId("")
-	return nil
+	return diags
 }
 
 // Create and populate a new datacenter object from resource data
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
-	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/gtm"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/session"
@@ -32,6 +31,7 @@ func resourceGTMv1Domain() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceGTMv1DomainImport,
 		},
+		Timeouts: propagationTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"contract": {
 				Type:             schema.TypeString,
@@ -189,6 +189,7 @@ func resourceGTMv1Domain() *schema.Resource {
 				Optional:    true,
 				Description: "The signing algorithm to use for signAndServe. One of the following values: RSA_SHA1, RSA_SHA256, RSA_SHA512, ECDSA_P256_SHA256, ECDSA_P384_SHA384, ED25519, ED448.",
 			},
+			"timeouts": timeoutsSchema(),
 		},
 	}
 }
@@ -273,6 +274,7 @@ func resourceGTMv1DomainCreate(ctx context.Context, d *schema.ResourceData, m in
 		Domain:    newDom,
 		QueryArgs: queryArgs,
 	})
+	invalidateDomainCache(meta, newDom.Name)
 	if err != nil {
 		// Errored. Let's see if special hack
 		if !HashiAcc {
@@ -312,6 +314,8 @@ func resourceGTMv1DomainCreate(ctx context.Context, d *schema.ResourceData, m in
 				Summary:  cStatus.Status.Message,
 			})
 		}
+		// Give terraform the ID, so that the domain is tainted if waiting for the propagation fails
+		d.SetId(dname)
 
 		waitOnComplete, err := tf.GetBoolValue("wait_on_complete", d)
 		if err != nil {
@@ -324,7 +328,8 @@ func resourceGTMv1DomainCreate(ctx context.Context, d *schema.ResourceData, m in
 				logger.Infof("Domain create completed")
 			} else {
 				if err == nil {
-					logger.Infof("Domain create pending")
+					logger.Warnf("Domain create pending")
+					diags = append(diags, propagationPendingWarning(dname, "Domain create"))
 				} else {
 					logger.Errorf("Domain create error: %s", err.Error())
 					return append(diags, diag.Diagnostic{
@@ -338,7 +343,7 @@ func resourceGTMv1DomainCreate(ctx context.Context, d *schema.ResourceData, m in
 	}
 	// Give terraform the ID
 	d.SetId(dname)
-	return resourceGTMv1DomainRead(ctx, d, m)
+	return append(diags, resourceGTMv1DomainRead(ctx, d, m)...)
 
 }
 
@@ -387,6 +392,10 @@ func resourceGTMv1DomainUpdate(ctx context.Context, d *schema.ResourceData, m in
 	)
 
 	logger.Debugf("Updating Domain: %s", d.Id())
+	if !d.HasChangeExcept("timeouts") {
+		logger.Debug("Only timeouts were updated, skipping")
+		return nil
+	}
 	var diags diag.Diagnostics
 	// Get existing domain
 	existDom, err := Client(meta).GetDomain(ctx, gtm.GetDomainRequest{
@@ -421,6 +430,7 @@ func resourceGTMv1DomainUpdate(ctx context.Context, d *schema.ResourceData, m in
 		Domain:    newDom,
 		QueryArgs: args,
 	})
+	invalidateDomainCache(meta, newDom.Name)
 	if err != nil {
 		logger.Errorf("Domain update error: %s", err.Error())
 		return append(diags, diag.Diagnostic{
@@ -449,7 +459,8 @@ func resourceGTMv1DomainUpdate(ctx context.Context, d *schema.ResourceData, m in
 			logger.Infof("Domain update completed")
 		} else {
 			if err == nil {
-				logger.Infof("Domain update pending")
+				logger.Warnf("Domain update pending")
+				diags = append(diags, propagationPendingWarning(d.Id(), "Domain update"))
 			} else {
 				logger.Errorf("Domain update error: %s", err.Error())
 				return append(diags, diag.Diagnostic{
@@ -462,7 +473,7 @@ func resourceGTMv1DomainUpdate(ctx context.Context, d *schema.ResourceData, m in
 
 	}
 
-	return resourceGTMv1DomainRead(ctx, d, m)
+	return append(diags, resourceGTMv1DomainRead(ctx, d, m)...)
 
 }
 
@@ -494,6 +505,7 @@ func resourceGTMv1DomainDelete(ctx context.Context, d *schema.ResourceData, m in
 	uStat, err := Client(meta).DeleteDomain(ctx, gtm.DeleteDomainRequest{
 		DomainName: d.Id(),
 	})
+	invalidateDomainCache(meta, d.Id())
 	if err != nil {
 		// Errored. Let's see if special hack
 		if !HashiAcc {
@@ -544,7 +556,8 @@ func resourceGTMv1DomainDelete(ctx context.Context, d *schema.ResourceData, m in
 				logger.Infof("Domain delete completed")
 			} else {
 				if err == nil {
-					logger.Infof("Domain delete pending")
+					logger.Warnf("Domain delete pending")
+					diags = append(diags, propagationPendingWarning(d.Id(), "Domain delete"))
 				} else {
 					logger.Errorf("Domain delete error: %s", err.Error())
 					return append(diags, diag.Diagnostic{
@@ -557,7 +570,7 @@ func resourceGTMv1DomainDelete(ctx context.Context, d *schema.ResourceData, m in
 		}
 	}
 	d.SetId("")
-	return nil
+	return diags
 
 }
 
@@ -894,47 +907,3 @@ func createDomainStruct(domain *gtm.GetDomainResponse) *gtm.Domain {
 	}
 	return nil
 }
-
-// Util function to wait for change deployment. return true if complete. false if not - error or nil (timeout)
-func waitForCompletion(ctx context.Context, domain string, m interface{}) (bool, error) {
-	meta := meta.Must(m)
-	logger := meta.Log("Akamai GTMv1", "waitForCompletion")
-
-	var defaultInterval = 5 * time.Second
-	var defaultTimeout = 300 * time.Second
-	var sleepInterval = defaultInterval // seconds. TODO:Should be configurable by user ...
-	var sleepTimeout = defaultTimeout   // seconds. TODO: Should be configurable by user ...
-	if HashiAcc {
-		// Override for ACC tests
-		sleepTimeout = sleepInterval
-	}
-	logger.Debugf("WAIT: Sleep Interval [%v]", sleepInterval/time.Second)
-	logger.Debugf("WAIT: Sleep Timeout [%v]", sleepTimeout/time.Second)
-	for {
-		propStat, err := Client(meta).GetDomainStatus(ctx, gtm.GetDomainStatusRequest{
-			DomainName: domain,
-		})
-		if err != nil {
-			return false, fmt.Errorf("GetDomainStatus error: %s", err.Error())
-		}
-		logger.Debugf("WAIT: propStat.PropagationStatus [%v]", propStat.PropagationStatus)
-		switch propStat.PropagationStatus {
-		case "COMPLETE":
-			logger.Debugf("WAIT: Return COMPLETE")
-			return true, nil
-		case "DENIED":
-			logger.Debugf("WAIT: Return DENIED")
-			return false, errors.New(propStat.Message)
-		case "PENDING":
-			if sleepTimeout <= 0 {
-				logger.Debugf("WAIT: Return TIMED OUT")
-				return false, nil
-			}
-			time.Sleep(sleepInterval)
-			sleepTimeout -= sleepInterval
-			logger.Debugf("WAIT: Sleep Time Remaining [%v]", sleepTimeout/time.Second)
-		default:
-			return false, fmt.Errorf("unknown propagationStatus while waiting for change completion") // don't know how/why we would have broken out.
-		}
-	}
-}
@@ -25,6 +25,7 @@ func resourceGTMv1GeoMap() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceGTMv1GeoMapImport,
 		},
+		Timeouts: propagationTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"domain": {
 				Type:     schema.TypeString,
@@ -79,6 +80,7 @@ func resourceGTMv1GeoMap() *schema.Resource {
 					},
 				},
 			},
+			"timeouts": timeoutsSchema(),
 		},
 	}
 }
@@ -151,10 +153,20 @@ func resourceGTMv1GeoMapCreate(ctx context.Context, d *schema.ResourceData, m in
 		})
 	}
 	logger.Debugf("Proposed New geoMap: [%v]", newGeo)
-	cStatus, err := Client(meta).CreateGeoMap(ctx, gtm.CreateGeoMapRequest{
-		GeoMap:     newGeo,
-		DomainName: domain,
-	})
+	var status *gtm.ResponseStatus
+	if batchUpdates(meta) {
+		status, err = submitDomainChange(ctx, meta, domain, upsertGeoMap(*newGeo))
+	} else {
+		var cStatus *gtm.CreateGeoMapResponse
+		cStatus, err = Client(meta).CreateGeoMap(ctx, gtm.CreateGeoMapRequest{
+			GeoMap:     newGeo,
+			DomainName: domain,
+		})
+		invalidateDomainCache(meta, domain)
+		if err == nil {
+			status = cStatus.Status
+		}
+	}
 	if err != nil {
 		logger.Errorf("geoMap create error: %s", err.Error())
 		return append(diags, diag.Diagnostic{
@@ -163,14 +175,19 @@ func resourceGTMv1GeoMapCreate(ctx context.Context, d *schema.ResourceData, m in
 			Detail:   err.Error(),
 		})
 	}
-	logger.Debugf("geoMap create status: %v", cStatus.Status)
-	if cStatus.Status.PropagationStatus == "DENIED" {
+	logger.Debugf("geoMap create status: %v", status)
+	if status.PropagationStatus == "DENIED" {
 		return append(diags, diag.Diagnostic{
 			Severity: diag.Error,
-			Summary:  cStatus.Status.Message,
+			Summary:  status.Message,
 		})
 	}
 
+	// Give terraform the ID. Format domain:geoMap
+	geoMapID := fmt.Sprintf("%s:%s", domain, newGeo.Name)
+	logger.Debugf("Generated geoMap resource ID: %s", geoMapID)
+	d.SetId(geoMapID)
+
 	waitOnComplete, err := tf.GetBoolValue("wait_on_complete", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -182,7 +199,8 @@ func resourceGTMv1GeoMapCreate(ctx context.Context, d *schema.ResourceData, m in
 			logger.Infof("geoMap create completed")
 		} else {
 			if err == nil {
-				logger.Infof("geoMap create pending")
+				logger.Warnf("geoMap create pending")
+				diags = append(diags, propagationPendingWarning(domain, "geoMap create"))
 			} else {
 				logger.Errorf("geoMap create error: %s", err.Error())
 				return append(diags, diag.Diagnostic{
@@ -195,11 +213,7 @@ func resourceGTMv1GeoMapCreate(ctx context.Context, d *schema.ResourceData, m in
 
 	}
 
-	// Give terraform the ID. Format domain:geoMap
-	geoMapID := fmt.Sprintf("%s:%s", domain, cStatus.Resource.Name)
-	logger.Debugf("Generated geoMap resource ID: %s", geoMapID)
-	d.SetId(geoMapID)
-	return resourceGTMv1GeoMapRead(ctx, d, m)
+	return append(diags, resourceGTMv1GeoMapRead(ctx, d, m)...)
 }
 
 func resourceGTMv1GeoMapRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -256,6 +270,10 @@ func resourceGTMv1GeoMapUpdate(ctx context.Context, d *schema.ResourceData, m in
 	)
 
 	logger.Debugf("Updating geoMap: %s", d.Id())
+	if !d.HasChangeExcept("timeouts") {
+		logger.Debug("Only timeouts were updated, skipping")
+		return nil
+	}
 	var diags diag.Diagnostics
 	// pull domain and geoMap out of id
 	domain, geoMap, err := parseResourceStringID(d.Id())
@@ -280,10 +298,20 @@ func resourceGTMv1GeoMapUpdate(ctx context.Context, d *schema.ResourceData, m in
 	logger.Debugf("Updating geoMap BEFORE: %v", newGeo)
 	populateGeoMapObject(d, newGeo, m)
 	logger.Debugf("Updating geoMap PROPOSED: %v", existGeo)
-	uStat, err := Client(meta).UpdateGeoMap(ctx, gtm.UpdateGeoMapRequest{
-		GeoMap:     newGeo,
-		DomainName: domain,
-	})
+	var status *gtm.ResponseStatus
+	if batchUpdates(meta) {
+		status, err = submitDomainChange(ctx, meta, domain, upsertGeoMap(*newGeo))
+	} else {
+		var uStat *gtm.UpdateGeoMapResponse
+		uStat, err = Client(meta).UpdateGeoMap(ctx, gtm.UpdateGeoMapRequest{
+			GeoMap:     newGeo,
+			DomainName: domain,
+		})
+		invalidateDomainCache(meta, domain)
+		if err == nil {
+			status = uStat.Status
+		}
+	}
 	if err != nil {
 		logger.Errorf("geoMap update error: %s", err.Error())
 		return append(diags, diag.Diagnostic{
@@ -292,12 +320,12 @@ func resourceGTMv1GeoMapUpdate(ctx context.Context, d *schema.ResourceData, m in
 			Detail:   err.Error(),
 		})
 	}
-	logger.Debugf("geoMap update status: %v", uStat)
-	if uStat.Status.PropagationStatus == "DENIED" {
-		logger.Errorf(uStat.Status.Message)
+	logger.Debugf("geoMap update status: %v", status)
+	if status.PropagationStatus == "DENIED" {
+		logger.Errorf(status.Message)
 		return append(diags, diag.Diagnostic{
 			Severity: diag.Error,
-			Summary:  uStat.Status.Message,
+			Summary:  status.Message,
 		})
 	}
 
@@ -312,7 +340,8 @@ func resourceGTMv1GeoMapUpdate(ctx context.Context, d *schema.ResourceData, m in
 			logger.Infof("geoMap update completed")
 		} else {
 			if err == nil {
-				logger.Infof("geoMap update pending")
+				logger.Warnf("geoMap update pending")
+				diags = append(diags, propagationPendingWarning(domain, "geoMap update"))
 			} else {
 				logger.Errorf("geoMap update error: %s", err.Error())
 				return append(diags, diag.Diagnostic{
@@ -325,7 +354,7 @@ func resourceGTMv1GeoMapUpdate(ctx context.Context, d *schema.ResourceData, m in
 
 	}
 
-	return resourceGTMv1GeoMapRead(ctx, d, m)
+	return append(diags, resourceGTMv1GeoMapRead(ctx, d, m)...)
 }
 
 func resourceGTMv1GeoMapImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
@@ -401,10 +430,20 @@ func resourceGTMv1GeoMapDelete(ctx context.Context, d *schema.ResourceData, m in
 	}
 	newGeo := createGeoMapStruct(existGeo)
 	logger.Debugf("Deleting geoMap: %v", newGeo)
-	uStat, err := Client(meta).DeleteGeoMap(ctx, gtm.DeleteGeoMapRequest{
-		MapName:    geoMap,
-		DomainName: domain,
-	})
+	var status *gtm.ResponseStatus
+	if batchUpdates(meta) {
+		status, err = submitDomainChange(ctx, meta, domain, removeGeoMap(geoMap))
+	} else {
+		var uStat *gtm.DeleteGeoMapResponse
+		uStat, err = Client(meta).DeleteGeoMap(ctx, gtm.DeleteGeoMapRequest{
+			MapName:    geoMap,
+			DomainName: domain,
+		})
+		invalidateDomainCache(meta, domain)
+		if err == nil {
+			status = uStat.Status
+		}
+	}
 	if err != nil {
 		logger.Errorf("geoMap delete error: %s", err.Error())
 		return append(diags, diag.Diagnostic{
@@ -413,12 +452,12 @@ func resourceGTMv1GeoMapDelete(ctx context.Context, d *schema.ResourceData, m in
 			Detail:   err.Error(),
 		})
 	}
-	logger.Debugf("geoMap delete status: %v", uStat)
-	if uStat.Status.PropagationStatus == "DENIED" {
-		logger.Errorf(uStat.Status.Message)
+	logger.Debugf("geoMap delete status: %v", status)
+	if status.PropagationStatus == "DENIED" {
+		logger.Errorf(status.Message)
 		return append(diags, diag.Diagnostic{
 			Severity: diag.Error,
-			Summary:  uStat.Status.Message,
+			Summary:  status.Message,
 		})
 	}
 
@@ -433,7 +472,8 @@ func resourceGTMv1GeoMapDelete(ctx context.Context, d *schema.ResourceData, m in
 			logger.Infof("geoMap delete completed")
 		} else {
 			if err == nil {
-				logger.Infof("geoMap delete pending")
+				logger.Warnf("geoMap delete pending")
+				diags = append(diags, propagationPendingWarning(domain, "geoMap delete"))
 			} else {
 				logger.Errorf("geoMap delete error: %s", err.Error())
 				return append(diags, diag.Diagnostic{
@@ -446,7 +486,7 @@ func resourceGTMv1GeoMapDelete(ctx context.Context, d *schema.ResourceData, m in
 	}
 
 	d.SetId("")
-	return nil
+	return diags
 }
 
 // Create and populate a new geoMap object from geoMap data
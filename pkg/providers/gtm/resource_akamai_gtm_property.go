@@ -34,6 +34,7 @@ func resourceGTMv1Property() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceGTMv1PropertyImport,
 		},
+		Timeouts: propagationTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"domain": {
 				Type:     schema.TypeString,
@@ -357,6 +358,7 @@ func resourceGTMv1Property() *schema.Resource {
 					},
 				},
 			},
+			"timeouts": timeoutsSchema(),
 		},
 	}
 }
@@ -463,7 +465,7 @@ func customDiffGTMProperty(_ context.Context, d *schema.ResourceDiff, m interfac
 		}
 	}
 
-	return nil
+	return validateLivenessTests(livenessTest)
 }
 
 // validateTTL is a SchemaValidateDiagFunc to validate dynamic_ttl.
@@ -538,33 +540,49 @@ func resourceGTMv1PropertyCreate(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 	logger.Debugf("Proposed New Property: [%v]", newProp)
-	cStatus, err := createPropertyWithRetry(ctx, meta, logger, gtm.CreatePropertyRequest{
-		Property:   newProp,
-		DomainName: domain,
-	})
+	var status *gtm.ResponseStatus
+	if batchUpdates(meta) {
+		status, err = submitDomainChange(ctx, meta, domain, upsertProperty(*newProp))
+	} else {
+		var cStatus *gtm.CreatePropertyResponse
+		cStatus, err = createPropertyWithRetry(ctx, meta, logger, gtm.CreatePropertyRequest{
+			Property:   newProp,
+			DomainName: domain,
+		})
+		if err == nil {
+			status = cStatus.Status
+		}
+	}
 	if err != nil {
 		logger.Errorf("Property create error: %s", err.Error())
 		return diag.Errorf("property create error: %s", err.Error())
 	}
-	logger.Debugf("Property create status: %v", cStatus.Status)
+	logger.Debugf("Property create status: %v", status)
 
-	if cStatus.Status.PropagationStatus == "DENIED" {
-		logger.Errorf(cStatus.Status.Message)
-		return diag.FromErr(errors.New(cStatus.Status.Message))
+	if status.PropagationStatus == "DENIED" {
+		logger.Errorf(status.Message)
+		return diag.FromErr(errors.New(status.Message))
 	}
 
+	// Give terraform the ID. Format domain::property
+	propertyResourceID := fmt.Sprintf("%s:%s", domain, newProp.Name)
+	logger.Debugf("Generated Property resource ID: %s", propertyResourceID)
+	d.SetId(propertyResourceID)
+
 	waitOnComplete, err := tf.GetBoolValue("wait_on_complete", d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	var diags diag.Diagnostics
 	if waitOnComplete {
 		done, err := waitForCompletion(ctx, domain, m)
 		if done {
 			logger.Infof("Property create completed")
 		} else {
 			if err == nil {
-				logger.Infof("Property create pending")
+				logger.Warnf("Property create pending")
+				diags = append(diags, propagationPendingWarning(domain, "Property create"))
 			} else {
 				logger.Errorf("Property create error: %s", err.Error())
 				return diag.Errorf("property create error: %s", err.Error())
@@ -572,11 +590,7 @@ func resourceGTMv1PropertyCreate(ctx context.Context, d *schema.ResourceData, m
 		}
 	}
 
-	// Give terraform the ID. Format domain::property
-	propertyResourceID := fmt.Sprintf("%s:%s", domain, cStatus.Resource.Name)
-	logger.Debugf("Generated Property resource ID: %s", propertyResourceID)
-	d.SetId(propertyResourceID)
-	return resourceGTMv1PropertyRead(ctx, d, m)
+	return append(diags, resourceGTMv1PropertyRead(ctx, d, m)...)
 
 }
 
@@ -589,6 +603,7 @@ func createPropertyWithRetry(ctx context.Context, meta meta.Meta, logger akalog.
 	for {
 		// Attempt to create the property
 		cStatus, err := Client(meta).CreateProperty(ctx, createPropertyRequest)
+		invalidateDomainCache(meta, createPropertyRequest.DomainName)
 		if err == nil {
 			// Success, return the created property
 			return cStatus, nil
@@ -666,6 +681,10 @@ func resourceGTMv1PropertyUpdate(ctx context.Context, d *schema.ResourceData, m
 	)
 
 	logger.Debugf("Updating Property: %s", d.Id())
+	if !d.HasChangeExcept("timeouts") {
+		logger.Debug("Only timeouts were updated, skipping")
+		return nil
+	}
 	// pull domain and property out of resource id
 	domain, property, err := parseResourceStringID(d.Id())
 	if err != nil {
@@ -687,18 +706,28 @@ func resourceGTMv1PropertyUpdate(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 	logger.Debugf("Updating Property PROPOSED: %v", newProp)
-	uStat, err := Client(meta).UpdateProperty(ctx, gtm.UpdatePropertyRequest{
-		Property:   newProp,
-		DomainName: domain,
-	})
+	var status *gtm.ResponseStatus
+	if batchUpdates(meta) {
+		status, err = submitDomainChange(ctx, meta, domain, upsertProperty(*newProp))
+	} else {
+		var uStat *gtm.UpdatePropertyResponse
+		uStat, err = Client(meta).UpdateProperty(ctx, gtm.UpdatePropertyRequest{
+			Property:   newProp,
+			DomainName: domain,
+		})
+		invalidateDomainCache(meta, domain)
+		if err == nil {
+			status = uStat.Status
+		}
+	}
 	if err != nil {
 		logger.Errorf("Property update error: %s", err.Error())
 		return diag.Errorf("property update error: %s", err.Error())
 	}
-	logger.Debugf("Property update status: %v", uStat)
-	if uStat.Status.PropagationStatus == "DENIED" {
-		logger.Debugf(uStat.Status.Message)
-		return diag.FromErr(errors.New(uStat.Status.Message))
+	logger.Debugf("Property update status: %v", status)
+	if status.PropagationStatus == "DENIED" {
+		logger.Debugf(status.Message)
+		return diag.FromErr(errors.New(status.Message))
 	}
 
 	waitOnComplete, err := tf.GetBoolValue("wait_on_complete", d)
@@ -706,13 +735,15 @@ func resourceGTMv1PropertyUpdate(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 
+	var diags diag.Diagnostics
 	if waitOnComplete {
 		done, err := waitForCompletion(ctx, domain, m)
 		if done {
 			logger.Infof("Property update completed")
 		} else {
 			if err == nil {
-				logger.Infof("Property update pending")
+				logger.Warnf("Property update pending")
+				diags = append(diags, propagationPendingWarning(domain, "Property update"))
 			} else {
 				logger.Errorf("Property update error: %s", err.Error())
 				return diag.Errorf("property update error: %s", err.Error())
@@ -720,7 +751,7 @@ func resourceGTMv1PropertyUpdate(ctx context.Context, d *schema.ResourceData, m
 		}
 	}
 
-	return resourceGTMv1PropertyRead(ctx, d, m)
+	return append(diags, resourceGTMv1PropertyRead(ctx, d, m)...)
 }
 
 // Import GTM Property.
@@ -785,18 +816,28 @@ func resourceGTMv1PropertyDelete(ctx context.Context, d *schema.ResourceData, m
 	}
 	newProp := createPropertyStruct(existProp)
 	logger.Debugf("Deleting Property: %v", newProp)
-	uStat, err := Client(meta).DeleteProperty(ctx, gtm.DeletePropertyRequest{
-		PropertyName: property,
-		DomainName:   domain,
-	})
+	var status *gtm.ResponseStatus
+	if batchUpdates(meta) {
+		status, err = submitDomainChange(ctx, meta, domain, removeProperty(property))
+	} else {
+		var uStat *gtm.DeletePropertyResponse
+		uStat, err = Client(meta).DeleteProperty(ctx, gtm.DeletePropertyRequest{
+			PropertyName: property,
+			DomainName:   domain,
+		})
+		invalidateDomainCache(meta, domain)
+		if err == nil {
+			status = uStat.Status
+		}
+	}
 	if err != nil {
 		logger.Errorf("Property delete error: %s", err.Error())
 		return diag.Errorf("property delete error: %s", err.Error())
 	}
-	logger.Debugf("Property delete status: %v", uStat)
-	if uStat.Status.PropagationStatus == "DENIED" {
-		logger.Errorf(uStat.Status.Message)
-		return diag.FromErr(errors.New(uStat.Status.Message))
+	logger.Debugf("Property delete status: %v", status)
+	if status.PropagationStatus == "DENIED" {
+		logger.Errorf(status.Message)
+		return diag.FromErr(errors.New(status.Message))
 	}
 
 	waitOnComplete, err := tf.GetBoolValue("wait_on_complete", d)
@@ -804,13 +845,15 @@ func resourceGTMv1PropertyDelete(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 
+	var diags diag.Diagnostics
 	if waitOnComplete {
 		done, err := waitForCompletion(ctx, domain, m)
 		if done {
 			logger.Infof("Property delete completed")
 		} else {
 			if err == nil {
-				logger.Infof("Property delete pending")
+				logger.Warnf("Property delete pending")
+				diags = append(diags, propagationPendingWarning(domain, "Property delete"))
 			} else {
 				logger.Errorf("Property delete error: %s", err.Error())
 				return diag.Errorf("property delete error: %s", err.Error())
@@ -820,7 +863,7 @@ func resourceGTMv1PropertyDelete(ctx context.Context, d *schema.ResourceData, m
 
 	// if successful ....
 	d.SetId("")
-	return nil
+	return diags
 }
 
 // nolint:gocyclo
@@ -186,7 +186,7 @@ func TestResGTMProperty(t *testing.T) {
 						resource.TestCheckResourceAttr(propertyResourceName, "type", "weighted-round-robin"),
 						resource.TestCheckResourceAttr(propertyResourceName, "weighted_hash_bits_for_ipv4", "0"),
 						resource.TestCheckResourceAttr(propertyResourceName, "weighted_hash_bits_for_ipv6", "0"),
-						resource.TestCheckResourceAttr(propertyResourceName, "liveness_test.0.http_method", "GET"),
+						resource.TestCheckResourceAttr(propertyResourceName, "liveness_test.0.http_method", "POST"),
 						resource.TestCheckResourceAttr(propertyResourceName, "liveness_test.0.http_request_body", "Body"),
 						resource.TestCheckResourceAttr(propertyResourceName, "liveness_test.0.pre_2023_security_posture", "true"),
 						resource.TestCheckResourceAttr(propertyResourceName, "liveness_test.0.alternate_ca_certificates.0", "test1"),
@@ -508,6 +508,15 @@ func TestResGTMProperty(t *testing.T) {
 				},
 			},
 		},
+		"create property with misconfigured liveness tests - error": {
+			property: getBasicProperty(),
+			steps: []resource.TestStep{
+				{
+					Config:      testutils.LoadFixtureString(t, "testdata/TestResGtmProperty/liveness_test/misconfigured_liveness_tests.tf"),
+					ExpectError: regexp.MustCompile(`'test_object_port' 80 is the port of HTTP, not of HTTPS`),
+				},
+			},
+		},
 	}
 
 	for name, test := range tests {
@@ -1199,7 +1208,7 @@ func getBasicPropertyWithLivenessTests() *gtm.Property {
 						Value: "test_value",
 					},
 				},
-				HTTPMethod:              ptr.To("GET"),
+				HTTPMethod:              ptr.To("POST"),
 				HTTPRequestBody:         ptr.To("Body"),
 				Pre2023SecurityPosture:  true,
 				AlternateCACertificates: []string{"test1"},
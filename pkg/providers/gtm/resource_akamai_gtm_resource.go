@@ -26,6 +26,7 @@ func resourceGTMv1Resource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceGTMv1ResourceImport,
 		},
+		Timeouts: propagationTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"domain": {
 				Type:     schema.TypeString,
@@ -114,6 +115,7 @@ func resourceGTMv1Resource() *schema.Resource {
 					},
 				},
 			},
+			"timeouts": timeoutsSchema(),
 		},
 	}
 }
@@ -171,6 +173,7 @@ func resourceGTMv1ResourceCreate(ctx context.Context, d *schema.ResourceData, m
 		Resource:   newRsrc,
 		DomainName: domain,
 	})
+	invalidateDomainCache(meta, domain)
 	if err != nil {
 		logger.Errorf("Resource create error: %s", err.Error())
 		return append(diags, diag.Diagnostic{
@@ -188,6 +191,11 @@ func resourceGTMv1ResourceCreate(ctx context.Context, d *schema.ResourceData, m
 		})
 	}
 
+	// Give terraform the ID. Format domain:resource
+	resourceID := fmt.Sprintf("%s:%s", domain, cStatus.Resource.Name)
+	logger.Debugf("Generated Resource. Resource ID: %s", resourceID)
+	d.SetId(resourceID)
+
 	waitOnComplete, err := tf.GetBoolValue("wait_on_complete", d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -199,7 +207,8 @@ func resourceGTMv1ResourceCreate(ctx context.Context, d *schema.ResourceData, m
 			logger.Infof("Resource create completed")
 		} else {
 			if err == nil {
-				logger.Infof("Resource create pending")
+				logger.Warnf("Resource create pending")
+				diags = append(diags, propagationPendingWarning(domain, "Resource create"))
 			} else {
 				logger.Errorf("Resource create error: %s", err.Error())
 				return append(diags, diag.Diagnostic{
@@ -211,11 +220,7 @@ func resourceGTMv1ResourceCreate(ctx context.Context, d *schema.ResourceData, m
 		}
 	}
 
-	// Give terraform the ID. Format domain:resource
-	resourceID := fmt.Sprintf("%s:%s", domain, cStatus.Resource.Name)
-	logger.Debugf("Generated Resource. Resource ID: %s", resourceID)
-	d.SetId(resourceID)
-	return resourceGTMv1ResourceRead(ctx, d, m)
+	return append(diags, resourceGTMv1ResourceRead(ctx, d, m)...)
 
 }
 
@@ -275,6 +280,10 @@ func resourceGTMv1ResourceUpdate(ctx context.Context, d *schema.ResourceData, m
 	)
 
 	logger.Infof("Updating Resource %s", d.Id())
+	if !d.HasChangeExcept("timeouts") {
+		logger.Debug("Only timeouts were updated, skipping")
+		return nil
+	}
 	var diags diag.Diagnostics
 	// pull domain and resource out of id
 	domain, resource, err := parseResourceStringID(d.Id())
@@ -305,6 +314,7 @@ func resourceGTMv1ResourceUpdate(ctx context.Context, d *schema.ResourceData, m
 		Resource:   newRsrc,
 		DomainName: domain,
 	})
+	invalidateDomainCache(meta, domain)
 	if err != nil {
 		logger.Errorf("Resource update error: %s", err.Error())
 		return append(diags, diag.Diagnostic{
@@ -333,7 +343,8 @@ func resourceGTMv1ResourceUpdate(ctx context.Context, d *schema.ResourceData, m
 			logger.Infof("Resource update completed")
 		} else {
 			if err == nil {
-				logger.Infof("Resource update pending")
+				logger.Warnf("Resource update pending")
+				diags = append(diags, propagationPendingWarning(domain, "Resource update"))
 			} else {
 				logger.Errorf("Resource update error: %s", err.Error())
 				return append(diags, diag.Diagnostic{
@@ -345,7 +356,7 @@ func resourceGTMv1ResourceUpdate(ctx context.Context, d *schema.ResourceData, m
 		}
 	}
 
-	return resourceGTMv1ResourceRead(ctx, d, m)
+	return append(diags, resourceGTMv1ResourceRead(ctx, d, m)...)
 }
 
 // Import GTM Resource.
@@ -429,6 +440,7 @@ func resourceGTMv1ResourceDelete(ctx context.Context, d *schema.ResourceData, m
 		ResourceName: resource,
 		DomainName:   domain,
 	})
+	invalidateDomainCache(meta, domain)
 	if err != nil {
 		logger.Errorf("Resource delete error: %s", err.Error())
 		return append(diags, diag.Diagnostic{
@@ -457,7 +469,8 @@ func resourceGTMv1ResourceDelete(ctx context.Context, d *schema.ResourceData, m
 			logger.Infof("Resource delete completed")
 		} else {
 			if err == nil {
-				logger.Infof("Resource delete pending")
+				logger.Warnf("Resource delete pending")
+				diags = append(diags, propagationPendingWarning(domain, "Resource delete"))
 			} else {
 				logger.Errorf("Resource delete error: %s", err.Error())
 				return append(diags, diag.Diagnostic{
@@ -471,7 +484,7 @@ func resourceGTMv1ResourceDelete(ctx context.Context, d *schema.ResourceData, m
 
 	// if successful ....
 	d.SetId("")
-	return nil
+	return diags
 }
 
 // Create and populate a new resource object from resource data
@@ -0,0 +1,15 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+data "akamai_gtm_liveness_test_check" "test" {
+  target               = "%s"
+  test_object_protocol = "HTTP"
+  test_object_port     = %d
+  test_object          = "/health"
+  response_string      = "OK"
+  http_header = [{
+    name  = "X-Test"
+    value = "abc"
+  }]
+}
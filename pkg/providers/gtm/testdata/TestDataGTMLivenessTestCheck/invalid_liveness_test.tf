@@ -0,0 +1,10 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+data "akamai_gtm_liveness_test_check" "test" {
+  target               = "127.0.0.1"
+  test_object_protocol = "HTTP"
+  test_object_port     = 8080
+  http_request_body    = "ping"
+}
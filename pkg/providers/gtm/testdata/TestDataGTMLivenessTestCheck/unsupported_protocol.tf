@@ -0,0 +1,8 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+data "akamai_gtm_liveness_test_check" "test" {
+  target               = "127.0.0.1"
+  test_object_protocol = "DNS"
+}
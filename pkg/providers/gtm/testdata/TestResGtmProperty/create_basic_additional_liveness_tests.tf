@@ -32,7 +32,7 @@ resource "akamai_gtm_property" "tfexample_prop_1" {
     http_error3xx                    = false
     http_error4xx                    = false
     http_error5xx                    = false
-    http_method                      = "GET"
+    http_method                      = "POST"
     http_request_body                = "Body"
     pre_2023_security_posture        = true
     alternate_ca_certificates        = ["test1"]
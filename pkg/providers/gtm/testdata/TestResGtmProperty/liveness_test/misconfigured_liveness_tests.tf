@@ -0,0 +1,49 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+locals {
+  gtmTestDomain = "gtm_terra_testdomain.akadns.net"
+}
+
+resource "akamai_gtm_property" "tfexample_prop_1" {
+  domain                 = local.gtmTestDomain
+  name                   = "tfexample_prop_1"
+  type                   = "weighted-round-robin"
+  score_aggregation_type = "median"
+  handout_limit          = 5
+  handout_mode           = "normal"
+  traffic_target {
+    datacenter_id = 3131
+    enabled       = true
+    weight        = 200
+    servers       = ["1.2.3.9"]
+    handout_cname = "test"
+  }
+  liveness_test {
+    name                 = "lt5"
+    test_interval        = 40
+    test_object_protocol = "HTTPS"
+    test_timeout         = 30
+    test_object          = "/junk"
+    http_method          = "GET"
+    http_request_body    = "Body"
+  }
+  liveness_test {
+    name                 = "lt2"
+    test_interval        = 30
+    test_object_protocol = "FTP"
+    test_object_port     = 21
+    test_timeout         = 20
+    test_object          = "/junk"
+    response_string      = "OK"
+  }
+  static_rr_set {
+    type  = "MX"
+    ttl   = 300
+    rdata = ["100 test_e"]
+  }
+  failover_delay   = 0
+  failback_delay   = 0
+  wait_on_complete = false
+}
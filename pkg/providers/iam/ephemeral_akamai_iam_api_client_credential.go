@@ -0,0 +1,198 @@
+package iam
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/iam"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/meta"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = &apiClientCredentialEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &apiClientCredentialEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithClose     = &apiClientCredentialEphemeralResource{}
+)
+
+// apiClientCredentialPrivateKey is the key under which the created credential is kept in the private data
+const apiClientCredentialPrivateKey = "credential"
+
+type (
+	apiClientCredentialEphemeralResource struct {
+		meta meta.Meta
+	}
+
+	apiClientCredentialModel struct {
+		ClientID      types.String `tfsdk:"client_id"`
+		RevokeOnClose types.Bool   `tfsdk:"revoke_on_close"`
+		CredentialID  types.Int64  `tfsdk:"credential_id"`
+		ClientToken   types.String `tfsdk:"client_token"`
+		ClientSecret  types.String `tfsdk:"client_secret"`
+		Description   types.String `tfsdk:"description"`
+		Status        types.String `tfsdk:"status"`
+		CreatedOn     types.String `tfsdk:"created_on"`
+		ExpiresOn     types.String `tfsdk:"expires_on"`
+	}
+
+	apiClientCredentialPrivateData struct {
+		ClientID      string `json:"clientId"`
+		CredentialID  int64  `json:"credentialId"`
+		RevokeOnClose bool   `json:"revokeOnClose"`
+	}
+)
+
+// NewAPIClientCredentialEphemeralResource returns a new akamai_iam_api_client_credential ephemeral resource.
+func NewAPIClientCredentialEphemeralResource() ephemeral.EphemeralResource {
+	return &apiClientCredentialEphemeralResource{}
+}
+
+func (e *apiClientCredentialEphemeralResource) Metadata(_ context.Context, _ ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = "akamai_iam_api_client_credential"
+}
+
+func (e *apiClientCredentialEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			resp.Diagnostics.AddError(
+				"Unexpected Ephemeral Resource Configure Type",
+				fmt.Sprintf("Expected meta.Meta, got: %T. Please report this issue to the provider developers.",
+					req.ProviderData))
+		}
+	}()
+	e.meta = meta.Must(req.ProviderData)
+}
+
+func (e *apiClientCredentialEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Identity and Access Management API client credential. A new credential is created every time the ephemeral resource is opened and is never persisted in the state.",
+		Attributes: map[string]schema.Attribute{
+			"client_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "A unique identifier of the API client. If not provided, the credential is created for the API client used to authenticate the provider.",
+			},
+			"revoke_on_close": schema.BoolAttribute{
+				Optional: true,
+				Description: "Whether to deactivate and delete the credential once Terraform no longer needs it. " +
+					"A new credential is created on every plan and apply, so set it to false only if the credential is stored elsewhere " +
+					"before Terraform finishes. Defaults to true.",
+			},
+			"credential_id": schema.Int64Attribute{
+				Computed:    true,
+				Description: "A unique identifier of the credential.",
+			},
+			"client_token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The client token of the credential.",
+			},
+			"client_secret": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The client secret of the credential.",
+			},
+			"description": schema.StringAttribute{
+				Computed:    true,
+				Description: "A human-readable description of the credential.",
+			},
+			"status": schema.StringAttribute{
+				Computed:    true,
+				Description: "Whether the credential is 'ACTIVE', 'INACTIVE', or 'DELETED'.",
+			},
+			"created_on": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ISO 8601 timestamp indicating when the credential was created.",
+			},
+			"expires_on": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ISO 8601 timestamp indicating when the credential expires.",
+			},
+		},
+	}
+}
+
+func (e *apiClientCredentialEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	tflog.Debug(ctx, "IAM API Client Credential Ephemeral Resource Open")
+
+	var data apiClientCredentialModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := inst.Client(e.meta)
+	credential, err := client.CreateCredential(ctx, iam.CreateCredentialRequest{
+		ClientID: data.ClientID.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Creating IAM API Client Credential Failed", err.Error())
+		return
+	}
+
+	data.CredentialID = types.Int64Value(credential.CredentialID)
+	data.ClientToken = types.StringValue(credential.ClientToken)
+	data.ClientSecret = types.StringValue(credential.ClientSecret)
+	data.Description = types.StringValue(credential.Description)
+	data.Status = types.StringValue(string(credential.Status))
+	data.CreatedOn = types.StringValue(credential.CreatedOn.Format(time.RFC3339Nano))
+	data.ExpiresOn = types.StringValue(credential.ExpiresOn.Format(time.RFC3339Nano))
+
+	privateData, err := json.Marshal(apiClientCredentialPrivateData{
+		ClientID:      data.ClientID.ValueString(),
+		CredentialID:  credential.CredentialID,
+		RevokeOnClose: data.RevokeOnClose.IsNull() || data.RevokeOnClose.ValueBool(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Storing IAM API Client Credential Failed", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, apiClientCredentialPrivateKey, privateData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+func (e *apiClientCredentialEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	tflog.Debug(ctx, "IAM API Client Credential Ephemeral Resource Close")
+
+	rawPrivateData, diags := req.Private.GetKey(ctx, apiClientCredentialPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || rawPrivateData == nil {
+		return
+	}
+
+	var privateData apiClientCredentialPrivateData
+	if err := json.Unmarshal(rawPrivateData, &privateData); err != nil {
+		resp.Diagnostics.AddError("Reading IAM API Client Credential Failed", err.Error())
+		return
+	}
+	if !privateData.RevokeOnClose {
+		return
+	}
+
+	client := inst.Client(e.meta)
+	if err := client.DeactivateCredential(ctx, iam.DeactivateCredentialRequest{
+		ClientID:     privateData.ClientID,
+		CredentialID: privateData.CredentialID,
+	}); err != nil {
+		resp.Diagnostics.AddError("Deactivating IAM API Client Credential Failed", err.Error())
+		return
+	}
+
+	if err := client.DeleteCredential(ctx, iam.DeleteCredentialRequest{
+		ClientID:     privateData.ClientID,
+		CredentialID: privateData.CredentialID,
+	}); err != nil {
+		resp.Diagnostics.AddError("Deleting IAM API Client Credential Failed", err.Error())
+	}
+}
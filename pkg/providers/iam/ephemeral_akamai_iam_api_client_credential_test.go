@@ -0,0 +1,107 @@
+package iam
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/iam"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/testutils"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestEphemeralAPIClientCredential(t *testing.T) {
+	createdOn := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	expiresOn := time.Date(2027, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	expectCreateCredential := func(m *iam.Mock) {
+		m.On("CreateCredential", testutils.MockContext, iam.CreateCredentialRequest{
+			ClientID: "abcd1234",
+		}).Return(&iam.CreateCredentialResponse{
+			ClientSecret: "secret",
+			ClientToken:  "akab-token",
+			CreatedOn:    createdOn,
+			CredentialID: 123,
+			Description:  "ephemeral",
+			ExpiresOn:    expiresOn,
+			Status:       iam.CredentialActive,
+		}, nil)
+	}
+
+	checks := []statecheck.StateCheck{
+		statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("credential_id"), knownvalue.Int64Exact(123)),
+		statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("client_token"), knownvalue.StringExact("akab-token")),
+		statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("client_secret"), knownvalue.StringExact("secret")),
+		statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("status"), knownvalue.StringExact("ACTIVE")),
+		statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("created_on"), knownvalue.StringExact("2025-01-15T10:00:00Z")),
+		statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("expires_on"), knownvalue.StringExact("2027-01-15T10:00:00Z")),
+	}
+
+	tests := map[string]struct {
+		init       func(*iam.Mock)
+		configPath string
+		check      []statecheck.StateCheck
+		error      *regexp.Regexp
+	}{
+		"happy path - revoke on close": {
+			init: func(m *iam.Mock) {
+				expectCreateCredential(m)
+				m.On("DeactivateCredential", testutils.MockContext, iam.DeactivateCredentialRequest{
+					ClientID:     "abcd1234",
+					CredentialID: 123,
+				}).Return(nil)
+				m.On("DeleteCredential", testutils.MockContext, iam.DeleteCredentialRequest{
+					ClientID:     "abcd1234",
+					CredentialID: 123,
+				}).Return(nil)
+			},
+			configPath: "testdata/TestEphemeralAPIClientCredential/default.tf",
+			check:      checks,
+		},
+		"happy path - keep on close": {
+			init:       expectCreateCredential,
+			configPath: "testdata/TestEphemeralAPIClientCredential/keep_on_close.tf",
+			check:      checks,
+		},
+		"error creating credential": {
+			init: func(m *iam.Mock) {
+				m.On("CreateCredential", testutils.MockContext, iam.CreateCredentialRequest{
+					ClientID: "abcd1234",
+				}).Return(nil, fmt.Errorf("create credential failed")).Once()
+			},
+			configPath: "testdata/TestEphemeralAPIClientCredential/default.tf",
+			error:      regexp.MustCompile("create credential failed"),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &iam.Mock{}
+			if tc.init != nil {
+				tc.init(client)
+			}
+
+			useClient(client, func() {
+				resource.UnitTest(t, resource.TestCase{
+					ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactoryWithEcho(NewSubprovider()),
+					TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+						tfversion.SkipBelow(tfversion.Version1_10_0),
+					},
+					Steps: []resource.TestStep{
+						{
+							Config:            testutils.LoadFixtureString(t, tc.configPath),
+							ConfigStateChecks: tc.check,
+							ExpectError:       tc.error,
+						},
+					},
+				})
+			})
+			client.AssertExpectations(t)
+		})
+	}
+}
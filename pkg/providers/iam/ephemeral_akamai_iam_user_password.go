@@ -0,0 +1,121 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/iam"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/meta"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ ephemeral.EphemeralResource                   = &userPasswordEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure      = &userPasswordEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithValidateConfig = &userPasswordEphemeralResource{}
+)
+
+type (
+	userPasswordEphemeralResource struct {
+		meta meta.Meta
+	}
+
+	userPasswordModel struct {
+		UIIdentityID types.String `tfsdk:"ui_identity_id"`
+		SendEmail    types.Bool   `tfsdk:"send_email"`
+		ConfirmReset types.Bool   `tfsdk:"confirm_reset"`
+		Password     types.String `tfsdk:"password"`
+	}
+)
+
+// NewUserPasswordEphemeralResource returns a new akamai_iam_user_password ephemeral resource.
+func NewUserPasswordEphemeralResource() ephemeral.EphemeralResource {
+	return &userPasswordEphemeralResource{}
+}
+
+func (e *userPasswordEphemeralResource) Metadata(_ context.Context, _ ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = "akamai_iam_user_password"
+}
+
+func (e *userPasswordEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			resp.Diagnostics.AddError(
+				"Unexpected Ephemeral Resource Configure Type",
+				fmt.Sprintf("Expected meta.Meta, got: %T. Please report this issue to the provider developers.",
+					req.ProviderData))
+		}
+	}()
+	e.meta = meta.Must(req.ProviderData)
+}
+
+func (e *userPasswordEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Identity and Access Management user password. The password is reset every time the ephemeral resource is opened, " +
+			"which Terraform does on every plan and apply, and is never persisted in the state.",
+		Attributes: map[string]schema.Attribute{
+			"ui_identity_id": schema.StringAttribute{
+				Required:    true,
+				Description: "A unique identifier of the user whose password should be reset.",
+			},
+			"send_email": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to also send the user an email with the new password. Defaults to false.",
+			},
+			"confirm_reset": schema.BoolAttribute{
+				Required: true,
+				Description: "Must be set to true to confirm that the password of the user is reset every time Terraform opens " +
+					"the ephemeral resource, that is on every plan and apply.",
+			},
+			"password": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "A newly generated one-time password of the user.",
+			},
+		},
+	}
+}
+
+func (e *userPasswordEphemeralResource) ValidateConfig(ctx context.Context, req ephemeral.ValidateConfigRequest, resp *ephemeral.ValidateConfigResponse) {
+	var data userPasswordModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.ConfirmReset.IsUnknown() && !data.ConfirmReset.ValueBool() {
+		resp.Diagnostics.AddAttributeError(path.Root("confirm_reset"), "Password Reset Not Confirmed",
+			"The password of the user is reset on every plan and apply. Set 'confirm_reset' to true to allow it.")
+	}
+}
+
+func (e *userPasswordEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	tflog.Debug(ctx, "IAM User Password Ephemeral Resource Open")
+
+	var data userPasswordModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := inst.Client(e.meta)
+	password, err := client.ResetUserPassword(ctx, iam.ResetUserPasswordRequest{
+		IdentityID: data.UIIdentityID.ValueString(),
+		SendEmail:  data.SendEmail.ValueBool(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Resetting IAM User Password Failed", err.Error())
+		return
+	}
+
+	data.Password = types.StringValue(password.NewPassword)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
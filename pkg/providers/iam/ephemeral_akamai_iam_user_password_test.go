@@ -0,0 +1,76 @@
+package iam
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/iam"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/testutils"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestEphemeralUserPassword(t *testing.T) {
+	tests := map[string]struct {
+		init       func(*iam.Mock)
+		configPath string
+		check      []statecheck.StateCheck
+		error      *regexp.Regexp
+	}{
+		"happy path": {
+			init: func(m *iam.Mock) {
+				m.On("ResetUserPassword", testutils.MockContext, iam.ResetUserPasswordRequest{
+					IdentityID: "1-ABCDE",
+				}).Return(&iam.ResetUserPasswordResponse{NewPassword: "n3wP@ssw0rd"}, nil)
+			},
+			configPath: "testdata/TestEphemeralUserPassword/default.tf",
+			check: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("ui_identity_id"), knownvalue.StringExact("1-ABCDE")),
+				statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("password"), knownvalue.StringExact("n3wP@ssw0rd")),
+			},
+		},
+		"error resetting password": {
+			init: func(m *iam.Mock) {
+				m.On("ResetUserPassword", testutils.MockContext, iam.ResetUserPasswordRequest{
+					IdentityID: "1-ABCDE",
+				}).Return(nil, fmt.Errorf("reset user password failed")).Once()
+			},
+			configPath: "testdata/TestEphemeralUserPassword/default.tf",
+			error:      regexp.MustCompile("reset user password failed"),
+		},
+		"reset not confirmed": {
+			configPath: "testdata/TestEphemeralUserPassword/not_confirmed.tf",
+			error:      regexp.MustCompile("Password Reset Not Confirmed"),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &iam.Mock{}
+			if tc.init != nil {
+				tc.init(client)
+			}
+
+			useClient(client, func() {
+				resource.UnitTest(t, resource.TestCase{
+					ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactoryWithEcho(NewSubprovider()),
+					TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+						tfversion.SkipBelow(tfversion.Version1_10_0),
+					},
+					Steps: []resource.TestStep{
+						{
+							Config:            testutils.LoadFixtureString(t, tc.configPath),
+							ConfigStateChecks: tc.check,
+							ExpectError:       tc.error,
+						},
+					},
+				})
+			})
+			client.AssertExpectations(t)
+		})
+	}
+}
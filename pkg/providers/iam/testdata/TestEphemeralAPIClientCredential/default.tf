@@ -0,0 +1,13 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+ephemeral "akamai_iam_api_client_credential" "test" {
+  client_id = "abcd1234"
+}
+
+provider "echo" {
+  data = ephemeral.akamai_iam_api_client_credential.test
+}
+
+resource "echo" "test" {}
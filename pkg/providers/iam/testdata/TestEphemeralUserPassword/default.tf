@@ -0,0 +1,14 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+ephemeral "akamai_iam_user_password" "test" {
+  ui_identity_id = "1-ABCDE"
+  confirm_reset  = true
+}
+
+provider "echo" {
+  data = ephemeral.akamai_iam_user_password.test
+}
+
+resource "echo" "test" {}
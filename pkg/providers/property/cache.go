@@ -0,0 +1,67 @@
+package property
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/papi"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/cache"
+	akameta "github.com/akamai/terraform-provider-akamai/v7/pkg/meta"
+)
+
+// Groups, contracts and products do not change during terraform operations, while the data sources
+// reading them are usually repeated for every property in a configuration. Reading them through the cache
+// limits the number of identical requests to one per provider process, or one per cacheTTL when the file cache is enabled.
+// CP codes are created by terraform, so they are kept in memory of a single provider process only.
+
+// cacheTTL is the time after which the cached groups, contracts and products expire
+const cacheTTL = time.Hour
+
+var cacheBucket = cache.BucketName(SubproviderName)
+
+// accountCacheBucket returns the bucket of read-mostly entries specific to the account of meta
+func accountCacheBucket(meta akameta.Meta) cache.Bucket {
+	return cache.WithTTL(cache.AccountBucket(SubproviderName, meta.AccountKey()), cacheTTL)
+}
+
+// getGroupsCached returns the groups available to the account of meta, reading them from cache if possible
+func getGroupsCached(ctx context.Context, meta akameta.Meta) (*papi.GetGroupsResponse, error) {
+	return cache.GetOrFetch(accountCacheBucket(meta), "GetGroups", func() (*papi.GetGroupsResponse, error) {
+		return Client(meta).GetGroups(ctx)
+	})
+}
+
+// getContractsCached returns the contracts available to the account of meta, reading them from cache if possible
+func getContractsCached(ctx context.Context, meta akameta.Meta) (*papi.GetContractsResponse, error) {
+	return cache.GetOrFetch(accountCacheBucket(meta), "GetContracts", func() (*papi.GetContractsResponse, error) {
+		return Client(meta).GetContracts(ctx)
+	})
+}
+
+// getProductsCached returns the products available on the given contract, reading them from cache if possible
+func getProductsCached(ctx context.Context, client papi.PAPI, contractID string) (*papi.GetProductsResponse, error) {
+	return cache.GetOrFetch(cache.WithTTL(cacheBucket, cacheTTL), fmt.Sprintf("GetProducts:%s", contractID), func() (*papi.GetProductsResponse, error) {
+		return client.GetProducts(ctx, papi.GetProductsRequest{ContractID: contractID})
+	})
+}
+
+// getCPCodesCached returns the CP codes of the given contract and group, reading them from cache if possible.
+// Operations changing CP codes have to call invalidateCPCodesCache.
+func getCPCodesCached(ctx context.Context, client papi.PAPI, contractID, groupID string) (*papi.GetCPCodesResponse, error) {
+	return cache.GetOrFetch(cacheBucket, cpCodesCacheKey(contractID, groupID), func() (*papi.GetCPCodesResponse, error) {
+		return client.GetCPCodes(ctx, papi.GetCPCodesRequest{
+			ContractID: contractID,
+			GroupID:    groupID,
+		})
+	})
+}
+
+// invalidateCPCodesCache removes the cached CP codes of the given contract and group
+func invalidateCPCodesCache(contractID, groupID string) {
+	cache.Delete(cacheBucket, cpCodesCacheKey(contractID, groupID))
+}
+
+func cpCodesCacheKey(contractID, groupID string) string {
+	return fmt.Sprintf("GetCPCodes:%s:%s", contractID, groupID)
+}
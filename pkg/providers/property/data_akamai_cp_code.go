@@ -88,10 +88,7 @@ func dataCPCodeRead(ctx context.Context, d *schema.ResourceData, m interface{})
 
 // findCPCode searches all CP codes for a match against given nameOrID
 func findCPCode(ctx context.Context, client papi.PAPI, nameOrID, contractID, groupID string) (*papi.CPCode, error) {
-	r, err := client.GetCPCodes(ctx, papi.GetCPCodesRequest{
-		ContractID: contractID,
-		GroupID:    groupID,
-	})
+	r, err := getCPCodesCached(ctx, client, contractID, groupID)
 
 	if err != nil {
 		return nil, err
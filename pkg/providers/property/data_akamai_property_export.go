@@ -0,0 +1,563 @@
+package property
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/hapi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/log"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/papi"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/str"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/providers/property/ruleformats"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	// exportRulesAsJSON renders the rule tree of the exported property as JSON in the rules attribute of akamai_property
+	exportRulesAsJSON = "json"
+	// exportRulesAsRulesBuilder renders the rule tree of the exported property as akamai_property_rules_builder data sources
+	exportRulesAsRulesBuilder = "rules_builder"
+)
+
+type (
+	// propertyExport holds the data the property export template is rendered with
+	propertyExport struct {
+		Name          string
+		PropertyName  string
+		ContractID    string
+		GroupID       string
+		ProductID     string
+		RuleFormat    string
+		ImportID      string
+		Hostnames     []exportedHostname
+		Rules         string
+		RulesBuilder  []string
+		EdgeHostnames []exportedEdgeHostname
+		CPCodes       []exportedCPCode
+		Activations   []exportedActivation
+	}
+
+	exportedHostname struct {
+		CnameFrom            string
+		CnameTo              string
+		CertProvisioningType string
+	}
+
+	exportedEdgeHostname struct {
+		ID           string
+		Name         string
+		ImportID     string
+		EdgeHostname string
+		ContractID   string
+		GroupID      string
+		ProductID    string
+		IPBehavior   string
+		Certificate  int64
+		TTL          int
+		UseCases     string
+	}
+
+	exportedCPCode struct {
+		Name       string
+		ImportID   string
+		CPCodeName string
+		ContractID string
+		GroupID    string
+		ProductID  string
+	}
+
+	exportedActivation struct {
+		Name     string
+		ImportID string
+		Network  string
+		Version  int
+		Contacts []string
+		Note     string
+	}
+)
+
+var propertyExportTemplate = template.Must(template.New("property_export").Funcs(template.FuncMap{
+	"quote": tf.QuoteHCL,
+	"quoteList": func(values []string) string {
+		quoted := make([]string, 0, len(values))
+		for _, v := range values {
+			quoted = append(quoted, tf.QuoteHCL(v))
+		}
+		return "[" + strings.Join(quoted, ", ") + "]"
+	},
+}).Parse(`
+{{- range .CPCodes}}
+resource "akamai_cp_code" "{{.Name}}" {
+  name        = {{quote .CPCodeName}}
+  contract_id = {{quote .ContractID}}
+  group_id    = {{quote .GroupID}}
+  product_id  = {{quote .ProductID}}
+}
+
+import {
+  to = akamai_cp_code.{{.Name}}
+  id = {{quote .ImportID}}
+}
+{{end}}
+{{- range .EdgeHostnames}}
+resource "akamai_edge_hostname" "{{.Name}}" {
+  contract_id   = {{quote .ContractID}}
+  group_id      = {{quote .GroupID}}
+  product_id    = {{quote .ProductID}}
+  edge_hostname = {{quote .EdgeHostname}}
+  ip_behavior   = {{quote .IPBehavior}}
+{{- if .Certificate}}
+  certificate   = {{.Certificate}}
+{{- end}}
+{{- if .TTL}}
+  ttl           = {{.TTL}}
+{{- end}}
+{{- if .UseCases}}
+  use_cases     = {{quote .UseCases}}
+{{- end}}
+}
+
+import {
+  to = akamai_edge_hostname.{{.Name}}
+  id = {{quote .ImportID}}
+}
+{{end}}
+{{- range .RulesBuilder}}
+{{.}}{{end}}
+resource "akamai_property" "{{.Name}}" {
+  name        = {{quote .PropertyName}}
+  contract_id = {{quote .ContractID}}
+  group_id    = {{quote .GroupID}}
+  product_id  = {{quote .ProductID}}
+  rule_format = {{quote .RuleFormat}}
+{{- range .Hostnames}}
+  hostnames {
+    cname_from             = {{quote .CnameFrom}}
+    cname_to               = {{.CnameTo}}
+    cert_provisioning_type = {{quote .CertProvisioningType}}
+  }
+{{- end}}
+  rules = {{.Rules}}
+}
+
+import {
+  to = akamai_property.{{.Name}}
+  id = {{quote .ImportID}}
+}
+{{- range .Activations}}
+
+resource "akamai_property_activation" "{{.Name}}" {
+  property_id = akamai_property.{{$.Name}}.id
+  contact     = {{quoteList .Contacts}}
+  version     = {{.Version}}
+  network     = {{quote .Network}}
+{{- if .Note}}
+  note        = {{quote .Note}}
+{{- end}}
+}
+
+import {
+  to = akamai_property_activation.{{.Name}}
+  id = {{quote .ImportID}}
+}
+{{- end}}
+`))
+
+func dataSourcePropertyExport() *schema.Resource {
+	return &schema.Resource{
+		Description: "Renders an existing property version as HCL of akamai_property, akamai_edge_hostname, akamai_cp_code " +
+			"and akamai_property_activation resources, with import blocks to bring them under Terraform management.",
+		ReadContext: dataPropertyExportRead,
+		Schema: map[string]*schema.Schema{
+			"property_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: tf.IsNotBlank,
+				Description:      "The ID of the property to export",
+			},
+			"contract_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The contract ID of the property. It is looked up by default",
+			},
+			"group_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The group ID of the property. It is looked up by default",
+			},
+			"version": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The property version to export. The latest version is exported by default",
+			},
+			"rules_as": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          exportRulesAsJSON,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{exportRulesAsJSON, exportRulesAsRulesBuilder}, false)),
+				Description: "How the rule tree is rendered: 'json' renders it as JSON in the rules attribute of akamai_property, " +
+					"'rules_builder' renders it as akamai_property_rules_builder data sources, one for each rule. " +
+					"'rules_builder' requires a rule format supported by akamai_property_rules_builder",
+			},
+			"rule_format": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The rule format of the exported rule tree",
+			},
+			"output_text": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The HCL of the exported resources and their import blocks",
+			},
+		},
+	}
+}
+
+func dataPropertyExportRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	ctx = log.NewContext(ctx, meta.Log("PAPI", "dataPropertyExportRead"))
+	logger := log.FromContext(ctx)
+	client := Client(meta)
+
+	propertyID := str.AddPrefix(d.Get("property_id").(string), "prp_")
+	var contractID, groupID string
+	if v, ok := d.GetOk("contract_id"); ok {
+		contractID = str.AddPrefix(v.(string), "ctr_")
+	}
+	if v, ok := d.GetOk("group_id"); ok {
+		groupID = str.AddPrefix(v.(string), "grp_")
+	}
+	rulesAs := d.Get("rules_as").(string)
+
+	property, err := fetchLatestProperty(ctx, client, propertyID, groupID, contractID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	version := d.Get("version").(int)
+	if version == 0 {
+		version = property.LatestVersion
+	}
+
+	logger.Debugf("exporting version %d of property %s", version, propertyID)
+	export, err := newPropertyExport(ctx, meta, property, version, rulesAs)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var output bytes.Buffer
+	if err := propertyExportTemplate.Execute(&output, export); err != nil {
+		return diag.Errorf("rendering property export: %s", err)
+	}
+
+	attrs := map[string]interface{}{
+		"contract_id": property.ContractID,
+		"group_id":    property.GroupID,
+		"version":     version,
+		"rule_format": export.RuleFormat,
+		"output_text": strings.TrimLeft(output.String(), "\n"),
+	}
+	if err := tf.SetAttrs(d, attrs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%d:%s", property.PropertyID, version, rulesAs))
+	return nil
+}
+
+// newPropertyExport fetches the property version, its rule tree, hostnames, edge hostnames, CP codes and activations
+func newPropertyExport(ctx context.Context, meta meta.Meta, property *papi.Property, version int, rulesAs string) (*propertyExport, error) {
+	client := Client(meta)
+
+	versionResponse, err := fetchPropertyVersion(ctx, client, property.PropertyID, property.GroupID, property.ContractID, version)
+	if err != nil {
+		return nil, err
+	}
+	export := &propertyExport{
+		Name:         tf.BlockName(property.PropertyName),
+		PropertyName: property.PropertyName,
+		ContractID:   property.ContractID,
+		GroupID:      property.GroupID,
+		ProductID:    versionResponse.Version.ProductID,
+		ImportID:     strings.Join([]string{property.PropertyID, property.ContractID, property.GroupID}, ","),
+	}
+	if version != property.LatestVersion {
+		export.ImportID += "," + strconv.Itoa(version)
+	}
+
+	rules, ruleFormat, _, _, err := fetchPropertyVersionRules(ctx, client, *property, version)
+	if err != nil {
+		return nil, err
+	}
+	export.RuleFormat = ruleFormat
+	if err := export.setRules(rules, rulesAs); err != nil {
+		return nil, err
+	}
+
+	cpCodes, err := exportCPCodes(ctx, client, property, export.ProductID, rules.Rules)
+	if err != nil {
+		return nil, err
+	}
+	export.CPCodes = cpCodes
+
+	hostnames, err := fetchPropertyVersionHostnames(ctx, client, *property, version)
+	if err != nil {
+		return nil, err
+	}
+	edgeHostnames, err := exportEdgeHostnames(ctx, meta, property, hostnames)
+	if err != nil {
+		return nil, err
+	}
+	export.EdgeHostnames = edgeHostnames
+	for _, h := range hostnames {
+		// hostnames refer to the exported edge hostnames, so that they are created first
+		cnameTo := tf.QuoteHCL(h.CnameTo)
+		for _, e := range edgeHostnames {
+			if e.ID == h.EdgeHostnameID {
+				cnameTo = fmt.Sprintf("akamai_edge_hostname.%s.edge_hostname", e.Name)
+			}
+		}
+		export.Hostnames = append(export.Hostnames, exportedHostname{
+			CnameFrom:            h.CnameFrom,
+			CnameTo:              cnameTo,
+			CertProvisioningType: h.CertProvisioningType,
+		})
+	}
+
+	activations, err := exportActivations(ctx, client, property, export.Name)
+	if err != nil {
+		return nil, err
+	}
+	export.Activations = activations
+
+	return export, nil
+}
+
+// setRules sets the expression of the rules attribute of akamai_property, and the rules builder data sources if needed
+func (e *propertyExport) setRules(rules papi.RulesUpdate, rulesAs string) error {
+	if rulesAs == exportRulesAsRulesBuilder {
+		blocks, err := ruleformats.RulesBuilderHCL(e.RuleFormat, e.Name, rules.Rules)
+		if errors.Is(err, ruleformats.ErrNotFound) {
+			return fmt.Errorf("rule format %q of the property is not supported by akamai_property_rules_builder: "+
+				"convert the rule tree to a supported rule format with akamai_property_rules_upgrade, or export the rules as %q", e.RuleFormat, exportRulesAsJSON)
+		}
+		if err != nil {
+			return fmt.Errorf("the rule tree cannot be rendered as akamai_property_rules_builder data sources, export the rules as %q:\n%w", exportRulesAsJSON, err)
+		}
+		e.RulesBuilder = blocks
+		e.Rules = fmt.Sprintf("data.akamai_property_rules_builder.%s.json", e.Name)
+		return nil
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("    ", "  ")
+	if err := enc.Encode(rules); err != nil {
+		return fmt.Errorf("invalid JSON result: %w", err)
+	}
+	// the rule tree is rendered as a heredoc, in which template sequences have to be escaped
+	escaped := strings.NewReplacer("${", "$${", "%{", "%%{").Replace(strings.TrimSpace(buf.String()))
+	e.Rules = fmt.Sprintf("<<-EOT\n    %s\n  EOT", escaped)
+	return nil
+}
+
+// exportCPCodes returns the CP codes used by the cpCode behaviors of the rule tree
+func exportCPCodes(ctx context.Context, client papi.PAPI, property *papi.Property, productID string, rules papi.Rules) ([]exportedCPCode, error) {
+	var cpCodes []exportedCPCode
+	names := map[string]bool{}
+	for _, id := range ruleCPCodeIDs(rules, nil) {
+		cpCodeID := str.AddPrefix(strconv.Itoa(id), "cpc_")
+		res, err := client.GetCPCode(ctx, papi.GetCPCodeRequest{
+			CPCodeID:   cpCodeID,
+			ContractID: property.ContractID,
+			GroupID:    property.GroupID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not fetch CP code %s: %w", cpCodeID, err)
+		}
+		cpCodeProductID := productID
+		if len(res.CPCode.ProductIDs) > 0 {
+			cpCodeProductID = res.CPCode.ProductIDs[0]
+		}
+		cpCodes = append(cpCodes, exportedCPCode{
+			Name:       uniqueBlockName(names, res.CPCode.Name),
+			ImportID:   strings.Join([]string{res.CPCode.ID, property.ContractID, property.GroupID}, ","),
+			CPCodeName: res.CPCode.Name,
+			ContractID: property.ContractID,
+			GroupID:    property.GroupID,
+			ProductID:  cpCodeProductID,
+		})
+	}
+	return cpCodes, nil
+}
+
+// ruleCPCodeIDs returns the IDs of the CP codes used by the cpCode behaviors of the rule and its children,
+// in the order of their first use
+func ruleCPCodeIDs(rule papi.Rules, ids []int) []int {
+	for _, behavior := range rule.Behaviors {
+		if behavior.Name != "cpCode" {
+			continue
+		}
+		value, ok := behavior.Options["value"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id, ok := value["id"].(float64)
+		if !ok {
+			continue
+		}
+		if !slices.Contains(ids, int(id)) {
+			ids = append(ids, int(id))
+		}
+	}
+	for _, child := range rule.Children {
+		ids = ruleCPCodeIDs(child, ids)
+	}
+	return ids
+}
+
+// exportEdgeHostnames returns the edge hostnames the hostnames of the property version point to
+func exportEdgeHostnames(ctx context.Context, meta meta.Meta, property *papi.Property, hostnames []papi.Hostname) ([]exportedEdgeHostname, error) {
+	client := Client(meta)
+	hapiClient := HapiClient(meta)
+	names := map[string]bool{}
+
+	edgeHostnameIDs := map[string]bool{}
+	for _, h := range hostnames {
+		if h.EdgeHostnameID != "" {
+			edgeHostnameIDs[h.EdgeHostnameID] = true
+		}
+	}
+	if len(edgeHostnameIDs) == 0 {
+		return nil, nil
+	}
+
+	res, err := client.GetEdgeHostnames(ctx, papi.GetEdgeHostnamesRequest{
+		ContractID: property.ContractID,
+		GroupID:    property.GroupID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch edge hostnames: %w", err)
+	}
+
+	var edgeHostnames []exportedEdgeHostname
+	for _, item := range res.EdgeHostnames.Items {
+		if !edgeHostnameIDs[item.ID] {
+			continue
+		}
+		edgeHostname := exportedEdgeHostname{
+			ID:           item.ID,
+			Name:         uniqueBlockName(names, item.Domain),
+			ImportID:     strings.Join([]string{item.ID, property.ContractID, property.GroupID}, ","),
+			EdgeHostname: item.Domain,
+			ContractID:   property.ContractID,
+			GroupID:      property.GroupID,
+			ProductID:    item.ProductID,
+			IPBehavior:   item.IPVersionBehavior,
+		}
+		if len(item.UseCases) > 0 {
+			useCases, err := json.Marshal(item.UseCases)
+			if err != nil {
+				return nil, err
+			}
+			edgeHostname.UseCases = string(useCases)
+		}
+
+		// the certificate and TTL are only available in HAPI, the same way they are imported
+		id, err := str.GetIntID(item.ID, "ehn_")
+		if err != nil {
+			return nil, err
+		}
+		hapiEdgeHostname, err := hapiClient.GetEdgeHostname(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("error getting edge hostname with id '%d': %w", id, err)
+		}
+		if !hapiEdgeHostname.UseDefaultTTL {
+			edgeHostname.TTL = hapiEdgeHostname.TTL
+		}
+		if hapiEdgeHostname.SecurityType == "ENHANCED-TLS" {
+			certificate, err := hapiClient.GetCertificate(ctx, hapi.GetCertificateRequest{
+				DNSZone:    hapiEdgeHostname.DNSZone,
+				RecordName: hapiEdgeHostname.RecordName,
+			})
+			if err != nil && !errors.Is(err, hapi.ErrNotFound) {
+				return nil, err
+			}
+			if err == nil {
+				if edgeHostname.Certificate, err = strconv.ParseInt(certificate.CertificateID, 10, 64); err != nil {
+					return nil, err
+				}
+			}
+		}
+		edgeHostnames = append(edgeHostnames, edgeHostname)
+	}
+	return edgeHostnames, nil
+}
+
+// exportActivations returns the activations of the versions of the property which are active on staging and production
+func exportActivations(ctx context.Context, client papi.PAPI, property *papi.Property, propertyName string) ([]exportedActivation, error) {
+	activeVersions := map[papi.ActivationNetwork]*int{
+		papi.ActivationNetworkStaging:    property.StagingVersion,
+		papi.ActivationNetworkProduction: property.ProductionVersion,
+	}
+	if property.StagingVersion == nil && property.ProductionVersion == nil {
+		return nil, nil
+	}
+
+	res, err := client.GetActivations(ctx, papi.GetActivationsRequest{
+		PropertyID: property.PropertyID,
+		ContractID: property.ContractID,
+		GroupID:    property.GroupID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch activations: %w", err)
+	}
+
+	var activations []exportedActivation
+	for _, network := range []papi.ActivationNetwork{papi.ActivationNetworkStaging, papi.ActivationNetworkProduction} {
+		version := activeVersions[network]
+		if version == nil {
+			continue
+		}
+		for _, act := range res.Activations.Items {
+			if act.Network != network || act.PropertyVersion != *version || act.ActivationType != papi.ActivationTypeActivate ||
+				act.Status != papi.ActivationStatusActive {
+				continue
+			}
+			activations = append(activations, exportedActivation{
+				Name:     fmt.Sprintf("%s_%s", propertyName, strings.ToLower(string(network))),
+				ImportID: fmt.Sprintf("%s:%s", property.PropertyID, network),
+				Network:  string(network),
+				Version:  act.PropertyVersion,
+				Contacts: act.NotifyEmails,
+				Note:     act.Note,
+			})
+			break
+		}
+	}
+	return activations, nil
+}
+
+// uniqueBlockName returns a name of a resource block for the object, which is not used by another exported resource
+func uniqueBlockName(names map[string]bool, objectName string) string {
+	name := tf.BlockName(objectName)
+	for i := 2; names[name]; i++ {
+		name = fmt.Sprintf("%s_%d", tf.BlockName(objectName), i)
+	}
+	names[name] = true
+	return name
+}
@@ -0,0 +1,262 @@
+package property
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/hapi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/papi"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/ptr"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/testutils"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDSPropertyExport(t *testing.T) {
+	mockRules := func(client *papi.Mock, ruleFormat string) {
+		client.On("GetProperty", testutils.MockContext, papi.GetPropertyRequest{
+			PropertyID: "prp_1",
+		}).Return(&papi.GetPropertyResponse{
+			Property: &papi.Property{
+				PropertyID:        "prp_1",
+				PropertyName:      "www.example.com",
+				ContractID:        "ctr_1",
+				GroupID:           "grp_1",
+				LatestVersion:     3,
+				StagingVersion:    ptr.To(3),
+				ProductionVersion: ptr.To(2),
+			},
+		}, nil)
+		client.On("GetPropertyVersion", testutils.MockContext, papi.GetPropertyVersionRequest{
+			PropertyID:      "prp_1",
+			PropertyVersion: 3,
+			ContractID:      "ctr_1",
+			GroupID:         "grp_1",
+		}).Return(&papi.GetPropertyVersionsResponse{
+			PropertyID: "prp_1",
+			Version:    papi.PropertyVersionGetItem{PropertyVersion: 3, ProductID: "prd_Fresca", RuleFormat: ruleFormat},
+		}, nil)
+		client.On("GetRuleTree", testutils.MockContext, papi.GetRuleTreeRequest{
+			PropertyID:      "prp_1",
+			PropertyVersion: 3,
+			ContractID:      "ctr_1",
+			GroupID:         "grp_1",
+			ValidateRules:   true,
+			ValidateMode:    papi.RuleValidateModeFull,
+		}).Return(&papi.GetRuleTreeResponse{
+			PropertyID:      "prp_1",
+			PropertyVersion: 3,
+			RuleFormat:      ruleFormat,
+			Rules: papi.Rules{
+				Name:    "default",
+				Options: papi.RuleOptions{IsSecure: true},
+				Variables: []papi.RuleVariable{{
+					Name:        "PMUSER_ORIGIN",
+					Value:       ptr.To("origin.example.com"),
+					Description: ptr.To(""),
+				}},
+				Behaviors: []papi.RuleBehavior{
+					{
+						Name: "origin",
+						Options: papi.RuleOptionsMap{
+							"originType":       "CUSTOMER",
+							"hostname":         "{{user.PMUSER_ORIGIN}}",
+							"httpPort":         float64(80),
+							"originSni":        true,
+							"cacheKeyHostname": "ORIGIN_HOSTNAME",
+						},
+					},
+					{
+						Name: "cpCode",
+						Options: papi.RuleOptionsMap{
+							"value": map[string]interface{}{"id": float64(123), "name": "www", "products": []interface{}{"Fresca"}},
+						},
+					},
+				},
+				Children: []papi.Rules{{
+					Name:                "Static Content",
+					CriteriaMustSatisfy: papi.RuleCriteriaMustSatisfyAll,
+					Comments:            "Cache ${static} content",
+					Criteria: []papi.RuleBehavior{{
+						Name:    "fileExtension",
+						Options: papi.RuleOptionsMap{"matchOperator": "IS_ONE_OF", "values": []interface{}{"css", "js"}},
+					}},
+					Behaviors: []papi.RuleBehavior{
+						{Name: "caching", Options: papi.RuleOptionsMap{"behavior": "MAX_AGE", "mustRevalidate": false, "ttl": "1d"}},
+						{Name: "cpCode", Options: papi.RuleOptionsMap{"value": map[string]interface{}{"id": float64(456)}}},
+					},
+				}},
+			},
+		}, nil)
+	}
+	mockResources := func(client *papi.Mock, hapiClient *hapi.Mock) {
+		client.On("GetCPCode", testutils.MockContext, papi.GetCPCodeRequest{
+			CPCodeID:   "cpc_123",
+			ContractID: "ctr_1",
+			GroupID:    "grp_1",
+		}).Return(&papi.GetCPCodesResponse{
+			CPCode: papi.CPCode{ID: "cpc_123", Name: "www", ProductIDs: []string{"prd_Fresca"}},
+		}, nil)
+		client.On("GetCPCode", testutils.MockContext, papi.GetCPCodeRequest{
+			CPCodeID:   "cpc_456",
+			ContractID: "ctr_1",
+			GroupID:    "grp_1",
+		}).Return(&papi.GetCPCodesResponse{
+			CPCode: papi.CPCode{ID: "cpc_456", Name: "static assets"},
+		}, nil)
+		client.On("GetPropertyVersionHostnames", testutils.MockContext, papi.GetPropertyVersionHostnamesRequest{
+			PropertyID:        "prp_1",
+			PropertyVersion:   3,
+			ContractID:        "ctr_1",
+			GroupID:           "grp_1",
+			IncludeCertStatus: true,
+		}).Return(&papi.GetPropertyVersionHostnamesResponse{
+			Hostnames: papi.HostnameResponseItems{Items: []papi.Hostname{
+				{
+					CnameType:            papi.HostnameCnameTypeEdgeHostname,
+					EdgeHostnameID:       "ehn_1",
+					CnameFrom:            "www.example.com",
+					CnameTo:              "www.example.com.edgekey.net",
+					CertProvisioningType: "CPS_MANAGED",
+				},
+				{
+					CnameType:            papi.HostnameCnameTypeEdgeHostname,
+					EdgeHostnameID:       "ehn_2",
+					CnameFrom:            "static.example.com",
+					CnameTo:              "static.example.com.edgesuite.net",
+					CertProvisioningType: "DEFAULT",
+				},
+			}},
+		}, nil)
+		client.On("GetEdgeHostnames", testutils.MockContext, papi.GetEdgeHostnamesRequest{
+			ContractID: "ctr_1",
+			GroupID:    "grp_1",
+		}).Return(&papi.GetEdgeHostnamesResponse{
+			EdgeHostnames: papi.EdgeHostnameItems{Items: []papi.EdgeHostnameGetItem{
+				{ID: "ehn_1", Domain: "www.example.com.edgekey.net", ProductID: "prd_Fresca", IPVersionBehavior: "IPV6_COMPLIANCE"},
+				{ID: "ehn_2", Domain: "static.example.com.edgesuite.net", ProductID: "prd_Fresca", IPVersionBehavior: "IPV4",
+					UseCases: []papi.UseCase{{Option: "BACKGROUND", Type: "GLOBAL", UseCase: "Download_Mode"}}},
+				{ID: "ehn_3", Domain: "other.example.com.edgesuite.net", ProductID: "prd_Fresca", IPVersionBehavior: "IPV4"},
+			}},
+		}, nil)
+		hapiClient.On("GetEdgeHostname", testutils.MockContext, 1).Return(&hapi.GetEdgeHostnameResponse{
+			EdgeHostnameID: 1,
+			RecordName:     "www.example.com",
+			DNSZone:        "edgekey.net",
+			SecurityType:   "ENHANCED-TLS",
+			UseDefaultTTL:  true,
+		}, nil)
+		hapiClient.On("GetCertificate", testutils.MockContext, hapi.GetCertificateRequest{
+			RecordName: "www.example.com",
+			DNSZone:    "edgekey.net",
+		}).Return(&hapi.GetCertificateResponse{CertificateID: "12345"}, nil)
+		hapiClient.On("GetEdgeHostname", testutils.MockContext, 2).Return(&hapi.GetEdgeHostnameResponse{
+			EdgeHostnameID: 2,
+			RecordName:     "static.example.com",
+			DNSZone:        "edgesuite.net",
+			SecurityType:   "STANDARD-TLS",
+			TTL:            300,
+		}, nil)
+		client.On("GetActivations", testutils.MockContext, papi.GetActivationsRequest{
+			PropertyID: "prp_1",
+			ContractID: "ctr_1",
+			GroupID:    "grp_1",
+		}).Return(&papi.GetActivationsResponse{
+			Activations: papi.ActivationsItems{Items: []*papi.Activation{
+				{PropertyVersion: 3, Network: papi.ActivationNetworkStaging, ActivationType: papi.ActivationTypeActivate,
+					Status: papi.ActivationStatusActive, NotifyEmails: []string{"user@example.com"}, Note: "static content"},
+				{PropertyVersion: 2, Network: papi.ActivationNetworkProduction, ActivationType: papi.ActivationTypeActivate,
+					Status: papi.ActivationStatusActive, NotifyEmails: []string{"user@example.com", "ops@example.com"}},
+				{PropertyVersion: 1, Network: papi.ActivationNetworkProduction, ActivationType: papi.ActivationTypeActivate,
+					Status: papi.ActivationStatusInactive, NotifyEmails: []string{"old@example.com"}},
+			}},
+		}, nil)
+	}
+
+	tests := map[string]struct {
+		init           func(*papi.Mock, *hapi.Mock)
+		configPath     string
+		expectedID     string
+		expectedOutput string
+		expectError    *regexp.Regexp
+	}{
+		"export with rules as JSON": {
+			init: func(client *papi.Mock, hapiClient *hapi.Mock) {
+				mockRules(client, "v2025-01-13")
+				mockResources(client, hapiClient)
+			},
+			configPath:     "testdata/TestDSPropertyExport/export_json.tf",
+			expectedID:     "prp_1:3:json",
+			expectedOutput: "testdata/TestDSPropertyExport/export_json.txt",
+		},
+		"export with rules builder": {
+			init: func(client *papi.Mock, hapiClient *hapi.Mock) {
+				mockRules(client, "v2025-01-13")
+				mockResources(client, hapiClient)
+			},
+			configPath:     "testdata/TestDSPropertyExport/export_rules_builder.tf",
+			expectedID:     "prp_1:3:rules_builder",
+			expectedOutput: "testdata/TestDSPropertyExport/export_rules_builder.txt",
+		},
+		"rule format not supported by rules builder": {
+			init: func(client *papi.Mock, _ *hapi.Mock) {
+				mockRules(client, "v2020-03-04")
+			},
+			configPath:  "testdata/TestDSPropertyExport/export_rules_builder.tf",
+			expectError: regexp.MustCompile(`rule format "v2020-03-04" of the property is not supported by\s+akamai_property_rules_builder`),
+		},
+		"invalid rules_as": {
+			init:        func(_ *papi.Mock, _ *hapi.Mock) {},
+			configPath:  "testdata/TestDSPropertyExport/invalid_rules_as.tf",
+			expectError: regexp.MustCompile(`expected rules_as to be one of \["json" "rules_builder"\], got yaml`),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &papi.Mock{}
+			hapiClient := &hapi.Mock{}
+			test.init(client, hapiClient)
+
+			var check resource.TestCheckFunc
+			if test.expectedOutput != "" {
+				check = resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.akamai_property_export.test", "id", test.expectedID),
+					resource.TestCheckResourceAttr("data.akamai_property_export.test", "contract_id", "ctr_1"),
+					resource.TestCheckResourceAttr("data.akamai_property_export.test", "group_id", "grp_1"),
+					resource.TestCheckResourceAttr("data.akamai_property_export.test", "version", "3"),
+					resource.TestCheckResourceAttr("data.akamai_property_export.test", "rule_format", "v2025-01-13"),
+					resource.TestCheckResourceAttr("data.akamai_property_export.test", "output_text", testutils.LoadFixtureString(t, test.expectedOutput)),
+				)
+			}
+
+			useClient(client, hapiClient, func() {
+				resource.UnitTest(t, resource.TestCase{
+					ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+					Steps: []resource.TestStep{{
+						Config:      testutils.LoadFixtureString(t, test.configPath),
+						Check:       check,
+						ExpectError: test.expectError,
+					}},
+				})
+			})
+			client.AssertExpectations(t)
+			hapiClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRuleCPCodeIDs(t *testing.T) {
+	rules := papi.Rules{
+		Behaviors: []papi.RuleBehavior{
+			{Name: "cpCode", Options: papi.RuleOptionsMap{"value": map[string]interface{}{"id": float64(2)}}},
+			{Name: "caching", Options: papi.RuleOptionsMap{"behavior": "NO_STORE"}},
+		},
+		Children: []papi.Rules{
+			{Behaviors: []papi.RuleBehavior{{Name: "cpCode", Options: papi.RuleOptionsMap{"value": map[string]interface{}{"id": float64(1)}}}}},
+			{Behaviors: []papi.RuleBehavior{{Name: "cpCode", Options: papi.RuleOptionsMap{"value": map[string]interface{}{"id": float64(2)}}}}},
+			{Behaviors: []papi.RuleBehavior{{Name: "cpCode", Options: papi.RuleOptionsMap{}}}},
+		},
+	}
+	assert.Equal(t, []int{2, 1}, ruleCPCodeIDs(rules, nil))
+}
@@ -0,0 +1,389 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/log"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/str"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/providers/property/ruleformats"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourcePropertyRulesUpgrade() *schema.Resource {
+	return &schema.Resource{
+		Description: "Converts a rule tree to a newer rule format using PAPI, and reports the options which were renamed, " +
+			"removed or added by the conversion, and the options the new rule format requires.",
+		ReadContext: dataPropertyRulesUpgradeRead,
+		Schema: map[string]*schema.Schema{
+			"property_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: tf.IsNotBlank,
+				Description:      "The ID of the property the rule tree is validated against",
+			},
+			"contract_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: tf.IsNotBlank,
+				Description:      "The contract ID of the property",
+			},
+			"group_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: tf.IsNotBlank,
+				Description:      "The group ID of the property",
+			},
+			"version": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The property version the rule tree is validated against. The latest version is used by default",
+			},
+			"rules": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsJSON),
+				Description:      "The rule tree to convert as JSON",
+			},
+			"rule_format": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: tf.ValidateRuleFormat,
+				Description:      "The rule format of the rule tree",
+			},
+			"target_rule_format": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateUpgradeRuleFormat,
+				Description:      "The rule format to convert the rule tree to. It has to be one of the rule formats supported by akamai_property_rules_builder",
+			},
+			"converted_rules": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The rule tree converted to the target rule format as JSON",
+			},
+			"renamed_options": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        ruleOptionChange(),
+				Description: "The options which were renamed by the conversion",
+			},
+			"removed_options": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        ruleOptionChange(),
+				Description: "The options, behaviors and criteria which were removed by the conversion",
+			},
+			"added_options": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        ruleOptionChange(),
+				Description: "The options, behaviors and criteria which were added by the conversion",
+			},
+			"required_options": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        ruleOptionChange(),
+				Description: "The options which are required by the target rule format, but are missing in the converted rule tree",
+			},
+			"validation_errors": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The behaviors, criteria and options of the converted rule tree which do not conform to the schema of the target rule format",
+			},
+			"rule_errors": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        papiError(),
+				Description: "Rule validation errors of the converted rule tree",
+			},
+			"rule_warnings": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        papiError(),
+				Description: "Rule validation warnings of the converted rule tree",
+			},
+		},
+	}
+}
+
+func ruleOptionChange() *schema.Resource {
+	return &schema.Resource{Schema: map[string]*schema.Schema{
+		"location": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "JSON pointer to the behavior or criterion, e.g. '#/rules/children/0/behaviors/1'",
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The name of the behavior or criterion",
+		},
+		"option": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The name of the option. Empty if the whole behavior or criterion was removed or added",
+		},
+		"new_option": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The new name of a renamed option",
+		},
+		"detail": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The details of a required option reported by PAPI",
+		},
+	}}
+}
+
+// optionChange is an option, behavior or criterion changed by a rule format conversion
+type optionChange struct {
+	location  string
+	name      string
+	option    string
+	newOption string
+	detail    string
+}
+
+// rulesUpgradeReport lists the changes made by a rule format conversion
+type rulesUpgradeReport struct {
+	renamed  []optionChange
+	removed  []optionChange
+	added    []optionChange
+	required []optionChange
+}
+
+func dataPropertyRulesUpgradeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	ctx = log.NewContext(ctx, meta.Log("PAPI", "dataPropertyRulesUpgradeRead"))
+	logger := log.FromContext(ctx)
+	client := Client(meta)
+
+	propertyID := str.AddPrefix(d.Get("property_id").(string), "prp_")
+	contractID := str.AddPrefix(d.Get("contract_id").(string), "ctr_")
+	groupID := str.AddPrefix(d.Get("group_id").(string), "grp_")
+	ruleFormat := d.Get("rule_format").(string)
+	targetRuleFormat := d.Get("target_rule_format").(string)
+
+	var rules papi.RulesUpdate
+	if err := json.Unmarshal([]byte(d.Get("rules").(string)), &rules); err != nil {
+		return diag.Errorf("rules are not valid JSON: %s", err)
+	}
+
+	version := d.Get("version").(int)
+	if version == 0 {
+		property, err := fetchLatestProperty(ctx, client, propertyID, groupID, contractID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		version = property.LatestVersion
+	}
+
+	// the rule tree is sent in its rule format, and returned converted to the one requested in Accept
+	h := http.Header{
+		"Content-Type": []string{fmt.Sprintf("application/vnd.akamai.papirules.%s+json", ruleFormat)},
+		"Accept":       []string{fmt.Sprintf("application/vnd.akamai.papirules.%s+json", targetRuleFormat)},
+	}
+	logger.Debugf("converting rules of version %d of property %s from %s to %s", version, propertyID, ruleFormat, targetRuleFormat)
+	res, err := client.UpdateRuleTree(session.ContextWithOptions(ctx, session.WithContextHeaders(h)), papi.UpdateRulesRequest{
+		PropertyID:      propertyID,
+		PropertyVersion: version,
+		ContractID:      contractID,
+		GroupID:         groupID,
+		DryRun:          true,
+		ValidateRules:   true,
+		ValidateMode:    papi.RuleValidateModeFull,
+		Rules:           rules,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	validationErrors, err := ruleformats.ValidateRules(targetRuleFormat, res.Rules)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var validationErrorsList []string
+	for _, e := range validationErrors {
+		validationErrorsList = append(validationErrorsList, e.Error())
+	}
+
+	convertedRules, err := json.MarshalIndent(papi.RulesUpdate{Comments: res.Comments, Rules: res.Rules}, "", "  ")
+	if err != nil {
+		return diag.Errorf("invalid JSON result: %s", err)
+	}
+
+	report := upgradeReport(rules.Rules, res.Rules, res.Errors)
+	attrs := map[string]interface{}{
+		"version":           version,
+		"converted_rules":   string(convertedRules),
+		"renamed_options":   optionChangesToList(report.renamed),
+		"removed_options":   optionChangesToList(report.removed),
+		"added_options":     optionChangesToList(report.added),
+		"required_options":  optionChangesToList(report.required),
+		"validation_errors": validationErrorsList,
+		"rule_errors":       papiErrorsToList(ruleErrorsToPAPIErrors(res.Errors)),
+		"rule_warnings":     papiErrorsToList(ruleWarningsToPAPIErrors(res.Warnings)),
+	}
+	if err := tf.SetAttrs(d, attrs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%d:%s", propertyID, version, targetRuleFormat))
+	return nil
+}
+
+// validateUpgradeRuleFormat checks that the rule format is one of the rule formats in the ruleformats registry,
+// as the converted rule tree is validated against its schema
+func validateUpgradeRuleFormat(v interface{}, path cty.Path) diag.Diagnostics {
+	if diags := tf.ValidateRuleFormat(v, path); diags.HasError() {
+		return diags
+	}
+	var supported []string
+	for _, rf := range ruleformats.RulesFormats() {
+		if rf.Version() == v.(string) {
+			return nil
+		}
+		supported = append(supported, rf.Version())
+	}
+	return diag.Errorf("rule format %q is not supported, expected one of: %s", v, strings.Join(supported, ", "))
+}
+
+// upgradeReport compares the rule tree with the converted one. The conversion keeps the structure of the rule tree,
+// so the behaviors and criteria are compared by their positions. An option removed by the conversion
+// is reported as renamed when an option with the same value was added to the same behavior or criterion.
+func upgradeReport(source, converted papi.Rules, ruleErrors []papi.RuleError) rulesUpgradeReport {
+	var report rulesUpgradeReport
+	report.compareRule("#/rules", source, converted)
+	for _, e := range ruleErrors {
+		if !strings.Contains(e.Type, "required") {
+			continue
+		}
+		report.required = append(report.required, optionChange{
+			location: e.ErrorLocation,
+			name:     e.BehaviorName,
+			detail:   e.Detail,
+		})
+	}
+	return report
+}
+
+func (r *rulesUpgradeReport) compareRule(location string, source, converted papi.Rules) {
+	r.compareItems(location+"/behaviors", source.Behaviors, converted.Behaviors)
+	r.compareItems(location+"/criteria", source.Criteria, converted.Criteria)
+	for i := 0; i < len(source.Children) && i < len(converted.Children); i++ {
+		r.compareRule(fmt.Sprintf("%s/children/%d", location, i), source.Children[i], converted.Children[i])
+	}
+}
+
+func (r *rulesUpgradeReport) compareItems(location string, source, converted []papi.RuleBehavior) {
+	for i := 0; i < len(source) || i < len(converted); i++ {
+		itemLocation := fmt.Sprintf("%s/%d", location, i)
+		switch {
+		case i >= len(converted):
+			r.removed = append(r.removed, optionChange{location: itemLocation, name: source[i].Name})
+		case i >= len(source):
+			r.added = append(r.added, optionChange{location: itemLocation, name: converted[i].Name})
+		case source[i].Name != converted[i].Name:
+			r.removed = append(r.removed, optionChange{location: itemLocation, name: source[i].Name})
+			r.added = append(r.added, optionChange{location: itemLocation, name: converted[i].Name})
+		default:
+			r.compareOptions(itemLocation, source[i].Name, source[i].Options, converted[i].Options)
+		}
+	}
+}
+
+func (r *rulesUpgradeReport) compareOptions(location, name string, source, converted papi.RuleOptionsMap) {
+	var removed, added []string
+	for key := range source {
+		if _, ok := converted[key]; !ok {
+			removed = append(removed, key)
+		}
+	}
+	for key := range converted {
+		if _, ok := source[key]; !ok {
+			added = append(added, key)
+		}
+	}
+	sort.Strings(removed)
+	sort.Strings(added)
+
+	renamedTo := make(map[string]bool, len(added))
+	for _, key := range removed {
+		change := optionChange{location: location, name: name, option: key}
+		for _, newKey := range added {
+			if !renamedTo[newKey] && reflect.DeepEqual(source[key], converted[newKey]) {
+				renamedTo[newKey] = true
+				change.newOption = newKey
+				break
+			}
+		}
+		if change.newOption != "" {
+			r.renamed = append(r.renamed, change)
+		} else {
+			r.removed = append(r.removed, change)
+		}
+	}
+	for _, key := range added {
+		if !renamedTo[key] {
+			r.added = append(r.added, optionChange{location: location, name: name, option: key})
+		}
+	}
+}
+
+func optionChangesToList(changes []optionChange) []map[string]interface{} {
+	var result []map[string]interface{}
+	for _, c := range changes {
+		result = append(result, map[string]interface{}{
+			"location":   c.location,
+			"name":       c.name,
+			"option":     c.option,
+			"new_option": c.newOption,
+			"detail":     c.detail,
+		})
+	}
+	return result
+}
+
+func ruleErrorsToPAPIErrors(ruleErrors []papi.RuleError) []*papi.Error {
+	var result []*papi.Error
+	for _, e := range ruleErrors {
+		result = append(result, &papi.Error{
+			Type:          e.Type,
+			Title:         e.Title,
+			Detail:        e.Detail,
+			Instance:      e.Instance,
+			BehaviorName:  e.BehaviorName,
+			ErrorLocation: e.ErrorLocation,
+		})
+	}
+	return result
+}
+
+func ruleWarningsToPAPIErrors(ruleWarnings []papi.RuleWarnings) []*papi.Error {
+	var result []*papi.Error
+	for _, w := range ruleWarnings {
+		result = append(result, &papi.Error{
+			Type:          w.Type,
+			Title:         w.Title,
+			Detail:        w.Detail,
+			ErrorLocation: w.ErrorLocation,
+		})
+	}
+	return result
+}
@@ -0,0 +1,194 @@
+package property
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/papi"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/testutils"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/providers/property/ruleformats"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDSPropertyRulesUpgrade(t *testing.T) {
+	t.Run("convert rules to a newer rule format", func(t *testing.T) {
+		client := &papi.Mock{}
+		client.On("GetProperty", testutils.MockContext, papi.GetPropertyRequest{
+			PropertyID: "prp_1",
+			ContractID: "ctr_1",
+			GroupID:    "grp_1",
+		}).Return(&papi.GetPropertyResponse{
+			Property: &papi.Property{
+				PropertyID:    "prp_1",
+				ContractID:    "ctr_1",
+				GroupID:       "grp_1",
+				LatestVersion: 3,
+			},
+		}, nil)
+		client.On("UpdateRuleTree", testutils.MockContext, papi.UpdateRulesRequest{
+			PropertyID:      "prp_1",
+			PropertyVersion: 3,
+			ContractID:      "ctr_1",
+			GroupID:         "grp_1",
+			DryRun:          true,
+			ValidateRules:   true,
+			ValidateMode:    papi.RuleValidateModeFull,
+			Rules: papi.RulesUpdate{Rules: papi.Rules{
+				Name: "default",
+				Behaviors: []papi.RuleBehavior{{
+					Name:    "caching",
+					Options: papi.RuleOptionsMap{"behavior": "MAX_AGE", "maxAge": "1d", "mustRevalidate": false},
+				}},
+			}},
+		}).Return(&papi.UpdateRulesResponse{
+			PropertyID:      "prp_1",
+			PropertyVersion: 3,
+			RuleFormat:      "v2025-01-13",
+			Rules: papi.Rules{
+				Name: "default",
+				Behaviors: []papi.RuleBehavior{{
+					Name:    "caching",
+					Options: papi.RuleOptionsMap{"behavior": "MAX_AGE", "ttl": "1d", "mustRevalidate": false, "enhancedRfcSupport": false},
+				}},
+			},
+			Errors: []papi.RuleError{{
+				Type:          "https://problems.luna.akamaiapis.net/papi/v0/validation/attribute_required",
+				Title:         "Missing required attribute",
+				Detail:        "The `defaultTtl` option is required",
+				BehaviorName:  "caching",
+				ErrorLocation: "#/rules/behaviors/0",
+			}},
+			Warnings: []papi.RuleWarnings{{
+				Type:          "https://problems.luna.akamaiapis.net/papi/v0/validation/product_behavior_issue.cpcode_incorrect_product",
+				Title:         "CP Code product mismatch",
+				ErrorLocation: "#/rules/behaviors/0",
+			}},
+		}, nil)
+
+		useClient(client, nil, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{{
+					Config: testutils.LoadFixtureString(t, "testdata/TestDSPropertyRulesUpgrade/upgrade.tf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.test", "id", "prp_1:3:v2025-01-13"),
+						resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.test", "version", "3"),
+						resource.TestCheckResourceAttrSet("data.akamai_property_rules_upgrade.test", "converted_rules"),
+						resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.test", "renamed_options.#", "1"),
+						resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.test", "renamed_options.0.location", "#/rules/behaviors/0"),
+						resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.test", "renamed_options.0.name", "caching"),
+						resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.test", "renamed_options.0.option", "maxAge"),
+						resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.test", "renamed_options.0.new_option", "ttl"),
+						resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.test", "removed_options.#", "0"),
+						resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.test", "added_options.#", "1"),
+						resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.test", "added_options.0.option", "enhancedRfcSupport"),
+						resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.test", "required_options.#", "1"),
+						resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.test", "required_options.0.name", "caching"),
+						resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.test", "required_options.0.detail", "The `defaultTtl` option is required"),
+						resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.test", "validation_errors.#", "0"),
+						resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.test", "rule_errors.#", "1"),
+						resource.TestCheckResourceAttr("data.akamai_property_rules_upgrade.test", "rule_warnings.#", "1"),
+					),
+				}},
+			})
+		})
+		client.AssertExpectations(t)
+	})
+
+	t.Run("target rule format not in the registry", func(t *testing.T) {
+		client := &papi.Mock{}
+		useClient(client, nil, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{{
+					Config:      testutils.LoadFixtureString(t, "testdata/TestDSPropertyRulesUpgrade/invalid_target_rule_format.tf"),
+					ExpectError: regexp.MustCompile(`rule format "v2015-08-17" is not supported`),
+				}},
+			})
+		})
+		client.AssertExpectations(t)
+	})
+}
+
+func TestUpgradeReport(t *testing.T) {
+	source := papi.Rules{
+		Name: "default",
+		Behaviors: []papi.RuleBehavior{
+			{Name: "origin", Options: papi.RuleOptionsMap{"hostname": "example.com", "g2oToken": "abc"}},
+			{Name: "caching", Options: papi.RuleOptionsMap{"behavior": "MAX_AGE", "maxAge": "1d", "legacy": true}},
+			{Name: "gzip"},
+		},
+		Children: []papi.Rules{{
+			Name:     "Images",
+			Criteria: []papi.RuleBehavior{{Name: "fileExtension", Options: papi.RuleOptionsMap{"values": []interface{}{"jpg"}}}},
+		}},
+	}
+	converted := papi.Rules{
+		Name: "default",
+		Behaviors: []papi.RuleBehavior{
+			{Name: "origin", Options: papi.RuleOptionsMap{"hostname": "example.com", "g2oToken": "abc"}},
+			{Name: "caching", Options: papi.RuleOptionsMap{"behavior": "MAX_AGE", "ttl": "1d", "enhancedRfcSupport": false}},
+		},
+		Children: []papi.Rules{{
+			Name:     "Images",
+			Criteria: []papi.RuleBehavior{{Name: "fileExtension", Options: papi.RuleOptionsMap{"values": []interface{}{"jpg"}, "matchCaseSensitive": false}}},
+		}},
+	}
+	ruleErrors := []papi.RuleError{
+		{Type: "https://problems.luna.akamaiapis.net/papi/v0/validation/attribute_required", BehaviorName: "caching", Detail: "defaultTtl is required", ErrorLocation: "#/rules/behaviors/1"},
+		{Type: "https://problems.luna.akamaiapis.net/papi/v0/validation/incompatible_condition", BehaviorName: "origin", ErrorLocation: "#/rules/behaviors/0"},
+	}
+
+	report := upgradeReport(source, converted, ruleErrors)
+	assert.Equal(t, rulesUpgradeReport{
+		renamed: []optionChange{
+			{location: "#/rules/behaviors/1", name: "caching", option: "maxAge", newOption: "ttl"},
+		},
+		removed: []optionChange{
+			{location: "#/rules/behaviors/1", name: "caching", option: "legacy"},
+			{location: "#/rules/behaviors/2", name: "gzip"},
+		},
+		added: []optionChange{
+			{location: "#/rules/behaviors/1", name: "caching", option: "enhancedRfcSupport"},
+			{location: "#/rules/children/0/criteria/0", name: "fileExtension", option: "matchCaseSensitive"},
+		},
+		required: []optionChange{
+			{location: "#/rules/behaviors/1", name: "caching", detail: "defaultTtl is required"},
+		},
+	}, report)
+}
+
+func TestValidateRulesAgainstRuleFormat(t *testing.T) {
+	rules := papi.Rules{
+		Name: "default",
+		Behaviors: []papi.RuleBehavior{
+			{Name: "origin", Options: papi.RuleOptionsMap{
+				"hostname":   "example.com",
+				"netStorage": map[string]interface{}{"downloadDomainName": "example.download.akamai.com", "g2oToken": "abc", "unknown": 1},
+			}},
+			{Name: "caching", Options: papi.RuleOptionsMap{"behavior": "SOMETIMES", "ttl": "{{user.PMUSER_TTL}}", "maxAge": "1d"}},
+		},
+		Children: []papi.Rules{{
+			Name:     "Images",
+			Criteria: []papi.RuleBehavior{{Name: "notACriterion"}},
+		}},
+	}
+
+	validationErrors, err := ruleformats.ValidateRules("v2025-01-13", rules)
+	require.NoError(t, err)
+	var messages []string
+	for _, e := range validationErrors {
+		messages = append(messages, e.Error())
+	}
+	assert.Equal(t, []string{
+		"#/rules/behaviors/0: origin.netStorage.unknown: option is not supported by rule format v2025-01-13",
+		`#/rules/behaviors/1: caching.behavior: expected behavior to be one of ["MAX_AGE" "NO_STORE" "BYPASS_CACHE" "CACHE_CONTROL_AND_EXPIRES" "CACHE_CONTROL" "EXPIRES"], got SOMETIMES`,
+		"#/rules/behaviors/1: caching.maxAge: option is not supported by rule format v2025-01-13",
+		"#/rules/children/0/criteria/0: notACriterion: criterion is not supported by rule format v2025-01-13",
+	}, messages)
+
+	_, err = ruleformats.ValidateRules("v2015-08-17", rules)
+	assert.ErrorIs(t, err, ruleformats.ErrNotFound)
+}
@@ -64,6 +64,15 @@ func rulesEqual(oldRules, newRules *papi.Rules) bool {
 		newRules.Children = nil
 	}
 
+	normalizeRules(oldRules, newRules)
+
+	return reflect.DeepEqual(oldRules, newRules)
+}
+
+// normalizeRules removes the differences between the two rules, not including their children,
+// which are not considered changes: empty behaviors and criteria, the default criteriaMustSatisfy,
+// the order of variables and options with null values.
+func normalizeRules(oldRules, newRules *papi.Rules) {
 	if len(oldRules.Behaviors) == 0 {
 		oldRules.Behaviors = nil
 	}
@@ -86,8 +95,6 @@ func rulesEqual(oldRules, newRules *papi.Rules) bool {
 
 	removeNilOptions(oldRules)
 	removeNilOptions(newRules)
-
-	return reflect.DeepEqual(oldRules, newRules)
 }
 
 // PAPI sometimes adds fields (with value null) that are not present in configuration (e.g. exported in cli-terraform)
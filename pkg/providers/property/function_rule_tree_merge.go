@@ -0,0 +1,226 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/papi"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &ruleTreeMergeFunction{}
+
+type (
+	ruleTreeMergeFunction struct{}
+
+	// ruleFlags are the boolean flags of a rule present in the overlay JSON. papi.Rules omits the false flags,
+	// so they are decoded separately to tell an explicit false, which overrides the base flag, from a missing flag.
+	ruleFlags struct {
+		CriteriaLocked *bool `json:"criteriaLocked"`
+		Options        struct {
+			IsSecure *bool `json:"is_secure"`
+		} `json:"options"`
+		Behaviors []behaviorFlags `json:"behaviors"`
+		Criteria  []behaviorFlags `json:"criteria"`
+		Children  []ruleFlags     `json:"children"`
+	}
+
+	// behaviorFlags are the boolean flags of a behavior or criterion present in the overlay JSON
+	behaviorFlags struct {
+		Locked *bool `json:"locked"`
+	}
+)
+
+// NewRuleTreeMergeFunction returns a new rule_tree_merge function
+func NewRuleTreeMergeFunction() function.Function {
+	return &ruleTreeMergeFunction{}
+}
+
+// Metadata implements function.Function
+func (f *ruleTreeMergeFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "rule_tree_merge"
+}
+
+// Definition implements function.Function
+func (f *ruleTreeMergeFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Merges two property rule trees",
+		Description: "Merges the overlay rule tree into the base rule tree. Children are matched by name and merged recursively, " +
+			"behaviors and criteria are matched by name and their options are overridden by the overlay, variables are matched by name " +
+			"and replaced. Elements present only in the overlay are appended. The criteriaLocked, is_secure and locked flags present " +
+			"in the overlay override the base ones, including false.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "base",
+				Description: "The rule tree JSON to merge into, in the format accepted by the `rules` attribute of `akamai_property`.",
+			},
+			function.StringParameter{
+				Name:        "overlay",
+				Description: "The rule tree JSON to merge, in the format accepted by the `rules` attribute of `akamai_property`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run implements function.Function
+func (f *ruleTreeMergeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var baseJSON, overlayJSON string
+
+	resp.Error = req.Arguments.Get(ctx, &baseJSON, &overlayJSON)
+	if resp.Error != nil {
+		return
+	}
+
+	var base papi.RulesUpdate
+	if err := json.Unmarshal([]byte(baseJSON), &base); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("invalid rule tree: %s", err))
+		return
+	}
+
+	var overlay papi.RulesUpdate
+	if err := json.Unmarshal([]byte(overlayJSON), &overlay); err != nil {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("invalid rule tree: %s", err))
+		return
+	}
+	var overlayFlags struct {
+		Rules ruleFlags `json:"rules"`
+	}
+	if err := json.Unmarshal([]byte(overlayJSON), &overlayFlags); err != nil {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("invalid rule tree: %s", err))
+		return
+	}
+
+	if overlay.Comments != "" {
+		base.Comments = overlay.Comments
+	}
+	mergeRules(&base.Rules, overlay.Rules, overlayFlags.Rules)
+
+	merged, err := json.MarshalIndent(base, "", "  ")
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, string(merged))
+}
+
+// mergeRules merges overlay into base, overriding the base values with the non-empty overlay ones,
+// and the base flags with the flags present in the overlay.
+func mergeRules(base *papi.Rules, overlay papi.Rules, flags ruleFlags) {
+	if overlay.AdvancedOverride != "" {
+		base.AdvancedOverride = overlay.AdvancedOverride
+	}
+	if overlay.Comments != "" {
+		base.Comments = overlay.Comments
+	}
+	if flags.CriteriaLocked != nil {
+		base.CriteriaLocked = *flags.CriteriaLocked
+	}
+	if overlay.CustomOverride != nil {
+		base.CustomOverride = overlay.CustomOverride
+	}
+	if overlay.CriteriaMustSatisfy != "" {
+		base.CriteriaMustSatisfy = overlay.CriteriaMustSatisfy
+	}
+	if flags.Options.IsSecure != nil {
+		base.Options.IsSecure = *flags.Options.IsSecure
+	}
+	if overlay.UUID != "" {
+		base.UUID = overlay.UUID
+	}
+	if overlay.TemplateUuid != "" {
+		base.TemplateUuid = overlay.TemplateUuid
+	}
+	if overlay.TemplateLink != "" {
+		base.TemplateLink = overlay.TemplateLink
+	}
+
+	base.Behaviors = mergeRuleBehaviors(base.Behaviors, overlay.Behaviors, flags.Behaviors)
+	base.Criteria = mergeRuleBehaviors(base.Criteria, overlay.Criteria, flags.Criteria)
+	base.Variables = mergeRuleVariables(base.Variables, overlay.Variables)
+
+	for i, overlayChild := range overlay.Children {
+		idx := findRuleByName(base.Children, overlayChild.Name)
+		if idx == -1 {
+			base.Children = append(base.Children, overlayChild)
+			continue
+		}
+		var childFlags ruleFlags
+		if i < len(flags.Children) {
+			childFlags = flags.Children[i]
+		}
+		mergeRules(&base.Children[idx], overlayChild, childFlags)
+	}
+}
+
+// mergeRuleBehaviors pairs the n-th overlay behavior of a given name with the n-th base behavior of the same name.
+// Options of the paired behaviors are merged, unpaired overlay behaviors are appended.
+func mergeRuleBehaviors(base, overlay []papi.RuleBehavior, flags []behaviorFlags) []papi.RuleBehavior {
+	occurrences := make(map[string]int)
+	for i, overlayBehavior := range overlay {
+		idx := findNthBehaviorByName(base, overlayBehavior.Name, occurrences[overlayBehavior.Name])
+		occurrences[overlayBehavior.Name]++
+		if idx == -1 {
+			base = append(base, overlayBehavior)
+			continue
+		}
+
+		if base[idx].Options == nil {
+			base[idx].Options = papi.RuleOptionsMap{}
+		}
+		for k, v := range overlayBehavior.Options {
+			base[idx].Options[k] = v
+		}
+		if i < len(flags) && flags[i].Locked != nil {
+			base[idx].Locked = *flags[i].Locked
+		}
+		if overlayBehavior.UUID != "" {
+			base[idx].UUID = overlayBehavior.UUID
+		}
+		if overlayBehavior.TemplateUuid != "" {
+			base[idx].TemplateUuid = overlayBehavior.TemplateUuid
+		}
+	}
+	return base
+}
+
+func mergeRuleVariables(base, overlay []papi.RuleVariable) []papi.RuleVariable {
+	for _, overlayVariable := range overlay {
+		replaced := false
+		for i := range base {
+			if base[i].Name == overlayVariable.Name {
+				base[i] = overlayVariable
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			base = append(base, overlayVariable)
+		}
+	}
+	return base
+}
+
+func findRuleByName(rules []papi.Rules, name string) int {
+	for i, rule := range rules {
+		if rule.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func findNthBehaviorByName(behaviors []papi.RuleBehavior, name string, n int) int {
+	for i, behavior := range behaviors {
+		if behavior.Name != name {
+			continue
+		}
+		if n == 0 {
+			return i
+		}
+		n--
+	}
+	return -1
+}
@@ -0,0 +1,85 @@
+package property
+
+import (
+	"context"
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/testutils"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuleTreeMergeFunction(t *testing.T) {
+	tests := map[string]struct {
+		base          string
+		overlay       string
+		expected      string
+		expectedError *function.FuncError
+	}{
+		"merge children, behaviors and variables": {
+			base:     testutils.LoadFixtureString(t, "testdata/TestRuleTreeMergeFunction/base.json"),
+			overlay:  testutils.LoadFixtureString(t, "testdata/TestRuleTreeMergeFunction/overlay.json"),
+			expected: testutils.LoadFixtureString(t, "testdata/TestRuleTreeMergeFunction/merged.json"),
+		},
+		"merge with empty overlay": {
+			base:     testutils.LoadFixtureString(t, "testdata/TestRuleTreeMergeFunction/base.json"),
+			overlay:  `{"rules":{"name":"default"}}`,
+			expected: testutils.LoadFixtureString(t, "testdata/TestRuleTreeMergeFunction/base.json"),
+		},
+		"explicit false flags of overlay override base": {
+			base: `{"rules":{"name":"default","criteriaLocked":true,"options":{"is_secure":true},
+				"behaviors":[{"name":"origin","locked":true,"options":{"hostname":"origin.example.com"}}],
+				"children":[{"name":"Static","criteriaLocked":true,"criteria":[{"name":"path","locked":true,"options":{"values":["/static/*"]}}]}]}}`,
+			overlay: `{"rules":{"name":"default","criteriaLocked":false,"options":{"is_secure":false},
+				"behaviors":[{"name":"origin","locked":false,"options":{}}],
+				"children":[{"name":"Static","criteriaLocked":false,"criteria":[{"name":"path","locked":false,"options":{}}]}]}}`,
+			expected: `{"rules":{"name":"default","options":{},
+				"behaviors":[{"name":"origin","options":{"hostname":"origin.example.com"}}],
+				"children":[{"name":"Static","criteria":[{"name":"path","options":{"values":["/static/*"]}}]}]}}`,
+		},
+		"missing flags of overlay keep base": {
+			base: `{"rules":{"name":"default","criteriaLocked":true,"options":{"is_secure":true},
+				"behaviors":[{"name":"origin","locked":true,"options":{"hostname":"origin.example.com"}}]}}`,
+			overlay: `{"rules":{"name":"default","behaviors":[{"name":"origin","options":{"hostname":"new.example.com"}}]}}`,
+			expected: `{"rules":{"name":"default","criteriaLocked":true,"options":{"is_secure":true},
+				"behaviors":[{"name":"origin","locked":true,"options":{"hostname":"new.example.com"}}]}}`,
+		},
+		"invalid base": {
+			base:          `{"rules":`,
+			overlay:       `{"rules":{"name":"default"}}`,
+			expectedError: function.NewArgumentFuncError(0, "invalid rule tree: unexpected end of JSON input"),
+		},
+		"invalid overlay": {
+			base:          `{"rules":{"name":"default"}}`,
+			overlay:       `{"rules":[]}`,
+			expectedError: function.NewArgumentFuncError(1, "invalid rule tree: json: cannot unmarshal array into Go struct field RulesUpdate.rules of type papi.Rules"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(test.base), types.StringValue(test.overlay)}),
+			}
+			resp := function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+
+			NewRuleTreeMergeFunction().Run(context.Background(), req, &resp)
+
+			if test.expectedError != nil {
+				assert.Equal(t, test.expectedError, resp.Error)
+				return
+			}
+			require.Nil(t, resp.Error)
+			result, ok := resp.Result.Value().(types.String)
+			require.True(t, ok)
+			equal, err := rulesJSONEqual(test.expected, result.ValueString())
+			require.NoError(t, err)
+			assert.True(t, equal, result.ValueString())
+		})
+	}
+}
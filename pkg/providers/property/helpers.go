@@ -141,11 +141,11 @@ func flattenHostnames(Hostnames []papi.Hostname) []map[string]interface{} {
 		certs := map[string]interface{}{}
 		certs["hostname"] = hn.CertStatus.ValidationCname.Hostname
 		certs["target"] = hn.CertStatus.ValidationCname.Target
-		if len(hn.CertStatus.Staging) > 0 {
-			certs["staging_status"] = hn.CertStatus.Staging[0].Status
+		if status := networkCertStatus(&hn.CertStatus, papi.ActivationNetworkStaging); status != "" {
+			certs["staging_status"] = status
 		}
-		if len(hn.CertStatus.Production) > 0 {
-			certs["production_status"] = hn.CertStatus.Production[0].Status
+		if status := networkCertStatus(&hn.CertStatus, papi.ActivationNetworkProduction); status != "" {
+			certs["production_status"] = status
 		}
 		c = append(c, certs)
 		m["cert_status"] = c
@@ -154,6 +154,22 @@ func flattenHostnames(Hostnames []papi.Hostname) []map[string]interface{} {
 	return res
 }
 
+// networkCertStatus returns the status of the Default DV certificate of a hostname on the network,
+// or an empty string if the certificate has no status on the network
+func networkCertStatus(certStatus *papi.CertStatusItem, network papi.ActivationNetwork) string {
+	if certStatus == nil {
+		return ""
+	}
+	statuses := certStatus.Staging
+	if network == papi.ActivationNetworkProduction {
+		statuses = certStatus.Production
+	}
+	if len(statuses) == 0 {
+		return ""
+	}
+	return statuses[0].Status
+}
+
 func papiErrorsToList(errors []*papi.Error) []map[string]interface{} {
 	if len(errors) == 0 {
 		return nil
@@ -72,6 +72,28 @@ func TestNetworkAlias(t *testing.T) {
 	}
 }
 
+func TestNetworkCertStatus(t *testing.T) {
+	certStatus := &papi.CertStatusItem{
+		Staging:    []papi.StatusItem{{Status: "DEPLOYED"}},
+		Production: []papi.StatusItem{{Status: "PENDING"}},
+	}
+	tests := map[string]struct {
+		certStatus *papi.CertStatusItem
+		network    papi.ActivationNetwork
+		expected   string
+	}{
+		"staging":               {certStatus, papi.ActivationNetworkStaging, "DEPLOYED"},
+		"production":            {certStatus, papi.ActivationNetworkProduction, "PENDING"},
+		"no status on network":  {&papi.CertStatusItem{Staging: []papi.StatusItem{{Status: "DEPLOYED"}}}, papi.ActivationNetworkProduction, ""},
+		"no certificate status": {nil, papi.ActivationNetworkStaging, ""},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, networkCertStatus(test.certStatus, test.network))
+		})
+	}
+}
+
 func TestIsPropertyInGroup(t *testing.T) {
 	key := papiKey{
 		propertyID: "prp_1",
@@ -0,0 +1,340 @@
+// Package hostnamebucket implements the PAPI hostname bucket operations, which manage the hostnames of a property
+// on each network directly, without creating new property versions.
+package hostnamebucket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/providers/property/internal/papierr"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// HostnameBucket is the PAPI hostname bucket API interface
+	HostnameBucket interface {
+		// ListPropertyHostnames lists the hostnames of the property on the network.
+		//
+		// See: https://techdocs.akamai.com/property-mgr/reference/get-property-hostnames
+		ListPropertyHostnames(ctx context.Context, params ListPropertyHostnamesRequest) (*ListPropertyHostnamesResponse, error)
+
+		// PatchPropertyHostnames adds hostnames to and removes hostnames from the property on the network,
+		// and returns the link of the started hostname activation.
+		//
+		// See: https://techdocs.akamai.com/property-mgr/reference/patch-property-hostnames
+		PatchPropertyHostnames(ctx context.Context, params PatchPropertyHostnamesRequest) (*PatchPropertyHostnamesResponse, error)
+
+		// GetPropertyHostnameActivation returns the status of the hostname activation.
+		//
+		// See: https://techdocs.akamai.com/property-mgr/reference/get-property-hostname-activation
+		GetPropertyHostnameActivation(ctx context.Context, params GetPropertyHostnameActivationRequest) (*GetPropertyHostnameActivationResponse, error)
+	}
+
+	hostnameBucket struct {
+		session.Session
+	}
+
+	// ListPropertyHostnamesRequest contains parameters required to list the hostnames of a property
+	ListPropertyHostnamesRequest struct {
+		PropertyID        string
+		ContractID        string
+		GroupID           string
+		Network           string
+		IncludeCertStatus bool
+		Offset            int
+		Limit             int
+	}
+
+	// ListPropertyHostnamesResponse contains a page of the hostnames of a property
+	ListPropertyHostnamesResponse struct {
+		AccountID    string        `json:"accountId"`
+		ContractID   string        `json:"contractId"`
+		GroupID      string        `json:"groupId"`
+		PropertyID   string        `json:"propertyId"`
+		PropertyName string        `json:"propertyName"`
+		Hostnames    HostnameItems `json:"hostnames"`
+	}
+
+	// HostnameItems contains the hostnames of a page and the paging information
+	HostnameItems struct {
+		Items            []Hostname `json:"items"`
+		CurrentItemCount int        `json:"currentItemCount"`
+		TotalItems       int        `json:"totalItems"`
+		NextLink         string     `json:"nextLink,omitempty"`
+	}
+
+	// Hostname contains the configuration of a hostname on the staging and production networks
+	Hostname struct {
+		CnameFrom                string               `json:"cnameFrom"`
+		CnameType                string               `json:"cnameType"`
+		StagingCertType          string               `json:"stagingCertType,omitempty"`
+		StagingCnameTo           string               `json:"stagingCnameTo,omitempty"`
+		StagingEdgeHostnameID    string               `json:"stagingEdgeHostnameId,omitempty"`
+		ProductionCertType       string               `json:"productionCertType,omitempty"`
+		ProductionCnameTo        string               `json:"productionCnameTo,omitempty"`
+		ProductionEdgeHostnameID string               `json:"productionEdgeHostnameId,omitempty"`
+		CertStatus               *papi.CertStatusItem `json:"certStatus,omitempty"`
+	}
+
+	// PatchPropertyHostnamesRequest contains parameters required to add and remove hostnames of a property
+	PatchPropertyHostnamesRequest struct {
+		PropertyID string
+		ContractID string
+		GroupID    string
+		Body       PatchPropertyHostnamesRequestBody
+	}
+
+	// PatchPropertyHostnamesRequestBody contains the hostnames to add and remove on the network
+	PatchPropertyHostnamesRequestBody struct {
+		Network      string        `json:"network"`
+		Note         string        `json:"note,omitempty"`
+		NotifyEmails []string      `json:"notifyEmails,omitempty"`
+		Add          []AddHostname `json:"add,omitempty"`
+		Remove       []string      `json:"remove,omitempty"`
+	}
+
+	// AddHostname contains a hostname to add, or to update if it already exists
+	AddHostname struct {
+		CnameFrom            string `json:"cnameFrom"`
+		CnameType            string `json:"cnameType"`
+		CertProvisioningType string `json:"certProvisioningType"`
+		CnameTo              string `json:"cnameTo,omitempty"`
+		EdgeHostnameID       string `json:"edgeHostnameId,omitempty"`
+	}
+
+	// PatchPropertyHostnamesResponse contains the link of the started hostname activation
+	PatchPropertyHostnamesResponse struct {
+		ActivationLink string `json:"activationLink"`
+	}
+
+	// GetPropertyHostnameActivationRequest contains parameters required to get a hostname activation
+	GetPropertyHostnameActivationRequest struct {
+		PropertyID           string
+		HostnameActivationID string
+		ContractID           string
+		GroupID              string
+	}
+
+	// GetPropertyHostnameActivationResponse contains the hostname activation
+	GetPropertyHostnameActivationResponse struct {
+		AccountID           string                  `json:"accountId"`
+		ContractID          string                  `json:"contractId"`
+		GroupID             string                  `json:"groupId"`
+		HostnameActivations HostnameActivationItems `json:"hostnameActivations"`
+	}
+
+	// HostnameActivationItems contains the hostname activations
+	HostnameActivationItems struct {
+		Items []HostnameActivation `json:"items"`
+	}
+
+	// HostnameActivation contains the status of a hostname activation
+	HostnameActivation struct {
+		ActivationType       string   `json:"activationType"`
+		HostnameActivationID string   `json:"hostnameActivationId"`
+		PropertyName         string   `json:"propertyName"`
+		PropertyID           string   `json:"propertyId"`
+		Network              string   `json:"network"`
+		Status               string   `json:"status"`
+		SubmitDate           string   `json:"submitDate"`
+		UpdateDate           string   `json:"updateDate"`
+		Note                 string   `json:"note,omitempty"`
+		NotifyEmails         []string `json:"notifyEmails,omitempty"`
+	}
+)
+
+const (
+	// CnameTypeEdgeHostname is the only type of the hostnames of a property
+	CnameTypeEdgeHostname = "EDGE_HOSTNAME"
+
+	// CertProvisioningTypeCPSManaged is the type of hostnames secured with a certificate managed in CPS
+	CertProvisioningTypeCPSManaged = "CPS_MANAGED"
+	// CertProvisioningTypeDefault is the type of hostnames secured with a Default Domain Validation certificate
+	CertProvisioningTypeDefault = "DEFAULT"
+
+	// ActivationStatusActive is the status of a completed hostname activation
+	ActivationStatusActive = "ACTIVE"
+	// ActivationStatusFailed is the status of a failed hostname activation
+	ActivationStatusFailed = "FAILED"
+	// ActivationStatusAborted is the status of an aborted hostname activation
+	ActivationStatusAborted = "ABORTED"
+)
+
+var (
+	// ErrListPropertyHostnames is returned when ListPropertyHostnames fails
+	ErrListPropertyHostnames = errors.New("listing property hostnames")
+	// ErrPatchPropertyHostnames is returned when PatchPropertyHostnames fails
+	ErrPatchPropertyHostnames = errors.New("patching property hostnames")
+	// ErrGetPropertyHostnameActivation is returned when GetPropertyHostnameActivation fails
+	ErrGetPropertyHostnameActivation = errors.New("getting property hostname activation")
+
+	networks = []interface{}{"STAGING", "PRODUCTION"}
+)
+
+// Client returns a new hostname bucket client with the given session
+func Client(sess session.Session) HostnameBucket {
+	return &hostnameBucket{Session: sess}
+}
+
+// Validate validates ListPropertyHostnamesRequest
+func (r ListPropertyHostnamesRequest) Validate() error {
+	return validation.Errors{
+		"PropertyID": validation.Validate(r.PropertyID, validation.Required),
+		"Network":    validation.Validate(r.Network, validation.In(networks...)),
+		"Offset":     validation.Validate(r.Offset, validation.Min(0)),
+		"Limit":      validation.Validate(r.Limit, validation.Min(0)),
+	}.Filter()
+}
+
+// Validate validates PatchPropertyHostnamesRequest
+func (r PatchPropertyHostnamesRequest) Validate() error {
+	return validation.Errors{
+		"PropertyID":   validation.Validate(r.PropertyID, validation.Required),
+		"Body.Network": validation.Validate(r.Body.Network, validation.Required, validation.In(networks...)),
+		"Body.Add":     validation.Validate(r.Body.Add, validation.Required.When(len(r.Body.Remove) == 0).Error("add or remove is required")),
+	}.Filter()
+}
+
+// Validate validates GetPropertyHostnameActivationRequest
+func (r GetPropertyHostnameActivationRequest) Validate() error {
+	return validation.Errors{
+		"PropertyID":           validation.Validate(r.PropertyID, validation.Required),
+		"HostnameActivationID": validation.Validate(r.HostnameActivationID, validation.Required),
+	}.Filter()
+}
+
+// ActivationID returns the ID of the hostname activation from the activation link
+func (r PatchPropertyHostnamesResponse) ActivationID() (string, error) {
+	link, err := url.Parse(r.ActivationLink)
+	if err != nil {
+		return "", fmt.Errorf("invalid activation link %q: %w", r.ActivationLink, err)
+	}
+	segments := strings.Split(strings.TrimSuffix(link.Path, "/"), "/")
+	if len(segments) < 2 || segments[len(segments)-2] != "hostname-activations" {
+		return "", fmt.Errorf("invalid activation link %q", r.ActivationLink)
+	}
+	return segments[len(segments)-1], nil
+}
+
+func (h *hostnameBucket) ListPropertyHostnames(ctx context.Context, params ListPropertyHostnamesRequest) (*ListPropertyHostnamesResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrListPropertyHostnames, papi.ErrStructValidation, err)
+	}
+
+	logger := h.Log(ctx)
+	logger.Debug("ListPropertyHostnames")
+
+	query := url.Values{}
+	addQueryParam(query, "contractId", params.ContractID)
+	addQueryParam(query, "groupId", params.GroupID)
+	addQueryParam(query, "network", params.Network)
+	query.Add("includeCertStatus", strconv.FormatBool(params.IncludeCertStatus))
+	if params.Offset > 0 {
+		query.Add("offset", strconv.Itoa(params.Offset))
+	}
+	if params.Limit > 0 {
+		query.Add("limit", strconv.Itoa(params.Limit))
+	}
+	getURL := fmt.Sprintf("/papi/v1/properties/%s/hostnames?%s", params.PropertyID, query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, getURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrListPropertyHostnames, err)
+	}
+
+	var result ListPropertyHostnamesResponse
+	resp, err := h.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrListPropertyHostnames, err)
+	}
+	defer session.CloseResponseBody(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %w", ErrListPropertyHostnames, papierr.FromResponse(resp))
+	}
+
+	return &result, nil
+}
+
+func (h *hostnameBucket) PatchPropertyHostnames(ctx context.Context, params PatchPropertyHostnamesRequest) (*PatchPropertyHostnamesResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrPatchPropertyHostnames, papi.ErrStructValidation, err)
+	}
+
+	logger := h.Log(ctx)
+	logger.Debug("PatchPropertyHostnames")
+
+	query := url.Values{}
+	addQueryParam(query, "contractId", params.ContractID)
+	addQueryParam(query, "groupId", params.GroupID)
+	patchURL := fmt.Sprintf("/papi/v1/properties/%s/hostnames", params.PropertyID)
+	if len(query) > 0 {
+		patchURL += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, patchURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrPatchPropertyHostnames, err)
+	}
+
+	var result PatchPropertyHostnamesResponse
+	resp, err := h.Exec(req, &result, params.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrPatchPropertyHostnames, err)
+	}
+	defer session.CloseResponseBody(resp)
+
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %w", ErrPatchPropertyHostnames, papierr.FromResponse(resp))
+	}
+
+	return &result, nil
+}
+
+func (h *hostnameBucket) GetPropertyHostnameActivation(ctx context.Context, params GetPropertyHostnameActivationRequest) (*GetPropertyHostnameActivationResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetPropertyHostnameActivation, papi.ErrStructValidation, err)
+	}
+
+	logger := h.Log(ctx)
+	logger.Debug("GetPropertyHostnameActivation")
+
+	query := url.Values{}
+	addQueryParam(query, "contractId", params.ContractID)
+	addQueryParam(query, "groupId", params.GroupID)
+	getURL := fmt.Sprintf("/papi/v1/properties/%s/hostname-activations/%s", params.PropertyID, params.HostnameActivationID)
+	if len(query) > 0 {
+		getURL += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, getURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetPropertyHostnameActivation, err)
+	}
+
+	var result GetPropertyHostnameActivationResponse
+	resp, err := h.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetPropertyHostnameActivation, err)
+	}
+	defer session.CloseResponseBody(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %w", ErrGetPropertyHostnameActivation, papierr.FromResponse(resp))
+	}
+
+	return &result, nil
+}
+
+func addQueryParam(query url.Values, name, value string) {
+	if value != "" {
+		query.Add(name, value)
+	}
+}
@@ -0,0 +1,316 @@
+package hostnamebucket
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/edgegrid"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockAPIClient(t *testing.T, mockServer *httptest.Server) HostnameBucket {
+	serverURL, err := url.Parse(mockServer.URL)
+	require.NoError(t, err)
+	certPool := x509.NewCertPool()
+	certPool.AddCert(mockServer.Certificate())
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs: certPool,
+			},
+		},
+	}
+	s, err := session.New(session.WithClient(httpClient), session.WithSigner(&edgegrid.Config{Host: serverURL.Host}))
+	require.NoError(t, err)
+	return Client(s)
+}
+
+func TestListPropertyHostnames(t *testing.T) {
+	tests := map[string]struct {
+		params           ListPropertyHostnamesRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedResponse *ListPropertyHostnamesResponse
+		withError        func(*testing.T, error)
+	}{
+		"200 OK": {
+			params: ListPropertyHostnamesRequest{
+				PropertyID:        "prp_175780",
+				ContractID:        "ctr_1-1TJZFW",
+				GroupID:           "grp_15166",
+				Network:           "STAGING",
+				IncludeCertStatus: true,
+				Offset:            2,
+				Limit:             2,
+			},
+			responseStatus: http.StatusOK,
+			responseBody: `{
+    "accountId": "act_1-1TJZFB",
+    "contractId": "ctr_1-1TJZFW",
+    "groupId": "grp_15166",
+    "propertyId": "prp_175780",
+    "propertyName": "mytestproperty.com",
+    "hostnames": {
+        "items": [{
+            "cnameFrom": "www.example.com",
+            "cnameType": "EDGE_HOSTNAME",
+            "stagingCertType": "DEFAULT",
+            "stagingCnameTo": "www.example.com.edgekey.net",
+            "stagingEdgeHostnameId": "ehn_895822",
+            "certStatus": {
+                "validationCname": {"hostname": "_acme-challenge.www.example.com", "target": "ac.1234.example.com"},
+                "staging": [{"status": "PENDING"}]
+            }
+        }],
+        "currentItemCount": 1,
+        "totalItems": 3
+    }
+}`,
+			expectedPath: "/papi/v1/properties/prp_175780/hostnames?contractId=ctr_1-1TJZFW&groupId=grp_15166&includeCertStatus=true&limit=2&network=STAGING&offset=2",
+			expectedResponse: &ListPropertyHostnamesResponse{
+				AccountID:    "act_1-1TJZFB",
+				ContractID:   "ctr_1-1TJZFW",
+				GroupID:      "grp_15166",
+				PropertyID:   "prp_175780",
+				PropertyName: "mytestproperty.com",
+				Hostnames: HostnameItems{
+					Items: []Hostname{{
+						CnameFrom:             "www.example.com",
+						CnameType:             "EDGE_HOSTNAME",
+						StagingCertType:       "DEFAULT",
+						StagingCnameTo:        "www.example.com.edgekey.net",
+						StagingEdgeHostnameID: "ehn_895822",
+						CertStatus: &papi.CertStatusItem{
+							ValidationCname: papi.ValidationCname{Hostname: "_acme-challenge.www.example.com", Target: "ac.1234.example.com"},
+							Staging:         []papi.StatusItem{{Status: "PENDING"}},
+						},
+					}},
+					CurrentItemCount: 1,
+					TotalItems:       3,
+				},
+			},
+		},
+		"404 not found": {
+			params:         ListPropertyHostnamesRequest{PropertyID: "prp_175780", Network: "PRODUCTION"},
+			responseStatus: http.StatusNotFound,
+			responseBody:   `{"type": "not_found", "title": "Not Found", "detail": "The property was not found"}`,
+			expectedPath:   "/papi/v1/properties/prp_175780/hostnames?includeCertStatus=false&network=PRODUCTION",
+			withError: func(t *testing.T, err error) {
+				var papiErr *papi.Error
+				require.True(t, errors.As(err, &papiErr))
+				assert.Equal(t, http.StatusNotFound, papiErr.StatusCode)
+				assert.Equal(t, "The property was not found", papiErr.Detail)
+				assert.True(t, errors.Is(err, ErrListPropertyHostnames))
+			},
+		},
+		"validation error": {
+			params: ListPropertyHostnamesRequest{Network: "QA"},
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, papi.ErrStructValidation))
+				assert.Contains(t, err.Error(), "PropertyID: cannot be blank")
+				assert.Contains(t, err.Error(), "Network: must be a valid value")
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer)
+			result, err := client.ListPropertyHostnames(context.Background(), test.params)
+			if test.withError != nil {
+				test.withError(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestPatchPropertyHostnames(t *testing.T) {
+	tests := map[string]struct {
+		params              PatchPropertyHostnamesRequest
+		responseStatus      int
+		responseBody        string
+		expectedPath        string
+		expectedRequestBody string
+		expectedResponse    *PatchPropertyHostnamesResponse
+		withError           func(*testing.T, error)
+	}{
+		"202 accepted": {
+			params: PatchPropertyHostnamesRequest{
+				PropertyID: "prp_175780",
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+				Body: PatchPropertyHostnamesRequestBody{
+					Network:      "STAGING",
+					NotifyEmails: []string{"team@example.com"},
+					Add: []AddHostname{{
+						CnameFrom:            "www.example.com",
+						CnameType:            CnameTypeEdgeHostname,
+						CertProvisioningType: CertProvisioningTypeDefault,
+						CnameTo:              "www.example.com.edgekey.net",
+					}},
+					Remove: []string{"old.example.com"},
+				},
+			},
+			responseStatus: http.StatusAccepted,
+			responseBody:   `{"activationLink": "/papi/v1/properties/prp_175780/hostname-activations/atv_1696985?contractId=ctr_1-1TJZFW&groupId=grp_15166"}`,
+			expectedPath:   "/papi/v1/properties/prp_175780/hostnames?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			expectedRequestBody: `{"network":"STAGING","notifyEmails":["team@example.com"],"add":[{"cnameFrom":"www.example.com","cnameType":"EDGE_HOSTNAME",` +
+				`"certProvisioningType":"DEFAULT","cnameTo":"www.example.com.edgekey.net"}],"remove":["old.example.com"]}`,
+			expectedResponse: &PatchPropertyHostnamesResponse{
+				ActivationLink: "/papi/v1/properties/prp_175780/hostname-activations/atv_1696985?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			},
+		},
+		"409 conflict": {
+			params: PatchPropertyHostnamesRequest{
+				PropertyID: "prp_175780",
+				Body:       PatchPropertyHostnamesRequestBody{Network: "PRODUCTION", Remove: []string{"old.example.com"}},
+			},
+			responseStatus:      http.StatusConflict,
+			responseBody:        `{"type": "conflict", "title": "Conflict", "detail": "A hostname activation is pending"}`,
+			expectedPath:        "/papi/v1/properties/prp_175780/hostnames",
+			expectedRequestBody: `{"network":"PRODUCTION","remove":["old.example.com"]}`,
+			withError: func(t *testing.T, err error) {
+				var papiErr *papi.Error
+				require.True(t, errors.As(err, &papiErr))
+				assert.Equal(t, http.StatusConflict, papiErr.StatusCode)
+				assert.True(t, errors.Is(err, ErrPatchPropertyHostnames))
+			},
+		},
+		"validation error": {
+			params: PatchPropertyHostnamesRequest{PropertyID: "prp_175780", Body: PatchPropertyHostnamesRequestBody{Network: "STAGING"}},
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, papi.ErrStructValidation))
+				assert.Contains(t, err.Error(), "Body.Add: add or remove is required")
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodPatch, r.Method)
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, test.expectedRequestBody, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer)
+			result, err := client.PatchPropertyHostnames(context.Background(), test.params)
+			if test.withError != nil {
+				test.withError(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestGetPropertyHostnameActivation(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/papi/v1/properties/prp_175780/hostname-activations/atv_1696985?contractId=ctr_1-1TJZFW&groupId=grp_15166", r.URL.String())
+		assert.Equal(t, http.MethodGet, r.Method)
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{
+    "accountId": "act_1-1TJZFB",
+    "contractId": "ctr_1-1TJZFW",
+    "groupId": "grp_15166",
+    "hostnameActivations": {
+        "items": [{
+            "activationType": "ACTIVATE",
+            "hostnameActivationId": "atv_1696985",
+            "propertyName": "mytestproperty.com",
+            "propertyId": "prp_175780",
+            "network": "STAGING",
+            "status": "ACTIVE",
+            "submitDate": "2024-03-12T15:51:12Z",
+            "updateDate": "2024-03-12T15:53:08Z"
+        }]
+    }
+}`))
+		assert.NoError(t, err)
+	}))
+	defer mockServer.Close()
+	client := mockAPIClient(t, mockServer)
+
+	result, err := client.GetPropertyHostnameActivation(context.Background(), GetPropertyHostnameActivationRequest{
+		PropertyID:           "prp_175780",
+		HostnameActivationID: "atv_1696985",
+		ContractID:           "ctr_1-1TJZFW",
+		GroupID:              "grp_15166",
+	})
+	require.NoError(t, err)
+	require.Len(t, result.HostnameActivations.Items, 1)
+	assert.Equal(t, HostnameActivation{
+		ActivationType:       "ACTIVATE",
+		HostnameActivationID: "atv_1696985",
+		PropertyName:         "mytestproperty.com",
+		PropertyID:           "prp_175780",
+		Network:              "STAGING",
+		Status:               ActivationStatusActive,
+		SubmitDate:           "2024-03-12T15:51:12Z",
+		UpdateDate:           "2024-03-12T15:53:08Z",
+	}, result.HostnameActivations.Items[0])
+
+	_, err = client.GetPropertyHostnameActivation(context.Background(), GetPropertyHostnameActivationRequest{PropertyID: "prp_175780"})
+	assert.True(t, errors.Is(err, papi.ErrStructValidation))
+}
+
+func TestActivationID(t *testing.T) {
+	tests := map[string]struct {
+		link          string
+		expected      string
+		expectedError string
+	}{
+		"link with query": {
+			link:     "/papi/v1/properties/prp_175780/hostname-activations/atv_1696985?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			expected: "atv_1696985",
+		},
+		"link without query": {
+			link:     "/papi/v1/properties/prp_175780/hostname-activations/atv_1696985",
+			expected: "atv_1696985",
+		},
+		"not an activation link": {
+			link:          "/papi/v1/properties/prp_175780/hostnames",
+			expectedError: `invalid activation link "/papi/v1/properties/prp_175780/hostnames"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			id, err := PatchPropertyHostnamesResponse{ActivationLink: test.link}.ActivationID()
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, id)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package hostnamebucket
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock is a mock of the HostnameBucket interface
+type Mock struct {
+	mock.Mock
+}
+
+var _ HostnameBucket = &Mock{}
+
+// ListPropertyHostnames implements HostnameBucket
+func (m *Mock) ListPropertyHostnames(ctx context.Context, params ListPropertyHostnamesRequest) (*ListPropertyHostnamesResponse, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ListPropertyHostnamesResponse), args.Error(1)
+}
+
+// PatchPropertyHostnames implements HostnameBucket
+func (m *Mock) PatchPropertyHostnames(ctx context.Context, params PatchPropertyHostnamesRequest) (*PatchPropertyHostnamesResponse, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*PatchPropertyHostnamesResponse), args.Error(1)
+}
+
+// GetPropertyHostnameActivation implements HostnameBucket
+func (m *Mock) GetPropertyHostnameActivation(ctx context.Context, params GetPropertyHostnameActivationRequest) (*GetPropertyHostnameActivationResponse, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*GetPropertyHostnameActivationResponse), args.Error(1)
+}
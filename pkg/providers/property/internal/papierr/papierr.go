@@ -0,0 +1,28 @@
+// Package papierr parses the errors of the PAPI operations which are not implemented by the edgegrid papi package
+// yet, so that they are reported as papi.Error, the same as the errors of the operations of the papi package.
+package papierr
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/papi"
+)
+
+// FromResponse parses the PAPI error from the response
+func FromResponse(r *http.Response) error {
+	e := papi.Error{StatusCode: r.StatusCode}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		e.Title = "Failed to read error body"
+		e.Detail = err.Error()
+		return &e
+	}
+	if err := json.Unmarshal(body, &e); err != nil {
+		e.Title = "Failed to unmarshal error body. PAPI API failed. Check details for more information."
+		e.Detail = string(body)
+	}
+	e.StatusCode = r.StatusCode
+	return &e
+}
@@ -0,0 +1,49 @@
+package papierr
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/papi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromResponse(t *testing.T) {
+	tests := map[string]struct {
+		body     string
+		expected papi.Error
+	}{
+		"PAPI error": {
+			body: `{"type": "https://problems.luna.akamaiapis.net/papi/v0/precondition-failed", "title": "Precondition Failed"}`,
+			expected: papi.Error{
+				Type:       "https://problems.luna.akamaiapis.net/papi/v0/precondition-failed",
+				Title:      "Precondition Failed",
+				StatusCode: http.StatusPreconditionFailed,
+			},
+		},
+		"invalid body": {
+			body: "<html>Bad Gateway</html>",
+			expected: papi.Error{
+				Title:      "Failed to unmarshal error body. PAPI API failed. Check details for more information.",
+				Detail:     "<html>Bad Gateway</html>",
+				StatusCode: http.StatusPreconditionFailed,
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := FromResponse(&http.Response{
+				StatusCode: http.StatusPreconditionFailed,
+				Body:       io.NopCloser(strings.NewReader(tc.body)),
+			})
+			var papiErr *papi.Error
+			require.True(t, errors.As(err, &papiErr))
+			assert.Equal(t, tc.expected, *papiErr)
+		})
+	}
+}
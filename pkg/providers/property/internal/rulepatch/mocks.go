@@ -0,0 +1,24 @@
+package rulepatch
+
+import (
+	"context"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/papi"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock is a mock of the RulePatch interface
+type Mock struct {
+	mock.Mock
+}
+
+var _ RulePatch = &Mock{}
+
+// PatchRuleTree implements RulePatch
+func (m *Mock) PatchRuleTree(ctx context.Context, params PatchRuleTreeRequest) (*papi.UpdateRulesResponse, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*papi.UpdateRulesResponse), args.Error(1)
+}
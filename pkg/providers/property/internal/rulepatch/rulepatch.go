@@ -0,0 +1,152 @@
+// Package rulepatch implements the PAPI rule tree patch operation, which applies an RFC 6902 JSON Patch
+// to the rule tree of a property version.
+package rulepatch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/providers/property/internal/papierr"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// RulePatch is the PAPI rule tree patch API interface
+	RulePatch interface {
+		// PatchRuleTree applies the JSON Patch operations to the rule tree of the property version.
+		// The patch is rejected with ErrEtagMismatch if the rule tree was modified since it was read with the given etag.
+		//
+		// See: https://techdocs.akamai.com/property-mgr/reference/patch-property-version-rules
+		PatchRuleTree(ctx context.Context, params PatchRuleTreeRequest) (*papi.UpdateRulesResponse, error)
+	}
+
+	rulePatch struct {
+		session.Session
+	}
+
+	// PatchRuleTreeRequest contains parameters required to patch the rule tree of a property version
+	PatchRuleTreeRequest struct {
+		PropertyID      string
+		PropertyVersion int
+		ContractID      string
+		GroupID         string
+		Etag            string
+		ValidateRules   bool
+		Operations      []Operation
+	}
+
+	// Operation is an RFC 6902 JSON Patch operation
+	Operation struct {
+		Op    string          `json:"op"`
+		Path  string          `json:"path"`
+		From  string          `json:"from,omitempty"`
+		Value json.RawMessage `json:"value,omitempty"`
+	}
+)
+
+const (
+	// OpAdd adds the value at the path
+	OpAdd = "add"
+	// OpRemove removes the value at the path
+	OpRemove = "remove"
+	// OpReplace replaces the value at the path
+	OpReplace = "replace"
+	// OpMove moves the value at from to the path
+	OpMove = "move"
+	// OpCopy copies the value at from to the path
+	OpCopy = "copy"
+	// OpTest checks the value at the path is equal to the value
+	OpTest = "test"
+)
+
+var (
+	// ErrPatchRuleTree is returned when PatchRuleTree fails
+	ErrPatchRuleTree = errors.New("patching rule tree")
+	// ErrEtagMismatch is returned when the rule tree was modified since it was read
+	ErrEtagMismatch = errors.New("rule tree was modified concurrently")
+
+	// Ops are the supported JSON Patch operations
+	Ops = []string{OpAdd, OpRemove, OpReplace, OpMove, OpCopy, OpTest}
+)
+
+// Client returns a new rule tree patch client with the given session
+func Client(sess session.Session) RulePatch {
+	return &rulePatch{Session: sess}
+}
+
+// Validate validates PatchRuleTreeRequest
+func (r PatchRuleTreeRequest) Validate() error {
+	return validation.Errors{
+		"PropertyID":      validation.Validate(r.PropertyID, validation.Required),
+		"PropertyVersion": validation.Validate(r.PropertyVersion, validation.Required),
+		"Etag":            validation.Validate(r.Etag, validation.Required),
+		"Operations":      validation.Validate(r.Operations, validation.Required),
+	}.Filter()
+}
+
+// Validate validates Operation
+func (o Operation) Validate() error {
+	ops := make([]interface{}, 0, len(Ops))
+	for _, op := range Ops {
+		ops = append(ops, op)
+	}
+	return validation.Errors{
+		"Op":    validation.Validate(o.Op, validation.Required, validation.In(ops...)),
+		"Path":  validation.Validate(o.Path, validation.Required),
+		"From":  validation.Validate(o.From, validation.Required.When(o.Op == OpMove || o.Op == OpCopy)),
+		"Value": validation.Validate(o.Value, validation.Required.When(o.Op == OpAdd || o.Op == OpReplace || o.Op == OpTest)),
+	}.Filter()
+}
+
+func (p *rulePatch) PatchRuleTree(ctx context.Context, params PatchRuleTreeRequest) (*papi.UpdateRulesResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrPatchRuleTree, papi.ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("PatchRuleTree")
+
+	query := url.Values{}
+	if params.ContractID != "" {
+		query.Add("contractId", params.ContractID)
+	}
+	if params.GroupID != "" {
+		query.Add("groupId", params.GroupID)
+	}
+	if !params.ValidateRules {
+		query.Add("validateRules", "false")
+	}
+	patchURL := fmt.Sprintf("/papi/v1/properties/%s/versions/%d/rules", params.PropertyID, params.PropertyVersion)
+	if len(query) > 0 {
+		patchURL += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, patchURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrPatchRuleTree, err)
+	}
+	req.Header.Set("Content-Type", "application/json-patch+json")
+	req.Header.Set("If-Match", params.Etag)
+
+	var result papi.UpdateRulesResponse
+	resp, err := p.Exec(req, &result, params.Operations)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrPatchRuleTree, err)
+	}
+	defer session.CloseResponseBody(resp)
+
+	if resp.StatusCode == http.StatusPreconditionFailed {
+		return nil, fmt.Errorf("%w: %w: %w", ErrPatchRuleTree, ErrEtagMismatch, papierr.FromResponse(resp))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %w", ErrPatchRuleTree, papierr.FromResponse(resp))
+	}
+
+	return &result, nil
+}
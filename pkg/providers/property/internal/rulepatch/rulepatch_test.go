@@ -0,0 +1,172 @@
+package rulepatch
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/edgegrid"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockAPIClient(t *testing.T, mockServer *httptest.Server) RulePatch {
+	serverURL, err := url.Parse(mockServer.URL)
+	require.NoError(t, err)
+	certPool := x509.NewCertPool()
+	certPool.AddCert(mockServer.Certificate())
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs: certPool,
+			},
+		},
+	}
+	s, err := session.New(session.WithClient(httpClient), session.WithSigner(&edgegrid.Config{Host: serverURL.Host}))
+	require.NoError(t, err)
+	return Client(s)
+}
+
+func TestPatchRuleTree(t *testing.T) {
+	tests := map[string]struct {
+		params              PatchRuleTreeRequest
+		responseStatus      int
+		responseBody        string
+		expectedPath        string
+		expectedRequestBody string
+		expectedResponse    *papi.UpdateRulesResponse
+		withError           func(*testing.T, error)
+	}{
+		"200 OK": {
+			params: PatchRuleTreeRequest{
+				PropertyID:      "prp_175780",
+				PropertyVersion: 3,
+				ContractID:      "ctr_1-1TJZFW",
+				GroupID:         "grp_15166",
+				Etag:            "a9dfe78cf93090516bde891d009eaf57",
+				ValidateRules:   true,
+				Operations: []Operation{
+					{Op: OpReplace, Path: "/rules/children/0/behaviors/0/options/ttl", Value: json.RawMessage(`"1d"`)},
+					{Op: OpRemove, Path: "/rules/children/1"},
+				},
+			},
+			responseStatus: http.StatusOK,
+			responseBody: `{
+    "accountId": "act_1-1TJZFB",
+    "contractId": "ctr_1-1TJZFW",
+    "groupId": "grp_15166",
+    "propertyId": "prp_175780",
+    "propertyVersion": 3,
+    "etag": "71573b922a87abc3f1d2d7d1e5e4b8a2",
+    "ruleFormat": "v2023-01-05",
+    "rules": {"name": "default"},
+    "errors": [{"type": "https://problems.luna.akamaiapis.net/papi/v0/validation/attribute_required", "title": "Missing required attribute", "errorLocation": "#/rules/behaviors/0"}]
+}`,
+			expectedPath: "/papi/v1/properties/prp_175780/versions/3/rules?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			expectedRequestBody: `[
+    {"op": "replace", "path": "/rules/children/0/behaviors/0/options/ttl", "value": "1d"},
+    {"op": "remove", "path": "/rules/children/1"}
+]`,
+			expectedResponse: &papi.UpdateRulesResponse{
+				AccountID:       "act_1-1TJZFB",
+				ContractID:      "ctr_1-1TJZFW",
+				GroupID:         "grp_15166",
+				PropertyID:      "prp_175780",
+				PropertyVersion: 3,
+				Etag:            "71573b922a87abc3f1d2d7d1e5e4b8a2",
+				RuleFormat:      "v2023-01-05",
+				Rules:           papi.Rules{Name: "default"},
+				Errors: []papi.RuleError{{
+					Type:          "https://problems.luna.akamaiapis.net/papi/v0/validation/attribute_required",
+					Title:         "Missing required attribute",
+					ErrorLocation: "#/rules/behaviors/0",
+				}},
+			},
+		},
+		"412 etag mismatch": {
+			params: PatchRuleTreeRequest{
+				PropertyID:      "prp_175780",
+				PropertyVersion: 3,
+				Etag:            "a9dfe78cf93090516bde891d009eaf57",
+				Operations:      []Operation{{Op: OpRemove, Path: "/rules/children/1"}},
+			},
+			responseStatus:      http.StatusPreconditionFailed,
+			responseBody:        `{"type": "precondition_failed", "title": "Precondition Failed", "detail": "The etag does not match"}`,
+			expectedPath:        "/papi/v1/properties/prp_175780/versions/3/rules?validateRules=false",
+			expectedRequestBody: `[{"op": "remove", "path": "/rules/children/1"}]`,
+			withError: func(t *testing.T, err error) {
+				var papiErr *papi.Error
+				require.True(t, errors.As(err, &papiErr))
+				assert.Equal(t, http.StatusPreconditionFailed, papiErr.StatusCode)
+				assert.True(t, errors.Is(err, ErrPatchRuleTree))
+				assert.True(t, errors.Is(err, ErrEtagMismatch))
+			},
+		},
+		"400 invalid patch": {
+			params: PatchRuleTreeRequest{
+				PropertyID:      "prp_175780",
+				PropertyVersion: 3,
+				Etag:            "a9dfe78cf93090516bde891d009eaf57",
+				ValidateRules:   true,
+				Operations:      []Operation{{Op: OpRemove, Path: "/rules/children/9"}},
+			},
+			responseStatus:      http.StatusBadRequest,
+			responseBody:        `{"type": "bad_request", "title": "Bad Request", "detail": "The path does not exist"}`,
+			expectedPath:        "/papi/v1/properties/prp_175780/versions/3/rules",
+			expectedRequestBody: `[{"op": "remove", "path": "/rules/children/9"}]`,
+			withError: func(t *testing.T, err error) {
+				var papiErr *papi.Error
+				require.True(t, errors.As(err, &papiErr))
+				assert.Equal(t, http.StatusBadRequest, papiErr.StatusCode)
+				assert.False(t, errors.Is(err, ErrEtagMismatch))
+			},
+		},
+		"validation error": {
+			params: PatchRuleTreeRequest{
+				PropertyID:      "prp_175780",
+				PropertyVersion: 3,
+				Etag:            "a9dfe78cf93090516bde891d009eaf57",
+				Operations:      []Operation{{Op: OpMove, Path: "/rules/children/1"}},
+			},
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, papi.ErrStructValidation))
+				assert.Contains(t, err.Error(), "From: cannot be blank")
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodPatch, r.Method)
+				assert.Equal(t, "application/json-patch+json", r.Header.Get("Content-Type"))
+				assert.Equal(t, test.params.Etag, r.Header.Get("If-Match"))
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, test.expectedRequestBody, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer)
+			result, err := client.PatchRuleTree(context.Background(), test.params)
+			if test.withError != nil {
+				test.withError(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
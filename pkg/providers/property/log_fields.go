@@ -292,11 +292,11 @@ func (res updatePropertyVersionHostnamesResFields) Fields() log.Fields {
 		certs := map[string]interface{}{}
 		certs["validation_cname.hostname"] = hn.CertStatus.ValidationCname.Hostname
 		certs["validation_cname.target"] = hn.CertStatus.ValidationCname.Hostname
-		if len(hn.CertStatus.Staging) > 0 {
-			certs["staging_status"] = hn.CertStatus.Staging[0].Status
+		if status := networkCertStatus(&hn.CertStatus, papi.ActivationNetworkStaging); status != "" {
+			certs["staging_status"] = status
 		}
-		if len(hn.CertStatus.Production) > 0 {
-			certs["production_status"] = hn.CertStatus.Production[0].Status
+		if status := networkCertStatus(&hn.CertStatus, papi.ActivationNetworkProduction); status != "" {
+			certs["production_status"] = status
 		}
 		//hostnames["cert_status"] = certs
 	}
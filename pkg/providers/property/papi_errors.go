@@ -77,6 +77,8 @@ var (
 	ErrPropertyNotFound = errors.New("property not found")
 	// ErrRulesNotFound is returned when no rules were found
 	ErrRulesNotFound = errors.New("property rules not found")
+	// ErrRuleModified is returned when the rule managed by akamai_property_rules_patch was modified since it was read
+	ErrRuleModified = errors.New("property rule modified outside of the resource")
 
 	// PAPI property version errors
 
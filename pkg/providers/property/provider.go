@@ -12,8 +12,12 @@ import (
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/papi"
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/str"
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/providers/property/internal/hostnamebucket"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/providers/property/internal/rulepatch"
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/subprovider"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -39,6 +43,9 @@ var (
 	client     papi.PAPI
 	hapiClient hapi.HAPI
 	iamClient  iam.IAM
+
+	hostnameBucketClient hostnamebucket.HostnameBucket
+	rulePatchClient      rulepatch.RulePatch
 )
 
 // NewSubprovider returns a new property subprovider
@@ -70,6 +77,22 @@ func HapiClient(meta meta.Meta) hapi.HAPI {
 	return hapi.Client(meta.Session())
 }
 
+// HostnameBucketClient returns the property hostname bucket interface
+func HostnameBucketClient(meta meta.Meta) hostnamebucket.HostnameBucket {
+	if hostnameBucketClient != nil {
+		return hostnameBucketClient
+	}
+	return hostnamebucket.Client(meta.Session())
+}
+
+// RulePatchClient returns the property rule tree patch interface
+func RulePatchClient(meta meta.Meta) rulepatch.RulePatch {
+	if rulePatchClient != nil {
+		return rulePatchClient
+	}
+	return rulepatch.Client(meta.Session())
+}
+
 // IAMClient returns the IAM interface
 func IAMClient(meta meta.Meta) iam.IAM {
 	if iamClient != nil {
@@ -87,6 +110,7 @@ func (p *Subprovider) SDKResources() map[string]*schema.Resource {
 		"akamai_property_activation":         resourcePropertyActivation(),
 		"akamai_property_include":            resourcePropertyInclude(),
 		"akamai_property_include_activation": resourcePropertyIncludeActivation(),
+		"akamai_property_rules_patch":        resourcePropertyRulesPatch(),
 	}
 }
 
@@ -102,6 +126,7 @@ func (p *Subprovider) SDKDataSources() map[string]*schema.Resource {
 		"akamai_properties_search":           dataSourcePropertiesSearch(),
 		"akamai_property":                    dataSourceProperty(),
 		"akamai_property_activation":         dataSourcePropertyActivation(),
+		"akamai_property_export":             dataSourcePropertyExport(),
 		"akamai_property_hostnames":          dataSourcePropertyHostnames(),
 		"akamai_property_include_activation": dataSourcePropertyIncludeActivation(),
 		"akamai_property_include_parents":    dataSourcePropertyIncludeParents(),
@@ -112,6 +137,7 @@ func (p *Subprovider) SDKDataSources() map[string]*schema.Resource {
 		"akamai_property_rules":              dataSourcePropertyRules(),
 		"akamai_property_rules_builder":      dataSourcePropertyRulesBuilder(),
 		"akamai_property_rules_template":     dataSourcePropertyRulesTemplate(),
+		"akamai_property_rules_upgrade":      dataSourcePropertyRulesUpgrade(),
 	}
 }
 
@@ -119,6 +145,7 @@ func (p *Subprovider) SDKDataSources() map[string]*schema.Resource {
 func (p *Subprovider) FrameworkResources() []func() resource.Resource {
 	return []func() resource.Resource{
 		NewBootstrapResource,
+		NewHostnamesResource,
 	}
 }
 
@@ -129,6 +156,18 @@ func (p *Subprovider) FrameworkDataSources() []func() datasource.DataSource {
 	}
 }
 
+// EphemeralResources returns the property ephemeral resources implemented using terraform-plugin-framework
+func (p *Subprovider) EphemeralResources() []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{}
+}
+
+// Functions returns the property provider-defined functions implemented using terraform-plugin-framework
+func (p *Subprovider) Functions() []func() function.Function {
+	return []func() function.Function{
+		NewRuleTreeMergeFunction,
+	}
+}
+
 // compactJSON converts a JSON-encoded byte slice to a compact form (so our JSON fixtures can be readable)
 func compactJSON(encoded []byte) string {
 	buf := bytes.Buffer{}
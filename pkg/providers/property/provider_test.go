@@ -8,6 +8,8 @@ import (
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/iam"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/papi"
 	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/testutils"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/providers/property/internal/hostnamebucket"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/providers/property/internal/rulepatch"
 )
 
 func TestMain(m *testing.M) {
@@ -46,6 +48,30 @@ func useIam(iamCli iam.IAM, f func()) {
 	f()
 }
 
+// useHostnameBucket swaps out the hostname bucket client for the duration of the given func
+func useHostnameBucket(bucketCli hostnamebucket.HostnameBucket, f func()) {
+	origBucket := hostnameBucketClient
+	hostnameBucketClient = bucketCli
+
+	defer func() {
+		hostnameBucketClient = origBucket
+	}()
+
+	f()
+}
+
+// useRulePatch swaps out the rule tree patch client for the duration of the given func
+func useRulePatch(patchCli rulepatch.RulePatch, f func()) {
+	origPatch := rulePatchClient
+	rulePatchClient = patchCli
+
+	defer func() {
+		rulePatchClient = origPatch
+	}()
+
+	f()
+}
+
 // Wrapper to intercept the papi.Mock's call of t.FailNow(). The Terraform test driver runs the provider code on
 // goroutines other than the one created for the test. When t.FailNow() is called from any other goroutine, it causes
 // the test to hang because the TF test driver is still waiting to serve requests. Mockery's failure message neglects to
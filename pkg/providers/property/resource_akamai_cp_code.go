@@ -244,6 +244,7 @@ func resourceCPCodeUpdate(ctx context.Context, d *schema.ResourceData, m interfa
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	invalidateCPCodesCache(contractID, groupID)
 
 	// Because we use CPRG API for update, we need to ensure that changes are also present when fetching cpCode with PAPI
 	if err := waitForCPCodeNameUpdate(ctx, client, contractID, groupID, d.Id(), name); err != nil {
@@ -319,6 +320,7 @@ func createCPCode(ctx context.Context, client papi.PAPI, name, productID, contra
 	if err != nil {
 		return "", err
 	}
+	invalidateCPCodesCache(contractID, groupID)
 
 	return r.CPCodeID, nil
 }
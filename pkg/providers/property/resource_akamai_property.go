@@ -58,6 +58,7 @@ func resourceProperty() *schema.Resource {
 			hostNamesCustomDiff,
 			propertyRulesCustomDiff,
 			setPropertyVersionsComputed,
+			setRulesDiff,
 		),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourcePropertyImport,
@@ -115,6 +116,7 @@ func resourceProperty() *schema.Resource {
 				DiffSuppressFunc: diffSuppressPropertyRules,
 				StateFunc:        rulesStateFunc,
 			},
+			"rules_diff": rulesDiffSchema,
 			"version_notes": {
 				Type:             schema.TypeString,
 				Optional:         true,
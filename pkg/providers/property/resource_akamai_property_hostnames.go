@@ -0,0 +1,637 @@
+package property
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/papi"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/framework/modifiers"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/providers/property/internal/hostnamebucket"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &hostnamesResource{}
+	_ resource.ResourceWithConfigure   = &hostnamesResource{}
+	_ resource.ResourceWithImportState = &hostnamesResource{}
+)
+
+var (
+	// HostnamesPollInterval is the interval for polling the hostname activations and the certificate statuses
+	HostnamesPollInterval = time.Minute
+
+	// hostnamesTimeout is the default time to wait for the hostname activation and the deployment of the certificates
+	hostnamesTimeout = 2 * time.Hour
+
+	// hostnamesPageSize is the number of hostnames read with each request
+	hostnamesPageSize = 500
+
+	// certStatusAttributeTypes are the types of the attributes of the cert_status elements
+	certStatusAttributeTypes = map[string]attr.Type{
+		"cname_from":        types.StringType,
+		"hostname":          types.StringType,
+		"target":            types.StringType,
+		"staging_status":    types.StringType,
+		"production_status": types.StringType,
+	}
+)
+
+// certStatusDeployed is the status of a Default DV certificate deployed on the network
+const certStatusDeployed = "DEPLOYED"
+
+type (
+	hostnamesResource struct {
+		meta meta.Meta
+	}
+
+	hostnamesResourceModel struct {
+		PropertyID   types.String     `tfsdk:"property_id"`
+		ContractID   types.String     `tfsdk:"contract_id"`
+		GroupID      types.String     `tfsdk:"group_id"`
+		Network      types.String     `tfsdk:"network"`
+		Hostnames    []hostnamesModel `tfsdk:"hostnames"`
+		Note         types.String     `tfsdk:"note"`
+		NotifyEmails types.List       `tfsdk:"notify_emails"`
+		CertStatus   types.List       `tfsdk:"cert_status"`
+		Timeouts     timeouts.Value   `tfsdk:"timeouts"`
+	}
+
+	// hostnamesModel represents a hostname of the property
+	hostnamesModel struct {
+		CnameFrom            types.String `tfsdk:"cname_from"`
+		CnameTo              types.String `tfsdk:"cname_to"`
+		CertProvisioningType types.String `tfsdk:"cert_provisioning_type"`
+	}
+
+	// certStatusModel represents the status of the Default DV certificate of a hostname
+	certStatusModel struct {
+		CnameFrom        types.String `tfsdk:"cname_from"`
+		Hostname         types.String `tfsdk:"hostname"`
+		Target           types.String `tfsdk:"target"`
+		StagingStatus    types.String `tfsdk:"staging_status"`
+		ProductionStatus types.String `tfsdk:"production_status"`
+	}
+)
+
+// NewHostnamesResource returns a new property hostnames resource
+func NewHostnamesResource() resource.Resource { return &hostnamesResource{} }
+
+func (r *hostnamesResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "akamai_property_hostnames"
+}
+
+func (r *hostnamesResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			resp.Diagnostics.AddError(
+				"Unexpected Resource Configure Type",
+				fmt.Sprintf("Expected meta.Meta, got: %T. Please report this issue to the provider developers.",
+					req.ProviderData))
+		}
+	}()
+	r.meta = meta.Must(req.ProviderData)
+}
+
+func (r *hostnamesResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Hostnames of a property using the hostname bucket, activated on the network without creating " +
+			"new property versions. Only the hostnames in the configuration are managed, so several resources can manage " +
+			"the hostnames of a shared property.",
+		Attributes: map[string]schema.Attribute{
+			"property_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the property, created with the hostname bucket enabled.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					modifiers.StringUseStateIf(modifiers.EqualUpToPrefixFunc("prp_")),
+				},
+			},
+			"contract_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The contract ID of the property.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					modifiers.StringUseStateIf(modifiers.EqualUpToPrefixFunc("ctr_")),
+				},
+			},
+			"group_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The group ID of the property.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					modifiers.StringUseStateIf(modifiers.EqualUpToPrefixFunc("grp_")),
+				},
+			},
+			"network": schema.StringAttribute{
+				Required:    true,
+				Description: "The network the hostnames are activated on, STAGING or PRODUCTION.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(string(papi.ActivationNetworkStaging), string(papi.ActivationNetworkProduction)),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"hostnames": schema.SetNestedAttribute{
+				Required:    true,
+				Description: "The hostnames managed by the resource. Other hostnames of the property are kept.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"cname_from": schema.StringAttribute{
+							Required:    true,
+							Description: "The hostname your end users see, e.g. www.example.com.",
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"cname_to": schema.StringAttribute{
+							Required:    true,
+							Description: "The edge hostname the hostname points to, e.g. www.example.com.edgekey.net.",
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"cert_provisioning_type": schema.StringAttribute{
+							Optional: true,
+							Computed: true,
+							Default:  stringdefault.StaticString(hostnamebucket.CertProvisioningTypeCPSManaged),
+							Description: "The certificate of the hostname, CPS_MANAGED or DEFAULT for a Default Domain Validation certificate. " +
+								"The resource waits until the DEFAULT certificates are deployed on the network.",
+							Validators: []validator.String{
+								stringvalidator.OneOf(hostnamebucket.CertProvisioningTypeCPSManaged, hostnamebucket.CertProvisioningTypeDefault),
+							},
+						},
+					},
+				},
+			},
+			"note": schema.StringAttribute{
+				Optional:    true,
+				Description: "The note of the hostname activations.",
+			},
+			"notify_emails": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "The email addresses notified of the hostname activations.",
+			},
+			"cert_status": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The statuses of the Default DV certificates of the hostnames.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"cname_from": schema.StringAttribute{
+							Computed:    true,
+							Description: "The hostname.",
+						},
+						"hostname": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the CNAME record used to validate the domain of the certificate.",
+						},
+						"target": schema.StringAttribute{
+							Computed:    true,
+							Description: "The target of the CNAME record used to validate the domain of the certificate.",
+						},
+						"staging_status": schema.StringAttribute{
+							Computed:    true,
+							Description: "The status of the certificate on the staging network.",
+						},
+						"production_status": schema.StringAttribute{
+							Computed:    true,
+							Description: "The status of the certificate on the production network.",
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *hostnamesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Creating Property Hostnames resource")
+	var plan hostnamesResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := plan.Timeouts.Create(ctx, hostnamesTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.apply(ctx, &plan, plan.Hostnames, nil, timeout); err != nil {
+		resp.Diagnostics.AddError("create property hostnames failed", err.Error())
+		return
+	}
+	if err := r.read(ctx, &plan); err != nil {
+		resp.Diagnostics.AddError("read property hostnames failed", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *hostnamesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "Reading Property Hostnames resource")
+	var state hostnamesResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.read(ctx, &state); err != nil {
+		var papiErr *papi.Error
+		if errors.As(err, &papiErr) && papiErr.StatusCode == http.StatusNotFound {
+			tflog.Warn(ctx, fmt.Sprintf("property %s not found, removing from state", state.PropertyID.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("read property hostnames failed", err.Error())
+		return
+	}
+	if len(state.Hostnames) == 0 {
+		tflog.Warn(ctx, fmt.Sprintf("none of the hostnames is active on property %s, removing from state", state.PropertyID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *hostnamesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Debug(ctx, "Updating Property Hostnames resource")
+	var plan, state hostnamesResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := plan.Timeouts.Update(ctx, hostnamesTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// added and changed hostnames are submitted in the add list, which also updates existing hostnames
+	current := make(map[string]hostnamesModel, len(state.Hostnames))
+	for _, hostname := range state.Hostnames {
+		current[strings.ToLower(hostname.CnameFrom.ValueString())] = hostname
+	}
+	var add []hostnamesModel
+	for _, hostname := range plan.Hostnames {
+		key := strings.ToLower(hostname.CnameFrom.ValueString())
+		if old, ok := current[key]; !ok || !old.CnameTo.Equal(hostname.CnameTo) || !old.CertProvisioningType.Equal(hostname.CertProvisioningType) {
+			add = append(add, hostname)
+		}
+		delete(current, key)
+	}
+	remove := make([]string, 0, len(current))
+	for _, hostname := range current {
+		remove = append(remove, hostname.CnameFrom.ValueString())
+	}
+	slices.Sort(remove)
+
+	if err := r.apply(ctx, &plan, add, remove, timeout); err != nil {
+		resp.Diagnostics.AddError("update property hostnames failed", err.Error())
+		return
+	}
+	if err := r.read(ctx, &plan); err != nil {
+		resp.Diagnostics.AddError("read property hostnames failed", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *hostnamesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Deleting Property Hostnames resource")
+	var state hostnamesResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := state.Timeouts.Delete(ctx, hostnamesTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remove := make([]string, 0, len(state.Hostnames))
+	for _, hostname := range state.Hostnames {
+		remove = append(remove, hostname.CnameFrom.ValueString())
+	}
+	slices.Sort(remove)
+	if err := r.apply(ctx, &state, nil, remove, timeout); err != nil {
+		resp.Diagnostics.AddError("delete property hostnames failed", err.Error())
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *hostnamesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	tflog.Debug(ctx, "Importing Property Hostnames resource")
+
+	parts := strings.Split(req.ID, ":")
+	if len(parts) != 4 && len(parts) != 5 {
+		resp.Diagnostics.AddError("incorrect import ID",
+			"import ID should have format: property_id:contract_id:group_id:network[:hostname1,hostname2,...], "+
+				"all hostnames of the property on the network are imported if no hostnames are given")
+		return
+	}
+	data := hostnamesResourceModel{
+		PropertyID:   types.StringValue(parts[0]),
+		ContractID:   types.StringValue(parts[1]),
+		GroupID:      types.StringValue(parts[2]),
+		Network:      types.StringValue(strings.ToUpper(parts[3])),
+		Note:         types.StringNull(),
+		NotifyEmails: types.ListNull(types.StringType),
+		Timeouts:     timeouts.Value{Object: types.ObjectNull(map[string]attr.Type{"create": types.StringType, "update": types.StringType, "delete": types.StringType})},
+	}
+
+	hostnames, err := r.listHostnames(ctx, &data, false)
+	if err != nil {
+		resp.Diagnostics.AddError("import property hostnames failed", err.Error())
+		return
+	}
+	var names []string
+	if len(parts) == 5 {
+		names = strings.Split(parts[4], ",")
+	}
+	for _, hostname := range hostnames {
+		if len(names) > 0 && !slices.ContainsFunc(names, func(name string) bool { return strings.EqualFold(name, hostname.CnameFrom) }) {
+			continue
+		}
+		cnameTo, certType := networkHostname(hostname, data.Network.ValueString())
+		if cnameTo == "" {
+			continue
+		}
+		data.Hostnames = append(data.Hostnames, hostnamesModel{
+			CnameFrom:            types.StringValue(hostname.CnameFrom),
+			CnameTo:              types.StringValue(cnameTo),
+			CertProvisioningType: types.StringValue(certType),
+		})
+	}
+	if len(data.Hostnames) == 0 {
+		resp.Diagnostics.AddError("import property hostnames failed",
+			fmt.Sprintf("no matching hostnames found on property %s on network %s", data.PropertyID.ValueString(), data.Network.ValueString()))
+		return
+	}
+	if err := r.read(ctx, &data); err != nil {
+		resp.Diagnostics.AddError("import property hostnames failed", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// apply adds and removes the hostnames, and waits until the hostname activation completes
+// and the Default DV certificates of the added hostnames are deployed
+func (r *hostnamesResource) apply(ctx context.Context, data *hostnamesResourceModel, add []hostnamesModel, remove []string, timeout time.Duration) error {
+	if len(add) == 0 && len(remove) == 0 {
+		tflog.Debug(ctx, "no hostnames to add or remove")
+		return nil
+	}
+	client := HostnameBucketClient(r.meta)
+
+	var notifyEmails []string
+	if diags := data.NotifyEmails.ElementsAs(ctx, &notifyEmails, false); diags.HasError() {
+		return fmt.Errorf("could not read notify_emails: %v", diags.Errors())
+	}
+	body := hostnamebucket.PatchPropertyHostnamesRequestBody{
+		Network:      data.Network.ValueString(),
+		Note:         data.Note.ValueString(),
+		NotifyEmails: notifyEmails,
+		Remove:       remove,
+	}
+	var defaultCerts []string
+	for _, hostname := range add {
+		body.Add = append(body.Add, hostnamebucket.AddHostname{
+			CnameFrom:            hostname.CnameFrom.ValueString(),
+			CnameType:            hostnamebucket.CnameTypeEdgeHostname,
+			CertProvisioningType: hostname.CertProvisioningType.ValueString(),
+			CnameTo:              hostname.CnameTo.ValueString(),
+		})
+		if hostname.CertProvisioningType.ValueString() == hostnamebucket.CertProvisioningTypeDefault {
+			defaultCerts = append(defaultCerts, hostname.CnameFrom.ValueString())
+		}
+	}
+	slices.SortFunc(body.Add, func(a, b hostnamebucket.AddHostname) int { return strings.Compare(a.CnameFrom, b.CnameFrom) })
+
+	resp, err := client.PatchPropertyHostnames(ctx, hostnamebucket.PatchPropertyHostnamesRequest{
+		PropertyID: data.PropertyID.ValueString(),
+		ContractID: data.ContractID.ValueString(),
+		GroupID:    data.GroupID.ValueString(),
+		Body:       body,
+	})
+	if err != nil {
+		return err
+	}
+	activationID, err := resp.ActivationID()
+	if err != nil {
+		return err
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	if err := r.waitForActivation(timeoutCtx, data, activationID); err != nil {
+		return err
+	}
+	return r.waitForCertificates(timeoutCtx, data, defaultCerts)
+}
+
+// waitForActivation waits until the hostname activation is active
+func (r *hostnamesResource) waitForActivation(ctx context.Context, data *hostnamesResourceModel, activationID string) error {
+	client := HostnameBucketClient(r.meta)
+	for {
+		resp, err := client.GetPropertyHostnameActivation(ctx, hostnamebucket.GetPropertyHostnameActivationRequest{
+			PropertyID:           data.PropertyID.ValueString(),
+			HostnameActivationID: activationID,
+			ContractID:           data.ContractID.ValueString(),
+			GroupID:              data.GroupID.ValueString(),
+		})
+		if err != nil {
+			return err
+		}
+		var status string
+		if len(resp.HostnameActivations.Items) > 0 {
+			status = resp.HostnameActivations.Items[0].Status
+		}
+		switch status {
+		case hostnamebucket.ActivationStatusActive:
+			return nil
+		case hostnamebucket.ActivationStatusFailed, hostnamebucket.ActivationStatusAborted:
+			return fmt.Errorf("hostname activation %s of property %s: status %s", activationID, data.PropertyID.ValueString(), status)
+		}
+		tflog.Debug(ctx, fmt.Sprintf("waiting for hostname activation %s, status %s", activationID, status))
+
+		select {
+		case <-time.After(HostnamesPollInterval):
+			continue
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for hostname activation %s of property %s: %w", activationID, data.PropertyID.ValueString(), ctx.Err())
+		}
+	}
+}
+
+// waitForCertificates waits until the Default DV certificates of the hostnames are deployed on the network
+func (r *hostnamesResource) waitForCertificates(ctx context.Context, data *hostnamesResourceModel, names []string) error {
+	for len(names) > 0 {
+		hostnames, err := r.listHostnames(ctx, data, true)
+		if err != nil {
+			return err
+		}
+		var pending []string
+		for _, hostname := range hostnames {
+			if !slices.ContainsFunc(names, func(name string) bool { return strings.EqualFold(name, hostname.CnameFrom) }) {
+				continue
+			}
+			status := networkCertStatus(hostname.CertStatus, papi.ActivationNetwork(data.Network.ValueString()))
+			if status == certStatusDeployed {
+				continue
+			}
+			pending = append(pending, hostname.CnameFrom)
+			fields := map[string]any{"hostname": hostname.CnameFrom, "status": status}
+			if hostname.CertStatus != nil {
+				fields["validation_cname"] = hostname.CertStatus.ValidationCname.Hostname
+				fields["validation_target"] = hostname.CertStatus.ValidationCname.Target
+			}
+			tflog.Debug(ctx, "waiting for the deployment of the Default DV certificate", fields)
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+
+		select {
+		case <-time.After(HostnamesPollInterval):
+			continue
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for the deployment of the Default DV certificates of hostnames %s: %w",
+				strings.Join(pending, ", "), ctx.Err())
+		}
+	}
+	return nil
+}
+
+// read sets the managed hostnames which are active on the network and the statuses of their certificates
+func (r *hostnamesResource) read(ctx context.Context, data *hostnamesResourceModel) error {
+	hostnames, err := r.listHostnames(ctx, data, true)
+	if err != nil {
+		return err
+	}
+	active := make(map[string]hostnamebucket.Hostname, len(hostnames))
+	for _, hostname := range hostnames {
+		active[strings.ToLower(hostname.CnameFrom)] = hostname
+	}
+
+	managed := make([]hostnamesModel, 0, len(data.Hostnames))
+	certStatuses := make([]certStatusModel, 0)
+	for _, hostname := range data.Hostnames {
+		found, ok := active[strings.ToLower(hostname.CnameFrom.ValueString())]
+		if !ok {
+			continue
+		}
+		cnameTo, certType := networkHostname(found, data.Network.ValueString())
+		if cnameTo == "" {
+			continue
+		}
+		managed = append(managed, hostnamesModel{
+			CnameFrom:            hostname.CnameFrom,
+			CnameTo:              types.StringValue(cnameTo),
+			CertProvisioningType: types.StringValue(certType),
+		})
+		if found.CertStatus != nil {
+			certStatus := certStatusModel{
+				CnameFrom:        hostname.CnameFrom,
+				Hostname:         types.StringValue(found.CertStatus.ValidationCname.Hostname),
+				Target:           types.StringValue(found.CertStatus.ValidationCname.Target),
+				StagingStatus:    types.StringNull(),
+				ProductionStatus: types.StringNull(),
+			}
+			if status := networkCertStatus(found.CertStatus, papi.ActivationNetworkStaging); status != "" {
+				certStatus.StagingStatus = types.StringValue(status)
+			}
+			if status := networkCertStatus(found.CertStatus, papi.ActivationNetworkProduction); status != "" {
+				certStatus.ProductionStatus = types.StringValue(status)
+			}
+			certStatuses = append(certStatuses, certStatus)
+		}
+	}
+	slices.SortFunc(certStatuses, func(a, b certStatusModel) int {
+		return strings.Compare(a.CnameFrom.ValueString(), b.CnameFrom.ValueString())
+	})
+
+	data.Hostnames = managed
+	certStatus, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: certStatusAttributeTypes}, certStatuses)
+	if diags.HasError() {
+		return fmt.Errorf("could not set cert_status: %v", diags.Errors())
+	}
+	data.CertStatus = certStatus
+	return nil
+}
+
+// listHostnames returns all hostnames of the property on the network
+func (r *hostnamesResource) listHostnames(ctx context.Context, data *hostnamesResourceModel, includeCertStatus bool) ([]hostnamebucket.Hostname, error) {
+	client := HostnameBucketClient(r.meta)
+	var hostnames []hostnamebucket.Hostname
+	for {
+		resp, err := client.ListPropertyHostnames(ctx, hostnamebucket.ListPropertyHostnamesRequest{
+			PropertyID:        data.PropertyID.ValueString(),
+			ContractID:        data.ContractID.ValueString(),
+			GroupID:           data.GroupID.ValueString(),
+			Network:           data.Network.ValueString(),
+			IncludeCertStatus: includeCertStatus,
+			Offset:            len(hostnames),
+			Limit:             hostnamesPageSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+		hostnames = append(hostnames, resp.Hostnames.Items...)
+		if len(resp.Hostnames.Items) == 0 || len(hostnames) >= resp.Hostnames.TotalItems {
+			return hostnames, nil
+		}
+	}
+}
+
+// networkHostname returns the edge hostname and the certificate type of the hostname on the network,
+// or empty strings if the hostname is not active on the network
+func networkHostname(hostname hostnamebucket.Hostname, network string) (string, string) {
+	if network == string(papi.ActivationNetworkProduction) {
+		return hostname.ProductionCnameTo, hostname.ProductionCertType
+	}
+	return hostname.StagingCnameTo, hostname.StagingCertType
+}
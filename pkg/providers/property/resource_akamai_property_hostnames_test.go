@@ -0,0 +1,223 @@
+package property
+
+import (
+	"regexp"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/papi"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/test"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/testutils"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/providers/property/internal/hostnamebucket"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/mock"
+)
+
+// mockHostnameBucket keeps the hostnames of the bucket, so that the patches are reflected by the listed hostnames
+type mockHostnameBucket struct {
+	*hostnamebucket.Mock
+	hostnames map[string]hostnamebucket.Hostname
+	// pendingCerts is the number of list requests the certificate of a hostname stays pending for
+	pendingCerts map[string]int
+}
+
+func newMockHostnameBucket() *mockHostnameBucket {
+	return &mockHostnameBucket{
+		Mock: &hostnamebucket.Mock{},
+		hostnames: map[string]hostnamebucket.Hostname{
+			"other.example.com": {
+				CnameFrom:       "other.example.com",
+				CnameType:       hostnamebucket.CnameTypeEdgeHostname,
+				StagingCertType: hostnamebucket.CertProvisioningTypeCPSManaged,
+				StagingCnameTo:  "other.example.com.edgekey.net",
+			},
+		},
+		pendingCerts: map[string]int{},
+	}
+}
+
+func (b *mockHostnameBucket) mockList() {
+	anyContext := mock.AnythingOfType("*context.valueCtx")
+	resp := &hostnamebucket.ListPropertyHostnamesResponse{}
+	b.On("ListPropertyHostnames", anyContext, mock.MatchedBy(func(req hostnamebucket.ListPropertyHostnamesRequest) bool {
+		return req.PropertyID == "prp_1" && req.ContractID == "ctr_1" && req.GroupID == "grp_1" && req.Network == "STAGING"
+	})).Run(func(args mock.Arguments) {
+		req := args.Get(1).(hostnamebucket.ListPropertyHostnamesRequest)
+		items := make([]hostnamebucket.Hostname, 0, len(b.hostnames))
+		for _, hostname := range b.hostnames {
+			if req.IncludeCertStatus && hostname.StagingCertType == hostnamebucket.CertProvisioningTypeDefault {
+				status := certStatusDeployed
+				if b.pendingCerts[hostname.CnameFrom] > 0 {
+					b.pendingCerts[hostname.CnameFrom]--
+					status = "PENDING"
+				}
+				hostname.CertStatus = &papi.CertStatusItem{
+					ValidationCname: papi.ValidationCname{
+						Hostname: "_acme-challenge." + hostname.CnameFrom,
+						Target:   "ac.1234.example.com",
+					},
+					Staging:    []papi.StatusItem{{Status: status}},
+					Production: []papi.StatusItem{{Status: "NOT_STARTED"}},
+				}
+			}
+			items = append(items, hostname)
+		}
+		sort.Slice(items, func(i, j int) bool { return items[i].CnameFrom < items[j].CnameFrom })
+		*resp = hostnamebucket.ListPropertyHostnamesResponse{
+			PropertyID: req.PropertyID,
+			Hostnames: hostnamebucket.HostnameItems{
+				Items:            items,
+				CurrentItemCount: len(items),
+				TotalItems:       len(items),
+			},
+		}
+	}).Return(resp, nil)
+}
+
+func (b *mockHostnameBucket) mockPatch(add []hostnamebucket.AddHostname, remove []string, activationID string) {
+	b.On("PatchPropertyHostnames", mock.AnythingOfType("*context.valueCtx"), hostnamebucket.PatchPropertyHostnamesRequest{
+		PropertyID: "prp_1",
+		ContractID: "ctr_1",
+		GroupID:    "grp_1",
+		Body: hostnamebucket.PatchPropertyHostnamesRequestBody{
+			Network: "STAGING",
+			Note:    "add hostnames",
+			Add:     add,
+			Remove:  remove,
+		},
+	}).Run(func(mock.Arguments) {
+		for _, name := range remove {
+			delete(b.hostnames, name)
+		}
+		for _, hostname := range add {
+			b.hostnames[hostname.CnameFrom] = hostnamebucket.Hostname{
+				CnameFrom:       hostname.CnameFrom,
+				CnameType:       hostname.CnameType,
+				StagingCertType: hostname.CertProvisioningType,
+				StagingCnameTo:  hostname.CnameTo,
+			}
+			if hostname.CertProvisioningType == hostnamebucket.CertProvisioningTypeDefault {
+				b.pendingCerts[hostname.CnameFrom] = 1
+			}
+		}
+	}).Return(&hostnamebucket.PatchPropertyHostnamesResponse{
+		ActivationLink: "/papi/v1/properties/prp_1/hostname-activations/" + activationID + "?contractId=ctr_1&groupId=grp_1",
+	}, nil).Once()
+}
+
+func (b *mockHostnameBucket) mockActivation(activationID string, statuses ...string) {
+	for _, status := range statuses {
+		b.On("GetPropertyHostnameActivation", mock.AnythingOfType("*context.valueCtx"), hostnamebucket.GetPropertyHostnameActivationRequest{
+			PropertyID:           "prp_1",
+			HostnameActivationID: activationID,
+			ContractID:           "ctr_1",
+			GroupID:              "grp_1",
+		}).Return(&hostnamebucket.GetPropertyHostnameActivationResponse{
+			HostnameActivations: hostnamebucket.HostnameActivationItems{
+				Items: []hostnamebucket.HostnameActivation{{HostnameActivationID: activationID, Network: "STAGING", Status: status}},
+			},
+		}, nil).Once()
+	}
+}
+
+func TestResPropertyHostnames(t *testing.T) {
+	HostnamesPollInterval = time.Microsecond
+
+	tests := map[string]struct {
+		init  func(*mockHostnameBucket)
+		steps []resource.TestStep
+	}{
+		"create, import, update and delete": {
+			init: func(b *mockHostnameBucket) {
+				b.mockList()
+				b.mockPatch([]hostnamebucket.AddHostname{
+					{CnameFrom: "shop.example.com", CnameType: "EDGE_HOSTNAME", CertProvisioningType: "DEFAULT", CnameTo: "shop.example.com.edgekey.net"},
+					{CnameFrom: "www.example.com", CnameType: "EDGE_HOSTNAME", CertProvisioningType: "CPS_MANAGED", CnameTo: "www.example.com.edgekey.net"},
+				}, nil, "atv_1")
+				b.mockActivation("atv_1", "PENDING", "ACTIVE")
+				b.mockPatch([]hostnamebucket.AddHostname{
+					{CnameFrom: "api.example.com", CnameType: "EDGE_HOSTNAME", CertProvisioningType: "CPS_MANAGED", CnameTo: "api.example.com.edgekey.net"},
+					{CnameFrom: "www.example.com", CnameType: "EDGE_HOSTNAME", CertProvisioningType: "CPS_MANAGED", CnameTo: "www.example.com.edgesuite.net"},
+				}, []string{"shop.example.com"}, "atv_2")
+				b.mockActivation("atv_2", "ACTIVE")
+				b.mockPatch(nil, []string{"api.example.com", "www.example.com"}, "atv_3")
+				b.mockActivation("atv_3", "ACTIVE")
+			},
+			steps: []resource.TestStep{
+				{
+					Config: testutils.LoadFixtureString(t, "testdata/TestResPropertyHostnames/create.tf"),
+					Check: test.NewStateChecker("akamai_property_hostnames.test").
+						CheckEqual("property_id", "prp_1").
+						CheckEqual("network", "STAGING").
+						CheckEqual("hostnames.#", "2").
+						CheckEqual("cert_status.#", "1").
+						CheckEqual("cert_status.0.cname_from", "shop.example.com").
+						CheckEqual("cert_status.0.hostname", "_acme-challenge.shop.example.com").
+						CheckEqual("cert_status.0.target", "ac.1234.example.com").
+						CheckEqual("cert_status.0.staging_status", "DEPLOYED").
+						CheckEqual("cert_status.0.production_status", "NOT_STARTED").
+						Build(),
+				},
+				{
+					ImportState:                          true,
+					ImportStateId:                        "prp_1:ctr_1:grp_1:STAGING:www.example.com,shop.example.com",
+					ImportStateVerify:                    true,
+					ImportStateVerifyIdentifierAttribute: "property_id",
+					ImportStateVerifyIgnore:              []string{"note"},
+					ResourceName:                         "akamai_property_hostnames.test",
+				},
+				{
+					Config: testutils.LoadFixtureString(t, "testdata/TestResPropertyHostnames/update.tf"),
+					Check: test.NewStateChecker("akamai_property_hostnames.test").
+						CheckEqual("hostnames.#", "2").
+						CheckEqual("cert_status.#", "0").
+						Build(),
+				},
+			},
+		},
+		"activation failed": {
+			init: func(b *mockHostnameBucket) {
+				b.mockList()
+				b.mockPatch([]hostnamebucket.AddHostname{
+					{CnameFrom: "shop.example.com", CnameType: "EDGE_HOSTNAME", CertProvisioningType: "DEFAULT", CnameTo: "shop.example.com.edgekey.net"},
+					{CnameFrom: "www.example.com", CnameType: "EDGE_HOSTNAME", CertProvisioningType: "CPS_MANAGED", CnameTo: "www.example.com.edgekey.net"},
+				}, nil, "atv_1")
+				b.mockActivation("atv_1", "FAILED")
+			},
+			steps: []resource.TestStep{
+				{
+					Config:      testutils.LoadFixtureString(t, "testdata/TestResPropertyHostnames/create.tf"),
+					ExpectError: regexp.MustCompile("hostname activation atv_1 of property prp_1: status FAILED"),
+				},
+			},
+		},
+		"invalid network": {
+			steps: []resource.TestStep{
+				{
+					Config:      testutils.LoadFixtureString(t, "testdata/TestResPropertyHostnames/invalid_network.tf"),
+					ExpectError: regexp.MustCompile(`Attribute network value must be one of`),
+				},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			b := newMockHostnameBucket()
+			if tc.init != nil {
+				tc.init(b)
+			}
+
+			useHostnameBucket(b, func() {
+				resource.UnitTest(t, resource.TestCase{
+					ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+					IsUnitTest:               true,
+					Steps:                    tc.steps,
+				})
+			})
+
+			b.AssertExpectations(t)
+		})
+	}
+}
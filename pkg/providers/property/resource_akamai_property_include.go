@@ -34,6 +34,7 @@ func resourcePropertyInclude() *schema.Resource {
 		CustomizeDiff: customdiff.All(
 			propertyIncludeRulesCustomDiff,
 			setIncludeVersionsComputedOnRulesChange,
+			setRulesDiff,
 		),
 		Schema: map[string]*schema.Schema{
 			"contract_id": {
@@ -86,6 +87,7 @@ func resourcePropertyInclude() *schema.Resource {
 				DiffSuppressFunc: tf.DiffSuppressAny(suppressDefaultRules, diffSuppressPropertyRules),
 				StateFunc:        rulesStateFunc,
 			},
+			"rules_diff": rulesDiffSchema,
 			"rule_errors": {
 				Type:        schema.TypeString,
 				Computed:    true,
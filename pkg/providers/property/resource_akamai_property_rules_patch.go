@@ -0,0 +1,575 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/log"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/papi"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/str"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/providers/property/internal/rulepatch"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourcePropertyRulesPatch() *schema.Resource {
+	return &schema.Resource{
+		Description: "Applies a JSON Patch (RFC 6902), or a rule subtree at a path, to the rule tree of the latest property version. " +
+			"Unlike the rules of akamai_property, only the patched part of the rule tree is managed, " +
+			"so several resources can manage different rules of the same property.",
+		CreateContext: resourcePropertyRulesPatchCreate,
+		ReadContext:   resourcePropertyRulesPatchRead,
+		UpdateContext: resourcePropertyRulesPatchUpdate,
+		DeleteContext: resourcePropertyRulesPatchDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourcePropertyRulesPatchImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"property_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				StateFunc:   addPrefixToState("prp_"),
+				Description: "The ID of the property",
+			},
+			"contract_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				StateFunc:   addPrefixToState("ctr_"),
+				Description: "The contract ID of the property",
+			},
+			"group_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				StateFunc:   addPrefixToState("grp_"),
+				Description: "The group ID of the property",
+			},
+			"patch": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ExactlyOneOf:     []string{"patch", "path"},
+				ValidateDiagFunc: validateRulesPatch,
+				DiffSuppressFunc: diffSuppressJSON,
+				StateFunc:        rulesStateFunc,
+				Description: "JSON Patch operations (RFC 6902) applied to the rule tree, e.g. " +
+					`[{"op": "replace", "path": "/rules/children/Offload/behaviors/0/options/enabled", "value": true}]. ` +
+					"The children of a rule can be referenced by their names instead of their indexes. " +
+					"The patch is applied again only when it changes, and it is not reverted when the resource is destroyed.",
+			},
+			"path": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				RequiredWith:     []string{"rule"},
+				ValidateDiagFunc: validateRulePath,
+				Description: "JSON Pointer of the rule managed by the resource, e.g. /rules/children/Offload. " +
+					"The children of a rule can be referenced by their names instead of their indexes. " +
+					"The rule is added as the last child of its parent rule if it does not exist, and it is removed when the resource is destroyed.",
+			},
+			"rule": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				RequiredWith:     []string{"path"},
+				ValidateDiagFunc: validateRuleJSON,
+				DiffSuppressFunc: diffSuppressRule,
+				StateFunc:        rulesStateFunc,
+				Description:      "The rule at path as JSON, including its behaviors, criteria and children",
+			},
+			"property_version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The property version the patch was applied to",
+			},
+			"etag": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "The etag of the rule tree of the property version, used to detect concurrent modifications. " +
+					"The rule at path is not replaced or removed if it was modified since the rule tree was read with this etag",
+			},
+			"rule_errors": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        papiError(),
+				Description: "Rule validation errors",
+			},
+			"rule_warnings": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        papiError(),
+				Description: "Rule validation warnings",
+			},
+		},
+	}
+}
+
+// ruleTree is the rule tree of a property version with its etag
+type ruleTree struct {
+	property papi.Property
+	etag     string
+	// document is the rule tree as generic JSON, which the JSON Pointers of the patch refer to
+	document map[string]interface{}
+	errors   []*papi.Error
+	warnings []*papi.Error
+}
+
+func resourcePropertyRulesPatchCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	ctx = log.NewContext(ctx, meta.Must(m).Log("PAPI", "resourcePropertyRulesPatchCreate"))
+
+	propertyID := str.AddPrefix(d.Get("property_id").(string), "prp_")
+	if err := applyRulesPatch(ctx, d, m, rulesPatchOperations); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if path := d.Get("path").(string); path != "" {
+		d.SetId(fmt.Sprintf("%s:%s", propertyID, path))
+	} else {
+		d.SetId(propertyID)
+	}
+
+	return resourcePropertyRulesPatchRead(ctx, d, m)
+}
+
+func resourcePropertyRulesPatchRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	ctx = log.NewContext(ctx, meta.Must(m).Log("PAPI", "resourcePropertyRulesPatchRead"))
+	logger := log.FromContext(ctx)
+	client := Client(meta.Must(m))
+
+	tree, err := fetchRuleTree(ctx, client, rulesPatchProperty(d), false)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if path := d.Get("path").(string); path != "" {
+		rule, err := tree.ruleAt(path)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if rule == "" {
+			logger.Warnf("rule %s not found in version %d of property %s", path, tree.property.LatestVersion, tree.property.PropertyID)
+		}
+		if err := d.Set("rule", rule); err != nil {
+			return diag.FromErr(fmt.Errorf("%w: %s", tf.ErrValueSet, err.Error()))
+		}
+	}
+
+	if len(tree.errors) > 0 {
+		msg, err := json.MarshalIndent(papiErrorsToList(tree.errors), "", "\t")
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error marshaling API error: %s", err))
+		}
+		logger.Errorf("property has rule errors %s", msg)
+	}
+
+	attrs := map[string]interface{}{
+		"property_version": tree.property.LatestVersion,
+		"etag":             tree.etag,
+		"rule_errors":      papiErrorsToList(tree.errors),
+		"rule_warnings":    papiErrorsToList(tree.warnings),
+	}
+	if err := tf.SetAttrs(d, attrs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourcePropertyRulesPatchUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	ctx = log.NewContext(ctx, meta.Must(m).Log("PAPI", "resourcePropertyRulesPatchUpdate"))
+
+	if !d.HasChanges("patch", "rule") {
+		return nil
+	}
+	if err := applyRulesPatch(ctx, d, m, rulesPatchOperations); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourcePropertyRulesPatchRead(ctx, d, m)
+}
+
+func resourcePropertyRulesPatchDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	ctx = log.NewContext(ctx, meta.Must(m).Log("PAPI", "resourcePropertyRulesPatchDelete"))
+	logger := log.FromContext(ctx)
+
+	path := d.Get("path").(string)
+	if path == "" {
+		logger.Infof("patch of property %s is not reverted, removing from state", d.Get("property_id").(string))
+		return nil
+	}
+
+	err := applyRulesPatch(ctx, d, m, func(_ *schema.ResourceData, document map[string]interface{}) ([]rulepatch.Operation, error) {
+		resolved, _, err := resolveRulePath(document, path)
+		if err != nil {
+			logger.Infof("rule %s not found, nothing to remove: %s", path, err)
+			return nil, nil
+		}
+		return []rulepatch.Operation{{Op: rulepatch.OpRemove, Path: resolved}}, nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourcePropertyRulesPatchImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	ctx = log.NewContext(ctx, meta.Must(m).Log("PAPI", "resourcePropertyRulesPatchImport"))
+
+	parts := strings.SplitN(d.Id(), ":", 4)
+	if len(parts) != 4 || parts[3] == "" {
+		return nil, fmt.Errorf("import ID should have format: property_id:contract_id:group_id:path, e.g. prp_1:ctr_1:grp_1:/rules/children/Offload")
+	}
+	propertyID := str.AddPrefix(parts[0], "prp_")
+	attrs := map[string]interface{}{
+		"property_id": propertyID,
+		"contract_id": str.AddPrefix(parts[1], "ctr_"),
+		"group_id":    str.AddPrefix(parts[2], "grp_"),
+		"path":        parts[3],
+	}
+	if err := tf.SetAttrs(d, attrs); err != nil {
+		return nil, err
+	}
+	d.SetId(fmt.Sprintf("%s:%s", propertyID, parts[3]))
+
+	if diags := resourcePropertyRulesPatchRead(ctx, d, m); diags.HasError() {
+		return nil, fmt.Errorf("%s", diags[0].Summary)
+	}
+	if d.Get("rule").(string) == "" {
+		return nil, fmt.Errorf("rule %s not found in the latest version of property %s", parts[3], propertyID)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// applyRulesPatch applies the operations built by the given function to the rule tree of the latest editable property version
+func applyRulesPatch(ctx context.Context, d *schema.ResourceData, m interface{},
+	operations func(*schema.ResourceData, map[string]interface{}) ([]rulepatch.Operation, error)) error {
+	logger := log.FromContext(ctx)
+	client := Client(meta.Must(m))
+
+	tree, err := fetchRuleTree(ctx, client, rulesPatchProperty(d), true)
+	if err != nil {
+		return err
+	}
+	if d.Id() != "" {
+		etag, _ := d.GetChange("etag")
+		rule, _ := d.GetChange("rule")
+		if err := checkRuleConflict(tree, d.Get("path").(string), etag.(string), rule.(string)); err != nil {
+			return err
+		}
+	}
+	ops, err := operations(d, tree.document)
+	if err != nil {
+		return err
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+
+	res, err := RulePatchClient(meta.Must(m)).PatchRuleTree(ctx, rulepatch.PatchRuleTreeRequest{
+		PropertyID:      tree.property.PropertyID,
+		PropertyVersion: tree.property.LatestVersion,
+		ContractID:      tree.property.ContractID,
+		GroupID:         tree.property.GroupID,
+		Etag:            tree.etag,
+		ValidateRules:   true,
+		Operations:      ops,
+	})
+	if errors.Is(err, rulepatch.ErrEtagMismatch) {
+		return fmt.Errorf("rule tree of version %d of property %s was modified while it was patched, apply again to patch the latest rule tree: %w",
+			tree.property.LatestVersion, tree.property.PropertyID, err)
+	}
+	if err != nil {
+		logger.Error("could not patch property rules", "error", err)
+		return err
+	}
+
+	logger.Info("patched property rules", logFields(*res))
+	return nil
+}
+
+// checkRuleConflict returns ErrRuleModified if the rule at path was modified since the rule tree was read with the etag
+// stored in the state, so that the changes made outside of the resource are not overwritten without being planned.
+// The etag alone does not prove a conflict, as it changes with the patches of other rules of the property
+// and with the new versions, so the rule stored in the state is compared with the current one.
+// The patches are not checked, as they are meant to be applied on top of the changes of other resources.
+func checkRuleConflict(tree *ruleTree, path, etag, rule string) error {
+	if path == "" || etag == "" || etag == tree.etag {
+		return nil
+	}
+	current, err := tree.ruleAt(path)
+	if err != nil {
+		return err
+	}
+	if diffSuppressRule("", rule, current, nil) {
+		return nil
+	}
+	return fmt.Errorf("%w: rule %s of property %s was modified since it was read with etag %s (current etag %s), refresh and apply again to plan the changes",
+		ErrRuleModified, path, tree.property.PropertyID, etag, tree.etag)
+}
+
+// ruleAt returns the rule at path as JSON, or an empty string if it does not exist
+func (t *ruleTree) ruleAt(path string) (string, error) {
+	resolved, exists, err := resolveRulePath(t.document, path)
+	if !exists || err != nil {
+		// a path which cannot be resolved points to a rule which does not exist
+		return "", nil //nolint:nilerr
+	}
+	value, _ := jsonPointerGet(t.document, resolved)
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("could not render rule %s as JSON: %w", path, err)
+	}
+	return string(encoded), nil
+}
+
+// rulesPatchOperations returns the operations of the patch, or the operation setting the rule at path
+func rulesPatchOperations(d *schema.ResourceData, document map[string]interface{}) ([]rulepatch.Operation, error) {
+	if path := d.Get("path").(string); path != "" {
+		resolved, exists, err := resolveRulePath(document, path)
+		if err != nil {
+			return nil, err
+		}
+		op := rulepatch.OpAdd
+		if exists {
+			op = rulepatch.OpReplace
+		}
+		return []rulepatch.Operation{{Op: op, Path: resolved, Value: json.RawMessage(d.Get("rule").(string))}}, nil
+	}
+
+	var ops []rulepatch.Operation
+	if err := json.Unmarshal([]byte(d.Get("patch").(string)), &ops); err != nil {
+		return nil, fmt.Errorf("invalid patch: %w", err)
+	}
+	for i := range ops {
+		resolved, _, err := resolveRulePath(document, ops[i].Path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path of operation %d: %w", i, err)
+		}
+		ops[i].Path = resolved
+		if ops[i].From != "" {
+			if ops[i].From, _, err = resolveRulePath(document, ops[i].From); err != nil {
+				return nil, fmt.Errorf("invalid from of operation %d: %w", i, err)
+			}
+		}
+	}
+	return ops, nil
+}
+
+// rulesPatchProperty returns the property of the resource
+func rulesPatchProperty(d *schema.ResourceData) papi.Property {
+	return papi.Property{
+		PropertyID: str.AddPrefix(d.Get("property_id").(string), "prp_"),
+		ContractID: str.AddPrefix(d.Get("contract_id").(string), "ctr_"),
+		GroupID:    str.AddPrefix(d.Get("group_id").(string), "grp_"),
+	}
+}
+
+// fetchRuleTree returns the rule tree of the latest property version. If editable is set and the latest version
+// was activated, a new version is created from it first.
+func fetchRuleTree(ctx context.Context, client papi.PAPI, property papi.Property, editable bool) (*ruleTree, error) {
+	latest, err := fetchLatestProperty(ctx, client, property.PropertyID, property.GroupID, property.ContractID)
+	if err != nil {
+		return nil, err
+	}
+	property.LatestVersion = latest.LatestVersion
+
+	if editable {
+		res, err := fetchPropertyVersion(ctx, client, property.PropertyID, property.GroupID, property.ContractID, property.LatestVersion)
+		if err != nil {
+			return nil, err
+		}
+		if res.Version.ProductionStatus != papi.VersionStatusInactive || res.Version.StagingStatus != papi.VersionStatusInactive {
+			// The latest version has been activated on either production or staging, so we need to create a new version to patch
+			version, err := createPropertyVersion(ctx, client, property, property.LatestVersion)
+			if err != nil {
+				return nil, err
+			}
+			property.LatestVersion = version
+		}
+	}
+
+	res, err := client.GetRuleTree(ctx, papi.GetRuleTreeRequest{
+		PropertyID:      property.PropertyID,
+		ContractID:      property.ContractID,
+		GroupID:         property.GroupID,
+		PropertyVersion: property.LatestVersion,
+		ValidateRules:   true,
+		ValidateMode:    papi.RuleValidateModeFull,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the JSON Pointers refer to the rule tree document, so resolve them against the rules as generic JSON
+	encoded, err := json.Marshal(map[string]interface{}{"rules": res.Rules})
+	if err != nil {
+		return nil, fmt.Errorf("could not render rules as JSON: %w", err)
+	}
+	var document map[string]interface{}
+	if err := json.Unmarshal(encoded, &document); err != nil {
+		return nil, fmt.Errorf("could not read rules as JSON: %w", err)
+	}
+
+	return &ruleTree{
+		property: property,
+		etag:     res.Etag,
+		document: document,
+		errors:   res.Errors,
+		warnings: res.Warnings,
+	}, nil
+}
+
+// resolveRulePath replaces the names of the children of rules in the JSON Pointer with their indexes, and returns
+// whether the value at the pointer exists. A name of a child which does not exist is only accepted as the last segment,
+// and is resolved to "-" which appends the child.
+func resolveRulePath(document map[string]interface{}, path string) (string, bool, error) {
+	if path == "" || path[0] != '/' {
+		return "", false, fmt.Errorf("path %q must start with /", path)
+	}
+	segments := strings.Split(path[1:], "/")
+	var current interface{} = document
+	for i, segment := range segments {
+		last := i == len(segments)-1
+		switch value := current.(type) {
+		case map[string]interface{}:
+			next, ok := value[unescapeJSONPointer(segment)]
+			if !ok {
+				if last {
+					return path, false, nil
+				}
+				return "", false, fmt.Errorf("path %q: %q not found", path, segment)
+			}
+			current = next
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil && segment != "-" {
+				if i == 0 || segments[i-1] != "children" {
+					return "", false, fmt.Errorf("path %q: %q is not an index", path, segment)
+				}
+				index = childIndex(value, unescapeJSONPointer(segment))
+				if index < 0 {
+					if last {
+						segments[i] = "-"
+						return "/" + strings.Join(segments, "/"), false, nil
+					}
+					return "", false, fmt.Errorf("path %q: rule %q not found", path, segment)
+				}
+				segments[i] = strconv.Itoa(index)
+			}
+			if segment == "-" || index < 0 || index >= len(value) {
+				if last {
+					return "/" + strings.Join(segments, "/"), false, nil
+				}
+				return "", false, fmt.Errorf("path %q: index %q out of range", path, segment)
+			}
+			current = value[index]
+		default:
+			return "", false, fmt.Errorf("path %q: %q not found", path, segment)
+		}
+	}
+	return "/" + strings.Join(segments, "/"), true, nil
+}
+
+// childIndex returns the index of the rule with the given name, or -1
+func childIndex(children []interface{}, name string) int {
+	for i, child := range children {
+		if rule, ok := child.(map[string]interface{}); ok && rule["name"] == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// jsonPointerGet returns the value at the JSON Pointer with resolved indexes
+func jsonPointerGet(document map[string]interface{}, path string) (interface{}, bool) {
+	var current interface{} = document
+	for _, segment := range strings.Split(strings.TrimPrefix(path, "/"), "/") {
+		switch value := current.(type) {
+		case map[string]interface{}:
+			next, ok := value[unescapeJSONPointer(segment)]
+			if !ok {
+				return nil, false
+			}
+			current = next
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(value) {
+				return nil, false
+			}
+			current = value[index]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func unescapeJSONPointer(segment string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(segment, "~1", "/"), "~0", "~")
+}
+
+func validateRulesPatch(v interface{}, _ cty.Path) diag.Diagnostics {
+	var ops []rulepatch.Operation
+	if err := json.Unmarshal([]byte(v.(string)), &ops); err != nil {
+		return diag.Errorf("patch must be a JSON array of JSON Patch operations: %s", err)
+	}
+	if len(ops) == 0 {
+		return diag.Errorf("patch must contain at least one operation")
+	}
+	for i, op := range ops {
+		if err := op.Validate(); err != nil {
+			return diag.Errorf("invalid operation %d of patch: %s", i, err)
+		}
+	}
+	return nil
+}
+
+func validateRulePath(v interface{}, _ cty.Path) diag.Diagnostics {
+	path := v.(string)
+	if !strings.HasPrefix(path, "/rules") {
+		return diag.Errorf("path %q must point into the rule tree and start with /rules", path)
+	}
+	return nil
+}
+
+func validateRuleJSON(v interface{}, _ cty.Path) diag.Diagnostics {
+	var rule papi.Rules
+	if err := json.Unmarshal([]byte(v.(string)), &rule); err != nil {
+		return diag.Errorf("rule must be a JSON object of a rule: %s", err)
+	}
+	return nil
+}
+
+// diffSuppressJSON suppresses the differences in formatting of JSON values
+func diffSuppressJSON(_, oldJSON, newJSON string, _ *schema.ResourceData) bool {
+	if oldJSON == "" || newJSON == "" {
+		return oldJSON == newJSON
+	}
+	var oldValue, newValue interface{}
+	if json.Unmarshal([]byte(oldJSON), &oldValue) != nil || json.Unmarshal([]byte(newJSON), &newValue) != nil {
+		return false
+	}
+	return reflect.DeepEqual(oldValue, newValue)
+}
+
+// diffSuppressRule suppresses the differences of rules ignoring the order of variables
+func diffSuppressRule(_, oldRuleJSON, newRuleJSON string, _ *schema.ResourceData) bool {
+	if oldRuleJSON == "" || newRuleJSON == "" {
+		return oldRuleJSON == newRuleJSON
+	}
+	var oldRule, newRule papi.Rules
+	if json.Unmarshal([]byte(oldRuleJSON), &oldRule) != nil || json.Unmarshal([]byte(newRuleJSON), &newRule) != nil {
+		return false
+	}
+	return rulesEqual(&oldRule, &newRule)
+}
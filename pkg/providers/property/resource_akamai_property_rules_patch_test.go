@@ -0,0 +1,338 @@
+package property
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/papi"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/test"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/testutils"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/providers/property/internal/rulepatch"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockRulesPatch keeps the rule tree of the latest property version, so that the patches are reflected by the read rule tree
+type mockRulesPatch struct {
+	papiMock  *papi.Mock
+	patchMock *rulepatch.Mock
+	version   int
+	rules     papi.Rules
+	etag      string
+	errors    []*papi.Error
+}
+
+func (p *mockRulesPatch) mockGetProperty() {
+	resp := &papi.GetPropertyResponse{}
+	p.papiMock.On("GetProperty", testutils.MockContext, papi.GetPropertyRequest{
+		PropertyID: "prp_1",
+		ContractID: "ctr_1",
+		GroupID:    "grp_1",
+	}).Run(func(mock.Arguments) {
+		*resp = papi.GetPropertyResponse{Property: &papi.Property{
+			PropertyID:    "prp_1",
+			ContractID:    "ctr_1",
+			GroupID:       "grp_1",
+			LatestVersion: p.version,
+		}}
+	}).Return(resp, nil)
+}
+
+func (p *mockRulesPatch) mockGetPropertyVersion(stagingStatus papi.VersionStatus) {
+	resp := &papi.GetPropertyVersionsResponse{}
+	p.papiMock.On("GetPropertyVersion", testutils.MockContext, mock.MatchedBy(func(req papi.GetPropertyVersionRequest) bool {
+		return req.PropertyID == "prp_1" && req.PropertyVersion == p.version
+	})).Run(func(args mock.Arguments) {
+		*resp = papi.GetPropertyVersionsResponse{
+			PropertyID: "prp_1",
+			Version: papi.PropertyVersionGetItem{
+				PropertyVersion:  args.Get(1).(papi.GetPropertyVersionRequest).PropertyVersion,
+				StagingStatus:    stagingStatus,
+				ProductionStatus: papi.VersionStatusInactive,
+			},
+		}
+	}).Return(resp, nil)
+}
+
+func (p *mockRulesPatch) mockGetRuleTree() {
+	resp := &papi.GetRuleTreeResponse{}
+	p.papiMock.On("GetRuleTree", testutils.MockContext, mock.MatchedBy(func(req papi.GetRuleTreeRequest) bool {
+		return req.PropertyID == "prp_1" && req.PropertyVersion == p.version && req.ValidateRules
+	})).Run(func(mock.Arguments) {
+		*resp = papi.GetRuleTreeResponse{
+			Response:        papi.Response{Errors: p.errors},
+			PropertyID:      "prp_1",
+			PropertyVersion: p.version,
+			Etag:            p.etag,
+			RuleFormat:      "v2023-01-05",
+			Rules:           p.rules,
+		}
+	}).Return(resp, nil)
+}
+
+// mockPatch expects the operations on the rule tree read with the etag, and sets the rule tree to the patched rules
+func (p *mockRulesPatch) mockPatch(t *testing.T, etag, operations string, patched papi.Rules, newEtag string) {
+	p.patchMock.On("PatchRuleTree", testutils.MockContext, mock.MatchedBy(func(req rulepatch.PatchRuleTreeRequest) bool {
+		ops, err := json.Marshal(req.Operations)
+		require.NoError(t, err)
+		return req.PropertyID == "prp_1" && req.PropertyVersion == p.version && req.Etag == etag &&
+			assert.ObjectsAreEqual(normalizeJSON(t, operations), normalizeJSON(t, string(ops)))
+	})).Run(func(mock.Arguments) {
+		p.rules, p.etag = patched, newEtag
+	}).Return(&papi.UpdateRulesResponse{PropertyID: "prp_1", Etag: newEtag}, nil).Once()
+}
+
+func normalizeJSON(t *testing.T, value string) interface{} {
+	var normalized interface{}
+	require.NoError(t, json.Unmarshal([]byte(value), &normalized))
+	return normalized
+}
+
+var (
+	performanceRule = papi.Rules{
+		Name: "Performance",
+		Behaviors: []papi.RuleBehavior{{
+			Name:    "http2",
+			Options: papi.RuleOptionsMap{"enabled": false},
+		}},
+	}
+
+	offloadRule = func(ttl string) papi.Rules {
+		return papi.Rules{
+			Name: "Offload",
+			Behaviors: []papi.RuleBehavior{{
+				Name:    "caching",
+				Options: papi.RuleOptionsMap{"behavior": "MAX_AGE", "ttl": ttl},
+			}},
+		}
+	}
+)
+
+func TestResPropertyRulesPatch(t *testing.T) {
+	tests := map[string]struct {
+		init  func(*testing.T, *mockRulesPatch)
+		steps []resource.TestStep
+	}{
+		"rule at path created, updated, imported and removed": {
+			init: func(t *testing.T, p *mockRulesPatch) {
+				p.mockGetProperty()
+				p.mockGetPropertyVersion(papi.VersionStatusInactive)
+				p.mockGetRuleTree()
+				p.mockPatch(t, "etag1", `[{"op": "add", "path": "/rules/children/-", "value": {"name": "Offload", "behaviors": [{"name": "caching", "options": {"behavior": "MAX_AGE", "ttl": "1d"}}]}}]`,
+					papi.Rules{Name: "default", Children: []papi.Rules{performanceRule, offloadRule("1d")}}, "etag2")
+				p.mockPatch(t, "etag2", `[{"op": "replace", "path": "/rules/children/1", "value": {"name": "Offload", "behaviors": [{"name": "caching", "options": {"behavior": "MAX_AGE", "ttl": "2d"}}]}}]`,
+					papi.Rules{Name: "default", Children: []papi.Rules{performanceRule, offloadRule("2d")}}, "etag3")
+				p.mockPatch(t, "etag3", `[{"op": "remove", "path": "/rules/children/1"}]`,
+					papi.Rules{Name: "default", Children: []papi.Rules{performanceRule}}, "etag4")
+			},
+			steps: []resource.TestStep{
+				{
+					Config: testutils.LoadFixtureString(t, "testdata/TestResPropertyRulesPatch/path.tf"),
+					Check: test.NewStateChecker("akamai_property_rules_patch.test").
+						CheckEqual("id", "prp_1:/rules/children/Offload").
+						CheckEqual("property_version", "3").
+						CheckEqual("etag", "etag2").
+						CheckEqual("rule_errors.#", "0").
+						Build(),
+				},
+				{
+					Config: testutils.LoadFixtureString(t, "testdata/TestResPropertyRulesPatch/path_update.tf"),
+					Check: test.NewStateChecker("akamai_property_rules_patch.test").
+						CheckEqual("etag", "etag3").
+						Build(),
+				},
+				{
+					ImportState:       true,
+					ImportStateId:     "prp_1:ctr_1:grp_1:/rules/children/Offload",
+					ImportStateVerify: true,
+					ResourceName:      "akamai_property_rules_patch.test",
+				},
+			},
+		},
+		"patch applied to new version with rule errors": {
+			init: func(t *testing.T, p *mockRulesPatch) {
+				p.version = 1
+				p.mockGetProperty()
+				p.mockGetPropertyVersion(papi.VersionStatusActive)
+				p.papiMock.On("CreatePropertyVersion", testutils.MockContext, papi.CreatePropertyVersionRequest{
+					PropertyID: "prp_1",
+					ContractID: "ctr_1",
+					GroupID:    "grp_1",
+					Version:    papi.PropertyVersionCreate{CreateFromVersion: 1},
+				}).Run(func(mock.Arguments) {
+					p.version = 2
+				}).Return(&papi.CreatePropertyVersionResponse{PropertyVersion: 2}, nil).Once()
+				p.mockGetRuleTree()
+				enabled := performanceRule
+				enabled.Behaviors = []papi.RuleBehavior{{Name: "http2", Options: papi.RuleOptionsMap{"enabled": true}}}
+				p.mockPatch(t, "etag1", `[{"op": "replace", "path": "/rules/children/0/behaviors/0/options/enabled", "value": true}]`,
+					papi.Rules{Name: "default", Children: []papi.Rules{enabled}}, "etag2")
+				p.errors = []*papi.Error{{Type: "validation", Title: "Incompatible behavior", ErrorLocation: "#/rules/children/0/behaviors/0"}}
+			},
+			steps: []resource.TestStep{
+				{
+					Config: testutils.LoadFixtureString(t, "testdata/TestResPropertyRulesPatch/patch.tf"),
+					Check: test.NewStateChecker("akamai_property_rules_patch.test").
+						CheckEqual("id", "prp_1").
+						CheckEqual("property_id", "prp_1").
+						CheckEqual("property_version", "2").
+						CheckEqual("etag", "etag2").
+						CheckEqual("rule_errors.#", "1").
+						CheckEqual("rule_errors.0.title", "Incompatible behavior").
+						Build(),
+				},
+			},
+		},
+		"rule tree modified concurrently": {
+			init: func(_ *testing.T, p *mockRulesPatch) {
+				p.mockGetProperty()
+				p.mockGetPropertyVersion(papi.VersionStatusInactive)
+				p.mockGetRuleTree()
+				p.patchMock.On("PatchRuleTree", testutils.MockContext, mock.AnythingOfType("rulepatch.PatchRuleTreeRequest")).
+					Return(nil, fmt.Errorf("%w: %w: %w", rulepatch.ErrPatchRuleTree, rulepatch.ErrEtagMismatch, &papi.Error{StatusCode: 412})).Once()
+			},
+			steps: []resource.TestStep{
+				{
+					Config:      testutils.LoadFixtureString(t, "testdata/TestResPropertyRulesPatch/patch.tf"),
+					ExpectError: regexp.MustCompile("was modified while it was patched, apply again"),
+				},
+			},
+		},
+		"invalid patch operation": {
+			steps: []resource.TestStep{
+				{
+					Config:      testutils.LoadFixtureString(t, "testdata/TestResPropertyRulesPatch/invalid_patch.tf"),
+					ExpectError: regexp.MustCompile("invalid operation 0 of patch: Op: must be a valid value"),
+				},
+			},
+		},
+		"patch and path": {
+			steps: []resource.TestStep{
+				{
+					Config:      testutils.LoadFixtureString(t, "testdata/TestResPropertyRulesPatch/patch_and_path.tf"),
+					ExpectError: regexp.MustCompile(`only one of .patch,path. can be specified`),
+				},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := &mockRulesPatch{
+				papiMock:  &papi.Mock{},
+				patchMock: &rulepatch.Mock{},
+				version:   3,
+				rules:     papi.Rules{Name: "default", Children: []papi.Rules{performanceRule}},
+				etag:      "etag1",
+			}
+			if tc.init != nil {
+				tc.init(t, p)
+			}
+
+			useClient(p.papiMock, nil, func() {
+				useRulePatch(p.patchMock, func() {
+					resource.UnitTest(t, resource.TestCase{
+						ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+						IsUnitTest:               true,
+						Steps:                    tc.steps,
+					})
+				})
+			})
+
+			p.papiMock.AssertExpectations(t)
+			p.patchMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestResolveRulePath(t *testing.T) {
+	document := map[string]interface{}{
+		"rules": map[string]interface{}{
+			"name": "default",
+			"children": []interface{}{
+				map[string]interface{}{"name": "Performance", "behaviors": []interface{}{map[string]interface{}{"name": "http2"}}},
+				map[string]interface{}{"name": "Offload/Static"},
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		path     string
+		expected string
+		exists   bool
+		err      string
+	}{
+		"index":                  {path: "/rules/children/1", expected: "/rules/children/1", exists: true},
+		"name":                   {path: "/rules/children/Performance/behaviors/0", expected: "/rules/children/0/behaviors/0", exists: true},
+		"escaped name":           {path: "/rules/children/Offload~1Static", expected: "/rules/children/1", exists: true},
+		"new name appended":      {path: "/rules/children/Images", expected: "/rules/children/-"},
+		"new attribute":          {path: "/rules/comments", expected: "/rules/comments"},
+		"index out of range":     {path: "/rules/children/2", expected: "/rules/children/2"},
+		"missing parent":         {path: "/rules/children/Images/behaviors/0", err: `rule "Images" not found`},
+		"name outside children":  {path: "/rules/children/0/behaviors/http2", err: `"http2" is not an index`},
+		"relative path":          {path: "rules", err: "must start with /"},
+		"missing parent element": {path: "/rules/variables/0", err: `"variables" not found`},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			resolved, exists, err := resolveRulePath(document, tc.path)
+			if tc.err != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, resolved)
+			assert.Equal(t, tc.exists, exists)
+		})
+	}
+}
+
+func TestCheckRuleConflict(t *testing.T) {
+	tree := &ruleTree{
+		property: papi.Property{PropertyID: "prp_1", LatestVersion: 3},
+		etag:     "etag2",
+		document: map[string]interface{}{
+			"rules": map[string]interface{}{
+				"name": "default",
+				"children": []interface{}{
+					map[string]interface{}{"name": "Offload", "options": map[string]interface{}{}},
+				},
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		path     string
+		etag     string
+		rule     string
+		conflict bool
+	}{
+		"same etag":             {path: "/rules/children/Offload", etag: "etag2", rule: `{"name": "Other"}`},
+		"patch":                 {etag: "etag1"},
+		"no etag in state":      {path: "/rules/children/Offload", rule: `{"name": "Other"}`},
+		"other rule patched":    {path: "/rules/children/Offload", etag: "etag1", rule: `{"name": "Offload", "options": {}}`},
+		"rule removed before":   {path: "/rules/children/Images", etag: "etag1"},
+		"rule modified":         {path: "/rules/children/Offload", etag: "etag1", rule: `{"name": "Offload", "comments": "changed", "options": {}}`, conflict: true},
+		"rule added since read": {path: "/rules/children/Offload", etag: "etag1", conflict: true},
+		"rule removed since read": {
+			path: "/rules/children/Images", etag: "etag1", rule: `{"name": "Images", "options": {}}`, conflict: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := checkRuleConflict(tree, tc.path, tc.etag, tc.rule)
+			if tc.conflict {
+				assert.ErrorIs(t, err, ErrRuleModified)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,370 @@
+package ruleformats
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/papi"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/tf"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type (
+	rulesHCLWriter struct {
+		version      RuleVersion
+		nameMappings map[string]string
+		behaviors    keyIndex
+		criteria     keyIndex
+		names        map[string]bool
+		blocks       []string
+		errors       []error
+	}
+
+	// keyIndex maps names used in the rule tree JSON to the keys and schemas of the rule format.
+	keyIndex map[string]schemaKey
+
+	schemaKey struct {
+		key    string
+		schema *schema.Schema
+	}
+
+	// hclBody is the body of an HCL block. Its attributes are aligned the same way 'terraform fmt' aligns them.
+	hclBody struct {
+		items []hclItem
+	}
+
+	hclItem struct {
+		name  string
+		value string
+		block *hclBody
+	}
+)
+
+// RulesBuilderHCL renders the rule tree as akamai_property_rules_builder data sources of given rule format, e.g. 'v2025-01-13',
+// one data source for each rule. The data source of the top rule is called name, the data sources of the child rules
+// are called after the rules. The data sources are returned in the order of the rules in the rule tree.
+//
+// It returns an error if the rule format is not in the registry, or if the rule tree contains behaviors, criteria
+// or options which are not defined by the rule format.
+func RulesBuilderHCL(ruleFormat, name string, rules papi.Rules) ([]string, error) {
+	return schemasRegistry.rulesBuilderHCL(ruleFormat, name, rules)
+}
+
+func (r *registry) rulesBuilderHCL(ruleFormat, name string, rules papi.Rules) ([]string, error) {
+	for _, rf := range r.rules {
+		if RuleVersion(rf.version).Version() != ruleFormat {
+			continue
+		}
+		w := rulesHCLWriter{
+			version:      RuleVersion(rf.version),
+			nameMappings: rf.nameMappings,
+			names:        map[string]bool{},
+		}
+		w.behaviors = w.index(rf.behaviorsSchemas)
+		w.criteria = w.index(rf.criteriaSchemas)
+		w.writeRule("#/rules", name, rules)
+		if len(w.errors) > 0 {
+			return nil, errors.Join(w.errors...)
+		}
+		return w.blocks, nil
+	}
+	return nil, fmt.Errorf("%w: rule format %q", ErrNotFound, ruleFormat)
+}
+
+func (w *rulesHCLWriter) index(schemas map[string]*schema.Schema) keyIndex {
+	result := make(keyIndex, len(schemas))
+	for key, s := range schemas {
+		result[jsonName(w.nameMappings, key)] = schemaKey{key: key, schema: s}
+	}
+	return result
+}
+
+// writeRule adds the data source of the rule and of its children, and returns the name of the data source of the rule.
+func (w *rulesHCLWriter) writeRule(location, name string, rule papi.Rules) string {
+	name = w.uniqueName(name)
+	position := len(w.blocks)
+	w.blocks = append(w.blocks, "")
+
+	body := &hclBody{}
+	body.attribute("name", tf.QuoteHCL(rule.Name))
+	isDefault := rule.Name == defaultRule
+	if isDefault {
+		body.attribute("is_secure", strconv.FormatBool(rule.Options.IsSecure))
+	}
+	if rule.CriteriaMustSatisfy != "" && !isDefault {
+		body.attribute("criteria_must_satisfy", tf.QuoteHCL(string(rule.CriteriaMustSatisfy)))
+	}
+	if rule.CriteriaLocked && !isDefault {
+		body.attribute("criteria_locked", "true")
+	}
+	if rule.AdvancedOverride != "" && isDefault {
+		body.attribute("advanced_override", tf.QuoteHCL(rule.AdvancedOverride))
+	}
+	for _, attr := range []struct{ key, value string }{
+		{"comments", rule.Comments},
+		{"uuid", rule.UUID},
+		{"template_uuid", rule.TemplateUuid},
+		{"template_link", rule.TemplateLink},
+	} {
+		if attr.value != "" {
+			body.attribute(attr.key, tf.QuoteHCL(attr.value))
+		}
+	}
+
+	if rule.CustomOverride != nil && isDefault {
+		override := body.block("custom_override")
+		override.attribute("name", tf.QuoteHCL(rule.CustomOverride.Name))
+		override.attribute("override_id", tf.QuoteHCL(rule.CustomOverride.OverrideID))
+	}
+	if isDefault {
+		for _, v := range rule.Variables {
+			variable := body.block("variable")
+			variable.attribute("name", tf.QuoteHCL(v.Name))
+			variable.attribute("value", tf.QuoteHCL(stringOrEmpty(v.Value)))
+			variable.attribute("description", tf.QuoteHCL(stringOrEmpty(v.Description)))
+			variable.attribute("hidden", strconv.FormatBool(v.Hidden))
+			variable.attribute("sensitive", strconv.FormatBool(v.Sensitive))
+		}
+	}
+	for i, criterion := range rule.Criteria {
+		w.writeItem(fmt.Sprintf("%s/criteria/%d", location, i), "criterion", w.criteria, criterion, body)
+	}
+	for i, behavior := range rule.Behaviors {
+		w.writeItem(fmt.Sprintf("%s/behaviors/%d", location, i), "behavior", w.behaviors, behavior, body)
+	}
+
+	if len(rule.Children) > 0 {
+		children := make([]string, 0, len(rule.Children))
+		for i, child := range rule.Children {
+			childName := w.writeRule(fmt.Sprintf("%s/children/%d", location, i), child.Name, child)
+			children = append(children, fmt.Sprintf("data.akamai_property_rules_builder.%s.json", childName))
+		}
+		body.attribute("children", "[\n  "+strings.Join(children, ",\n  ")+",\n]")
+	}
+
+	dataSource := &hclBody{}
+	*dataSource.block(w.version.SchemaKey()) = *body
+	w.blocks[position] = fmt.Sprintf("data \"akamai_property_rules_builder\" %q {\n%s}\n", name, dataSource.render("  "))
+	return name
+}
+
+// writeItem adds the block of a behavior or criterion to the body of the rule.
+func (w *rulesHCLWriter) writeItem(location, kind string, index keyIndex, item papi.RuleBehavior, body *hclBody) {
+	s, ok := index[item.Name]
+	if !ok {
+		w.errors = append(w.errors, RuleValidationError{
+			Location: location,
+			Name:     item.Name,
+			Detail:   fmt.Sprintf("%s is not supported by rule format %s", kind, w.version.Version()),
+		})
+		return
+	}
+
+	options := make(map[string]any, len(item.Options)+3)
+	for key, value := range item.Options {
+		options[key] = value
+	}
+	if item.Locked {
+		options["locked"] = true
+	}
+	if item.UUID != "" {
+		options["uuid"] = item.UUID
+	}
+	if item.TemplateUuid != "" {
+		options["templateUuid"] = item.TemplateUuid
+	}
+
+	itemBody := body.block(kind).block(s.key)
+	w.writeOptions(location, item.Name, "", s.schema, options, itemBody)
+}
+
+// writeOptions adds the options to the body of a behavior, criterion or an option holding an object.
+func (w *rulesHCLWriter) writeOptions(location, name, prefix string, s *schema.Schema, options map[string]any, body *hclBody) {
+	res, ok := s.Elem.(*schema.Resource)
+	if !ok {
+		return
+	}
+	optionsIndex := w.index(res.Schema)
+
+	keys := make([]string, 0, len(options))
+	for key := range options {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return optionsIndex[keys[i]].key < optionsIndex[keys[j]].key
+	})
+
+	var blocks []string
+	for _, key := range keys {
+		value := options[key]
+		option := prefix + key
+		optionSchema, ok := optionsIndex[key]
+		if !ok {
+			w.errors = append(w.errors, RuleValidationError{
+				Location: location,
+				Name:     name,
+				Option:   option,
+				Detail:   fmt.Sprintf("option is not supported by rule format %s", w.version.Version()),
+			})
+			continue
+		}
+		if value == nil {
+			continue
+		}
+
+		if _, ok := optionSchema.schema.Elem.(*schema.Resource); ok {
+			blocks = append(blocks, key)
+			continue
+		}
+		rendered, err := renderValue(optionSchema.schema, value)
+		if err != nil {
+			w.errors = append(w.errors, RuleValidationError{Location: location, Name: name, Option: option, Detail: err.Error()})
+			continue
+		}
+		body.attribute(optionSchema.key, rendered)
+	}
+
+	// options holding objects are rendered as nested blocks, after the attributes
+	for _, key := range blocks {
+		optionSchema, option := optionsIndex[key], prefix+key
+		var objects []any
+		switch value := options[key].(type) {
+		case map[string]any:
+			objects = []any{value}
+		case []any:
+			objects = value
+		default:
+			w.errors = append(w.errors, RuleValidationError{Location: location, Name: name, Option: option,
+				Detail: fmt.Sprintf("expected an object or a list of objects, got %s", typeof(value))})
+			continue
+		}
+		for _, object := range objects {
+			objectOptions, ok := object.(map[string]any)
+			if !ok {
+				w.errors = append(w.errors, RuleValidationError{Location: location, Name: name, Option: option,
+					Detail: fmt.Sprintf("expected an object, got %s", typeof(object))})
+				continue
+			}
+			w.writeOptions(location, name, option+".", optionSchema.schema, objectOptions, body.block(optionSchema.key))
+		}
+	}
+}
+
+// uniqueName returns the name of the data source of a rule, which is not used by another rule of the rule tree.
+func (w *rulesHCLWriter) uniqueName(ruleName string) string {
+	name := tf.BlockName(ruleName)
+	for i := 2; w.names[name]; i++ {
+		name = fmt.Sprintf("%s_%d", tf.BlockName(ruleName), i)
+	}
+	w.names[name] = true
+	return name
+}
+
+// renderValue renders the value of an option as an HCL expression of the type of the option schema.
+// Values of a different type in JSON, e.g. due to type mappings, are converted to the type of the schema.
+func renderValue(s *schema.Schema, value any) (string, error) {
+	switch s.Type {
+	case schema.TypeString:
+		switch v := value.(type) {
+		case string:
+			return tf.QuoteHCL(v), nil
+		case float64:
+			return tf.QuoteHCL(formatNumber(v)), nil
+		case bool, int:
+			return tf.QuoteHCL(fmt.Sprint(v)), nil
+		}
+	case schema.TypeBool:
+		if v, ok := value.(bool); ok {
+			return strconv.FormatBool(v), nil
+		}
+	case schema.TypeInt, schema.TypeFloat:
+		switch v := value.(type) {
+		case float64:
+			return formatNumber(v), nil
+		case int:
+			return strconv.Itoa(v), nil
+		case string:
+			// variables, e.g. '{{user.PMUSER_TTL}}', cannot be used in numeric options of the rules builder
+			if _, err := strconv.ParseFloat(v, 64); err == nil {
+				return v, nil
+			}
+		}
+	case schema.TypeList:
+		elem, ok := s.Elem.(*schema.Schema)
+		list, isList := value.([]any)
+		if !ok || !isList {
+			break
+		}
+		items := make([]string, 0, len(list))
+		for _, item := range list {
+			rendered, err := renderValue(elem, item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, rendered)
+		}
+		if len(items) == 0 {
+			return "[]", nil
+		}
+		return "[" + strings.Join(items, ", ") + "]", nil
+	}
+	return "", fmt.Errorf("value of type %s cannot be used for an option of type %s", typeof(value), strings.TrimPrefix(s.Type.String(), "Type"))
+}
+
+func formatNumber(v float64) string {
+	if v == math.Trunc(v) && math.Abs(v) < 1e15 {
+		return strconv.FormatInt(int64(v), 10)
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func (b *hclBody) attribute(name, value string) {
+	b.items = append(b.items, hclItem{name: name, value: value})
+}
+
+func (b *hclBody) block(name string) *hclBody {
+	block := &hclBody{}
+	b.items = append(b.items, hclItem{name: name, block: block})
+	return block
+}
+
+// render returns the body indented by prefix, aligning the equal signs of consecutive single line attributes.
+func (b *hclBody) render(prefix string) string {
+	var sb strings.Builder
+	for i := 0; i < len(b.items); {
+		item := b.items[i]
+		if item.block != nil {
+			sb.WriteString(fmt.Sprintf("%s%s {\n%s%s}\n", prefix, item.name, item.block.render(prefix+"  "), prefix))
+			i++
+			continue
+		}
+		end, width := i, 0
+		for ; end < len(b.items) && b.items[end].block == nil; end++ {
+			width = max(width, len(b.items[end].name))
+			if strings.Contains(b.items[end].value, "\n") {
+				end++
+				break
+			}
+		}
+		for ; i < end; i++ {
+			sb.WriteString(fmt.Sprintf("%s%-*s = %s\n", prefix, width, b.items[i].name, indent(b.items[i].value, prefix)))
+		}
+	}
+	return sb.String()
+}
+
+// indent indents all lines of a multi-line value but the first one.
+func indent(value, prefix string) string {
+	return strings.ReplaceAll(value, "\n", "\n"+prefix)
+}
@@ -0,0 +1,162 @@
+package ruleformats
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/papi"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/iancoleman/strcase"
+)
+
+type (
+	// RuleValidationError describes a behavior, criterion or option of a rule tree which does not conform
+	// to the schema of a rule format.
+	RuleValidationError struct {
+		// Location is a JSON pointer to the behavior or criterion, e.g. '#/rules/children/0/behaviors/1'.
+		Location string
+		// Name is the name of the behavior or criterion.
+		Name string
+		// Option is the name of the option, empty if the behavior or criterion itself is not supported.
+		Option string
+		// Detail describes the problem.
+		Detail string
+	}
+
+	rulesValidator struct {
+		ruleFormat   string
+		nameMappings map[string]string
+		behaviors    schemaIndex
+		criteria     schemaIndex
+		errors       []RuleValidationError
+	}
+
+	// schemaIndex maps names used in the rule tree JSON to the keys and schemas of the rule format.
+	schemaIndex map[string]*schema.Schema
+)
+
+// Error returns RuleValidationError as a string.
+func (e RuleValidationError) Error() string {
+	if e.Option == "" {
+		return fmt.Sprintf("%s: %s: %s", e.Location, e.Name, e.Detail)
+	}
+	return fmt.Sprintf("%s: %s.%s: %s", e.Location, e.Name, e.Option, e.Detail)
+}
+
+// ValidateRules validates the behaviors and criteria of the rule tree against the schema of given rule format,
+// e.g. 'v2025-01-13'. It reports the behaviors, criteria and options which are not defined by the rule format,
+// and the option values which are not allowed by it. Values using variables, e.g. '{{user.PMUSER_ORIGIN}}' are not validated.
+//
+// It returns an error if the rule format is not in the registry.
+func ValidateRules(ruleFormat string, rules papi.Rules) ([]RuleValidationError, error) {
+	return schemasRegistry.validateRules(ruleFormat, rules)
+}
+
+func (r *registry) validateRules(ruleFormat string, rules papi.Rules) ([]RuleValidationError, error) {
+	for _, rf := range r.rules {
+		if RuleVersion(rf.version).Version() != ruleFormat {
+			continue
+		}
+		v := rulesValidator{
+			ruleFormat:   ruleFormat,
+			nameMappings: rf.nameMappings,
+		}
+		v.behaviors = v.index(rf.behaviorsSchemas)
+		v.criteria = v.index(rf.criteriaSchemas)
+		v.validateRule("#/rules", rules)
+		return v.errors, nil
+	}
+	return nil, fmt.Errorf("%w: rule format %q", ErrNotFound, ruleFormat)
+}
+
+// index maps the camel case names used in the rule tree JSON to the schemas, the same way RulesBuilder maps them back.
+func (v *rulesValidator) index(schemas map[string]*schema.Schema) schemaIndex {
+	result := make(schemaIndex, len(schemas))
+	for key, s := range schemas {
+		result[jsonName(v.nameMappings, key)] = s
+	}
+	return result
+}
+
+// jsonName returns the name used in the rule tree JSON for the key of a behavior, criterion or option.
+func jsonName(nameMappings map[string]string, key string) string {
+	name := strcase.ToLowerCamel(key)
+	if mapped, ok := nameMappings[name]; ok {
+		return mapped
+	}
+	return name
+}
+
+func (v *rulesValidator) validateRule(location string, rule papi.Rules) {
+	for i, behavior := range rule.Behaviors {
+		v.validateItem(fmt.Sprintf("%s/behaviors/%d", location, i), "behavior", v.behaviors, behavior)
+	}
+	for i, criterion := range rule.Criteria {
+		v.validateItem(fmt.Sprintf("%s/criteria/%d", location, i), "criterion", v.criteria, criterion)
+	}
+	for i, child := range rule.Children {
+		v.validateRule(fmt.Sprintf("%s/children/%d", location, i), child)
+	}
+}
+
+func (v *rulesValidator) validateItem(location, kind string, index schemaIndex, item papi.RuleBehavior) {
+	s, ok := index[item.Name]
+	if !ok {
+		v.errors = append(v.errors, RuleValidationError{
+			Location: location,
+			Name:     item.Name,
+			Detail:   fmt.Sprintf("%s is not supported by rule format %s", kind, v.ruleFormat),
+		})
+		return
+	}
+	v.validateOptions(location, item.Name, "", s, item.Options)
+}
+
+// validateOptions validates the options against the schema of a behavior, criterion or an option holding an object.
+func (v *rulesValidator) validateOptions(location, name, prefix string, s *schema.Schema, options map[string]any) {
+	res, ok := s.Elem.(*schema.Resource)
+	if !ok {
+		return
+	}
+	optionsIndex := v.index(res.Schema)
+
+	keys := make([]string, 0, len(options))
+	for key := range options {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := options[key]
+		option := prefix + key
+		optionSchema, ok := optionsIndex[key]
+		if !ok {
+			v.errors = append(v.errors, RuleValidationError{
+				Location: location,
+				Name:     name,
+				Option:   option,
+				Detail:   fmt.Sprintf("option is not supported by rule format %s", v.ruleFormat),
+			})
+			continue
+		}
+
+		switch val := value.(type) {
+		case map[string]any:
+			v.validateOptions(location, name, option+".", optionSchema, val)
+		case string:
+			if optionSchema.Type != schema.TypeString || optionSchema.ValidateDiagFunc == nil || strings.Contains(val, "{{") {
+				continue
+			}
+			for _, d := range optionSchema.ValidateDiagFunc(val, cty.GetAttrPath(key)) {
+				v.errors = append(v.errors, RuleValidationError{
+					Location: location,
+					Name:     name,
+					Option:   option,
+					Detail:   d.Summary,
+				})
+			}
+		}
+	}
+}
@@ -0,0 +1,286 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/papi"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/common/tf"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// notSet is the rendered value of attributes missing on one side of the rules diff
+const notSet = "(not set)"
+
+// rulesDiffSchema is the schema of the rules_diff attribute of akamai_property and akamai_property_include
+var rulesDiffSchema = &schema.Schema{
+	Type:     schema.TypeList,
+	Computed: true,
+	Elem:     &schema.Schema{Type: schema.TypeString},
+	Description: "The changes of the rules in the latest plan which changed them, one per line, " +
+		"e.g. '/default/children[Images]/behaviors[caching].options.ttl: 1d -> 7d'",
+}
+
+// setRulesDiff is a schema.CustomizeDiffFunc which sets rules_diff to the structural diff of the planned rules
+// when the rules change. The previous value is kept otherwise, so that rules_diff is not reported as a change.
+func setRulesDiff(_ context.Context, rd *schema.ResourceDiff, _ interface{}) error {
+	if rd.Id() == "" {
+		return nil
+	}
+	if !rd.NewValueKnown("rules") {
+		if err := rd.SetNewComputed("rules_diff"); err != nil {
+			return fmt.Errorf("%w: %s", tf.ErrValueSet, err.Error())
+		}
+		return nil
+	}
+
+	oldRules, newRules := rd.GetChange("rules")
+	if oldRules.(string) == "" || newRules.(string) == "" {
+		return nil
+	}
+	lines, err := rulesDiff(oldRules.(string), newRules.(string))
+	if err != nil {
+		return err
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+
+	if err := rd.SetNew("rules_diff", lines); err != nil {
+		return fmt.Errorf("%w: %s", tf.ErrValueSet, err.Error())
+	}
+	return nil
+}
+
+// rulesDiff returns the changes between the two papi.RulesUpdate JSON representations, identifying the rules,
+// behaviors, criteria and variables by their names. The differences ignored by diffSuppressPropertyRules are not reported.
+func rulesDiff(oldRulesJSON, newRulesJSON string) ([]string, error) {
+	var oldRules, newRules papi.RulesUpdate
+	if err := json.Unmarshal([]byte(oldRulesJSON), &oldRules); err != nil {
+		return nil, fmt.Errorf("'old' = %s, unmarshal: %w", oldRulesJSON, err)
+	}
+	if err := json.Unmarshal([]byte(newRulesJSON), &newRules); err != nil {
+		return nil, fmt.Errorf("'new' = %s, unmarshal: %w", newRulesJSON, err)
+	}
+	normalizeFields(&oldRules, &newRules)
+
+	var lines []string
+	if oldRules.Comments != newRules.Comments {
+		lines = append(lines, fmt.Sprintf("comments: %s -> %s", renderDiffValue(oldRules.Comments, oldRules.Comments != ""), renderDiffValue(newRules.Comments, newRules.Comments != "")))
+	}
+	d := rulesDiffer{}
+	if err := d.diffRule("/"+newRules.Rules.Name, &oldRules.Rules, &newRules.Rules); err != nil {
+		return nil, err
+	}
+	return append(lines, d.lines...), nil
+}
+
+type rulesDiffer struct {
+	lines []string
+}
+
+// diffRule adds the changes between the two rules at the path, and between their children
+func (d *rulesDiffer) diffRule(path string, oldRule, newRule *papi.Rules) error {
+	normalizeRules(oldRule, newRule)
+
+	oldAttrs, err := toGenericMap(oldRule)
+	if err != nil {
+		return err
+	}
+	newAttrs, err := toGenericMap(newRule)
+	if err != nil {
+		return err
+	}
+	for _, key := range []string{"behaviors", "children", "criteria", "variables"} {
+		delete(oldAttrs, key)
+		delete(newAttrs, key)
+	}
+	d.diffValues(path, oldAttrs, newAttrs)
+
+	if err := diffNamedList(d, path+"/criteria", oldRule.Criteria, newRule.Criteria); err != nil {
+		return err
+	}
+	if err := diffNamedList(d, path+"/behaviors", oldRule.Behaviors, newRule.Behaviors); err != nil {
+		return err
+	}
+	if err := diffNamedList(d, path+"/variables", oldRule.Variables, newRule.Variables); err != nil {
+		return err
+	}
+
+	oldChildren, newChildren := keyByName(oldRule.Children), keyByName(newRule.Children)
+	d.diffOrder(path+"/children", oldChildren.keys, newChildren.keys)
+	for _, key := range newChildren.keys {
+		childPath := fmt.Sprintf("%s/children[%s]", path, key)
+		oldIndex, ok := oldChildren.index[key]
+		if !ok {
+			d.lines = append(d.lines, childPath+": added")
+			continue
+		}
+		if err := d.diffRule(childPath, &oldRule.Children[oldIndex], &newRule.Children[newChildren.index[key]]); err != nil {
+			return err
+		}
+	}
+	for _, key := range oldChildren.keys {
+		if _, ok := newChildren.index[key]; !ok {
+			d.lines = append(d.lines, fmt.Sprintf("%s/children[%s]: removed", path, key))
+		}
+	}
+	return nil
+}
+
+// diffNamedList adds the changes between the two lists of behaviors, criteria or variables, matched by their names
+func diffNamedList[T papi.RuleBehavior | papi.RuleVariable](d *rulesDiffer, path string, oldList, newList []T) error {
+	oldKeys, newKeys := keyByName(oldList), keyByName(newList)
+	d.diffOrder(path, oldKeys.keys, newKeys.keys)
+	for _, key := range newKeys.keys {
+		itemPath := fmt.Sprintf("%s[%s]", path, key)
+		oldIndex, ok := oldKeys.index[key]
+		if !ok {
+			d.lines = append(d.lines, itemPath+": added")
+			continue
+		}
+		oldItem, err := toGenericMap(oldList[oldIndex])
+		if err != nil {
+			return err
+		}
+		newItem, err := toGenericMap(newList[newKeys.index[key]])
+		if err != nil {
+			return err
+		}
+		delete(oldItem, "name")
+		delete(newItem, "name")
+		d.diffValues(itemPath, oldItem, newItem)
+	}
+	for _, key := range oldKeys.keys {
+		if _, ok := newKeys.index[key]; !ok {
+			d.lines = append(d.lines, fmt.Sprintf("%s[%s]: removed", path, key))
+		}
+	}
+	return nil
+}
+
+// diffValues adds the changes between the two generic JSON values, descending into objects
+func (d *rulesDiffer) diffValues(path string, oldValue, newValue interface{}) {
+	oldMap, oldIsMap := oldValue.(map[string]interface{})
+	newMap, newIsMap := newValue.(map[string]interface{})
+	if oldIsMap && newIsMap {
+		keys := make([]string, 0, len(oldMap)+len(newMap))
+		for key := range oldMap {
+			keys = append(keys, key)
+		}
+		for key := range newMap {
+			if _, ok := oldMap[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			oldItem, oldOK := oldMap[key]
+			newItem, newOK := newMap[key]
+			if !oldOK {
+				oldItem = missingValue{}
+			}
+			if !newOK {
+				newItem = missingValue{}
+			}
+			d.diffValues(path+"."+key, oldItem, newItem)
+		}
+		return
+	}
+
+	if reflect.DeepEqual(oldValue, newValue) {
+		return
+	}
+	_, oldMissing := oldValue.(missingValue)
+	_, newMissing := newValue.(missingValue)
+	d.lines = append(d.lines, fmt.Sprintf("%s: %s -> %s", path, renderDiffValue(oldValue, !oldMissing), renderDiffValue(newValue, !newMissing)))
+}
+
+// diffOrder adds a change if the items present in both lists are in a different order
+func (d *rulesDiffer) diffOrder(path string, oldKeys, newKeys []string) {
+	common := func(keys, other []string) []string {
+		var result []string
+		for _, key := range keys {
+			for _, o := range other {
+				if key == o {
+					result = append(result, key)
+					break
+				}
+			}
+		}
+		return result
+	}
+	oldOrder, newOrder := common(oldKeys, newKeys), common(newKeys, oldKeys)
+	if !reflect.DeepEqual(oldOrder, newOrder) {
+		d.lines = append(d.lines, fmt.Sprintf("%s: order %s -> %s", path, strings.Join(oldOrder, ", "), strings.Join(newOrder, ", ")))
+	}
+}
+
+// missingValue marks an attribute missing on one side of the diff
+type missingValue struct{}
+
+// namedKeys are the keys of the items of a list, and the indexes of the items by their keys
+type namedKeys struct {
+	keys  []string
+	index map[string]int
+}
+
+// keyByName returns the keys of the items, which are their names followed by the occurrence number
+// for the names which are repeated, e.g. 'caching', 'caching#2'
+func keyByName[T papi.Rules | papi.RuleBehavior | papi.RuleVariable](items []T) namedKeys {
+	result := namedKeys{index: make(map[string]int, len(items))}
+	occurrences := make(map[string]int, len(items))
+	for i, item := range items {
+		var name string
+		switch v := any(item).(type) {
+		case papi.Rules:
+			name = v.Name
+		case papi.RuleBehavior:
+			name = v.Name
+		case papi.RuleVariable:
+			name = v.Name
+		}
+		occurrences[name]++
+		key := name
+		if occurrences[name] > 1 {
+			key = fmt.Sprintf("%s#%d", name, occurrences[name])
+		}
+		result.keys = append(result.keys, key)
+		result.index[key] = i
+	}
+	return result
+}
+
+func toGenericMap(value interface{}) (map[string]interface{}, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode rules JSON %s", err)
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal(encoded, &result); err != nil {
+		return nil, fmt.Errorf("cannot parse rules JSON %s", err)
+	}
+	return result, nil
+}
+
+// renderDiffValue renders strings as they are, and other values as JSON
+func renderDiffValue(value interface{}, set bool) string {
+	if !set {
+		return notSet
+	}
+	if s, ok := value.(string); ok {
+		if s == "" {
+			return `""`
+		}
+		return s
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(encoded)
+}
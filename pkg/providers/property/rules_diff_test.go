@@ -0,0 +1,138 @@
+package property
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v10/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v7/pkg/meta"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRulesDiff(t *testing.T) {
+	tests := map[string]struct {
+		oldRules string
+		newRules string
+		expected []string
+	}{
+		"behavior option changed in child rule": {
+			oldRules: `{"rules": {"name": "default", "children": [{"name": "Images", "behaviors": [{"name": "caching", "options": {"behavior": "MAX_AGE", "ttl": "1d"}}]}]}}`,
+			newRules: `{"rules": {"name": "default", "children": [{"name": "Images", "behaviors": [{"name": "caching", "options": {"behavior": "MAX_AGE", "ttl": "7d"}}]}]}}`,
+			expected: []string{"/default/children[Images]/behaviors[caching].options.ttl: 1d -> 7d"},
+		},
+		"no changes with differences suppressed in the plan": {
+			oldRules: `{"rules": {"name": "default", "criteriaMustSatisfy": "all", "behaviors": [{"name": "origin", "options": {"hostname": "example.com", "port": null}}],
+				"variables": [{"name": "PMUSER_B", "value": "b", "description": null, "hidden": false, "sensitive": false},
+					{"name": "PMUSER_A", "value": "a", "description": null, "hidden": false, "sensitive": false}]}}`,
+			newRules: `{"rules": {"name": "default", "behaviors": [{"name": "origin", "options": {"hostname": "example.com"}}], "criteria": [],
+				"variables": [{"name": "PMUSER_A", "value": "a", "description": null, "hidden": false, "sensitive": false},
+					{"name": "PMUSER_B", "value": "b", "description": null, "hidden": false, "sensitive": false}]}}`,
+		},
+		"rules, behaviors and options added and removed": {
+			oldRules: `{"rules": {"name": "default", "behaviors": [{"name": "origin", "options": {"hostname": "example.com"}}, {"name": "gzip", "options": {}}],
+				"children": [{"name": "Images"}, {"name": "Legacy"}]}}`,
+			newRules: `{"rules": {"name": "default", "behaviors": [{"name": "origin", "options": {"hostname": "example.com", "forwardHostHeader": "ORIGIN_HOSTNAME"}}, {"name": "http2", "options": {"enabled": true}}],
+				"children": [{"name": "Images"}, {"name": "Scripts", "behaviors": [{"name": "caching", "options": {"ttl": "1h"}}]}]}}`,
+			expected: []string{
+				"/default/behaviors[origin].options.forwardHostHeader: (not set) -> ORIGIN_HOSTNAME",
+				"/default/behaviors[http2]: added",
+				"/default/behaviors[gzip]: removed",
+				"/default/children[Scripts]: added",
+				"/default/children[Legacy]: removed",
+			},
+		},
+		"order of children and repeated behaviors": {
+			oldRules: `{"rules": {"name": "default", "children": [{"name": "Images"}, {"name": "Scripts"}],
+				"behaviors": [{"name": "modifyOutgoingResponseHeader", "options": {"customHeaderName": "X-A"}}, {"name": "modifyOutgoingResponseHeader", "options": {"customHeaderName": "X-B"}}]}}`,
+			newRules: `{"rules": {"name": "default", "children": [{"name": "Scripts"}, {"name": "Images"}],
+				"behaviors": [{"name": "modifyOutgoingResponseHeader", "options": {"customHeaderName": "X-A"}}, {"name": "modifyOutgoingResponseHeader", "options": {"customHeaderName": "X-C"}}]}}`,
+			expected: []string{
+				"/default/behaviors[modifyOutgoingResponseHeader#2].options.customHeaderName: X-B -> X-C",
+				"/default/children: order Images, Scripts -> Scripts, Images",
+			},
+		},
+		"rule attributes, criteria, variables and comments": {
+			oldRules: `{"comments": "v1", "rules": {"name": "default", "options": {"is_secure": false},
+				"variables": [{"name": "PMUSER_A", "value": "a", "description": null, "hidden": false, "sensitive": false}],
+				"children": [{"name": "API", "criteriaMustSatisfy": "all", "criteria": [{"name": "path", "options": {"values": ["/api/*"]}}]}]}}`,
+			newRules: `{"comments": "v2", "rules": {"name": "default", "options": {"is_secure": true},
+				"variables": [{"name": "PMUSER_A", "value": "b", "description": null, "hidden": false, "sensitive": true}],
+				"children": [{"name": "API", "criteriaMustSatisfy": "any", "criteria": [{"name": "path", "options": {"values": ["/api/*", "/v2/*"]}}]}]}}`,
+			expected: []string{
+				"comments: v1 -> v2",
+				"/default.options.is_secure: (not set) -> true",
+				"/default/variables[PMUSER_A].sensitive: false -> true",
+				"/default/variables[PMUSER_A].value: a -> b",
+				"/default/children[API].criteriaMustSatisfy: all -> any",
+				`/default/children[API]/criteria[path].options.values: ["/api/*"] -> ["/api/*","/v2/*"]`,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			lines, err := rulesDiff(test.oldRules, test.newRules)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, lines)
+		})
+	}
+}
+
+func TestSetRulesDiff(t *testing.T) {
+	oldRules := `{"rules":{"name":"default","behaviors":[{"name":"caching","options":{"ttl":"1d"}}]}}`
+	state := &terraform.InstanceState{
+		ID: "prp_1",
+		Attributes: map[string]string{
+			"id":           "prp_1",
+			"name":         "test",
+			"group_id":     "grp_1",
+			"contract_id":  "ctr_1",
+			"product_id":   "prd_1",
+			"rules":        oldRules,
+			"rules_diff.#": "1",
+			"rules_diff.0": "/default/behaviors[caching].options.ttl: 2h -> 1d",
+		},
+	}
+
+	tests := map[string]struct {
+		rules    string
+		expected []string
+	}{
+		"rules changed": {
+			rules:    `{"rules":{"name":"default","behaviors":[{"name":"caching","options":{"ttl":"7d"}}]}}`,
+			expected: []string{"/default/behaviors[caching].options.ttl: 1d -> 7d"},
+		},
+		"rules not changed": {
+			rules: oldRules,
+		},
+	}
+
+	providerMeta, err := meta.New(session.Must(session.New()), hclog.NewNullLogger(), "opID")
+	require.NoError(t, err)
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"name":        "test",
+				"group_id":    "grp_1",
+				"contract_id": "ctr_1",
+				"product_id":  "prd_1",
+				"rules":       test.rules,
+			})
+			diff, err := resourceProperty().Diff(context.Background(), state, config, providerMeta)
+			require.NoError(t, err)
+
+			var lines []string
+			for key, attr := range diff.Attributes {
+				if key != "rules_diff.#" && strings.HasPrefix(key, "rules_diff.") {
+					lines = append(lines, attr.New)
+				}
+			}
+			assert.Equal(t, test.expected, lines)
+		})
+	}
+}
@@ -0,0 +1,8 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+data "akamai_property_export" "test" {
+  property_id = "prp_1"
+  rules_as    = "yaml"
+}
@@ -0,0 +1,12 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+data "akamai_property_rules_upgrade" "test" {
+  property_id        = "prp_1"
+  contract_id        = "ctr_1"
+  group_id           = "grp_1"
+  rule_format        = "v2023-01-05"
+  target_rule_format = "v2015-08-17"
+  rules              = jsonencode({ rules = { name = "default" } })
+}
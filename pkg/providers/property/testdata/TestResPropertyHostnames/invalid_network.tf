@@ -0,0 +1,17 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_property_hostnames" "test" {
+  property_id = "prp_1"
+  contract_id = "ctr_1"
+  group_id    = "grp_1"
+  network     = "QA"
+
+  hostnames = [
+    {
+      cname_from = "www.example.com"
+      cname_to   = "www.example.com.edgekey.net"
+    },
+  ]
+}
@@ -0,0 +1,16 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_property_rules_patch" "test" {
+  property_id = "1"
+  contract_id = "1"
+  group_id    = "1"
+  patch = jsonencode([
+    {
+      op    = "replace"
+      path  = "/rules/children/Performance/behaviors/0/options/enabled"
+      value = true
+    },
+  ])
+}
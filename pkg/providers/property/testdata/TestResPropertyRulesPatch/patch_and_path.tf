@@ -0,0 +1,17 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_property_rules_patch" "test" {
+  property_id = "prp_1"
+  contract_id = "ctr_1"
+  group_id    = "grp_1"
+  path        = "/rules/children/Offload"
+  rule        = jsonencode({ name = "Offload" })
+  patch = jsonencode([
+    {
+      op   = "remove"
+      path = "/rules/children/Performance"
+    },
+  ])
+}
@@ -0,0 +1,19 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_property_rules_patch" "test" {
+  property_id = "prp_1"
+  contract_id = "ctr_1"
+  group_id    = "grp_1"
+  path        = "/rules/children/Offload"
+  rule = jsonencode({
+    name = "Offload"
+    behaviors = [
+      {
+        name    = "caching"
+        options = { behavior = "MAX_AGE", ttl = "1d" }
+      },
+    ]
+  })
+}
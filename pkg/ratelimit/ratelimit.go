@@ -0,0 +1,287 @@
+// Package ratelimit provides an HTTP transport limiting the rate of requests sent to Akamai APIs
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// Transport is a http.RoundTripper which limits the rate of requests sent to APIs matching
+	// the configured path prefixes, using a separate token bucket for every prefix.
+	//
+	// Besides the configured rate, the transport follows the X-RateLimit-Remaining and X-RateLimit-Next
+	// headers returned by the API: once the quota is exhausted, no further requests for the prefix are
+	// sent until the time indicated by the API, instead of being rejected with 429 Too Many Requests.
+	Transport struct {
+		// Base is the transport used to send the requests
+		Base http.RoundTripper
+
+		// PrepareRequest, if set, is called on requests which were delayed by the limiter before they are sent,
+		// e.g. to sign them again as their signature could have expired in the meantime
+		PrepareRequest func(*http.Request) error
+
+		limiters []*limiter
+	}
+
+	limiter struct {
+		prefix string
+		rate   float64
+		burst  float64
+
+		mu           sync.Mutex
+		tokens       float64
+		last         time.Time
+		blockedUntil time.Time
+		now          func() time.Time
+	}
+
+	sharedKey struct {
+		host   string
+		prefix string
+	}
+)
+
+var (
+	sharedMu sync.Mutex
+	// shared holds the limiters created by NewSharedTransport, keyed by the host and the path prefix
+	shared = make(map[sharedKey]*limiter)
+)
+
+// NewTransport returns a new Transport sending requests through base and limiting the requests whose path starts
+// with one of the limits' keys to the given number of requests per second. A limit of 0 does not restrict
+// the rate, but still follows the rate limit headers returned by the API.
+func NewTransport(base http.RoundTripper, limits map[string]int) (*Transport, error) {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	t := &Transport{Base: base}
+	for prefix, limit := range limits {
+		if err := validateLimit(prefix, limit); err != nil {
+			return nil, err
+		}
+		t.limiters = append(t.limiters, newLimiter(prefix, limit, time.Now))
+	}
+	t.sortLimiters()
+
+	return t, nil
+}
+
+// NewSharedTransport returns a new Transport like NewTransport, but its limiters are shared by all the transports
+// of the process created for the same host, so that the sessions of every configured provider, e.g. the SDK and
+// framework providers or the sessions of other accounts, draw from a single budget instead of multiplying the rate.
+// An existing limiter for the host and prefix is updated to the given limit.
+func NewSharedTransport(base http.RoundTripper, host string, limits map[string]int) (*Transport, error) {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+
+	t := &Transport{Base: base}
+	for prefix, limit := range limits {
+		if err := validateLimit(prefix, limit); err != nil {
+			return nil, err
+		}
+		key := sharedKey{host: host, prefix: prefix}
+		l, ok := shared[key]
+		if !ok {
+			l = newLimiter(prefix, limit, time.Now)
+			shared[key] = l
+		}
+		l.setLimit(limit)
+		t.limiters = append(t.limiters, l)
+	}
+	t.sortLimiters()
+
+	return t, nil
+}
+
+func validateLimit(prefix string, limit int) error {
+	if !strings.HasPrefix(prefix, "/") {
+		return fmt.Errorf("rate limit prefix %q has to start with '/'", prefix)
+	}
+	if limit < 0 {
+		return fmt.Errorf("rate limit (%d) for %q cannot be negative", limit, prefix)
+	}
+	return nil
+}
+
+// sortLimiters orders the limiters so that the most specific prefix takes precedence
+func (t *Transport) sortLimiters() {
+	sort.Slice(t.limiters, func(i, j int) bool {
+		return len(t.limiters[i].prefix) > len(t.limiters[j].prefix)
+	})
+}
+
+// ParseLimits parses the limits in the prefix=limit[,prefix=limit...] format, e.g. "/papi/=20,/appsec/=10"
+func ParseLimits(s string) (map[string]int, error) {
+	limits := make(map[string]int)
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		prefix, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid rate limit %q, expected format is prefix=limit", part)
+		}
+		limit, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate limit %q: %w", part, err)
+		}
+		limits[strings.TrimSpace(prefix)] = limit
+	}
+	return limits, nil
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	l := t.limiterFor(req.URL.Path)
+	if l == nil {
+		return t.Base.RoundTrip(req)
+	}
+
+	waited, err := l.wait(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	if waited && t.PrepareRequest != nil {
+		req = req.Clone(req.Context())
+		if err = t.PrepareRequest(req); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := t.Base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	l.update(resp)
+	return resp, nil
+}
+
+func (t *Transport) limiterFor(path string) *limiter {
+	for _, l := range t.limiters {
+		if strings.HasPrefix(path, l.prefix) {
+			return l
+		}
+	}
+	return nil
+}
+
+func newLimiter(prefix string, limit int, now func() time.Time) *limiter {
+	return &limiter{
+		prefix: prefix,
+		rate:   float64(limit),
+		burst:  float64(limit),
+		tokens: float64(limit),
+		last:   now(),
+		now:    now,
+	}
+}
+
+// setLimit changes the rate and burst of the limiter, keeping the tokens already taken
+func (l *limiter) setLimit(limit int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rate = float64(limit)
+	l.burst = float64(limit)
+	l.tokens = min(l.burst, l.tokens)
+}
+
+// reserve takes a token from the bucket and returns how long the caller has to wait before using it
+func (l *limiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	var wait time.Duration
+	if l.rate > 0 {
+		l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+		l.last = now
+		l.tokens--
+		if l.tokens < 0 {
+			wait = time.Duration(-l.tokens / l.rate * float64(time.Second))
+		}
+	}
+	if blocked := l.blockedUntil.Sub(now); blocked > wait {
+		wait = blocked
+	}
+	return wait
+}
+
+// cancel returns the token taken by reserve to the bucket
+func (l *limiter) cancel() {
+	if l.rate == 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens = min(l.burst, l.tokens+1)
+}
+
+// wait blocks until a request can be sent and returns whether it had to wait
+func (l *limiter) wait(ctx context.Context) (bool, error) {
+	wait := l.reserve()
+	if wait <= 0 {
+		return false, nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true, nil
+	case <-ctx.Done():
+		l.cancel()
+		return false, ctx.Err()
+	}
+}
+
+// update adjusts the limiter to the rate limit headers returned by the API
+func (l *limiter) update(resp *http.Response) {
+	remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	hasRemaining := err == nil
+	if !hasRemaining && resp.StatusCode != http.StatusTooManyRequests {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if hasRemaining && l.rate > 0 && float64(remaining) < l.tokens {
+		l.tokens = float64(remaining)
+	}
+	if (hasRemaining && remaining > 0) && resp.StatusCode != http.StatusTooManyRequests {
+		return
+	}
+	if next, ok := nextAllowed(resp, l.now()); ok && next.After(l.blockedUntil) {
+		l.blockedUntil = next
+	}
+}
+
+// nextAllowed returns the local time at which the API accepts requests again, based on the X-RateLimit-Next header.
+// As the header uses the server's clock, it is related to the Date header to not depend on the clock skew.
+func nextAllowed(resp *http.Response, now time.Time) (time.Time, bool) {
+	next, err := time.Parse(time.RFC3339Nano, resp.Header.Get("X-RateLimit-Next"))
+	if err != nil {
+		return time.Time{}, false
+	}
+	date, err := time.Parse(time.RFC1123, resp.Header.Get("Date"))
+	if err != nil {
+		return time.Time{}, false
+	}
+	if next.Before(date) {
+		return time.Time{}, false
+	}
+	return now.Add(next.Sub(date)), true
+}
@@ -0,0 +1,237 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTransport(t *testing.T) {
+	tests := map[string]struct {
+		limits    map[string]int
+		withError string
+	}{
+		"valid limits": {
+			limits: map[string]int{"/papi/": 10, "/appsec/": 0},
+		},
+		"no limits": {
+			limits: nil,
+		},
+		"prefix without leading slash": {
+			limits:    map[string]int{"papi": 10},
+			withError: `rate limit prefix "papi" has to start with '/'`,
+		},
+		"negative limit": {
+			limits:    map[string]int{"/papi/": -1},
+			withError: `rate limit (-1) for "/papi/" cannot be negative`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewTransport(nil, test.limits)
+			if test.withError != "" {
+				assert.EqualError(t, err, test.withError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNewSharedTransport(t *testing.T) {
+	first, err := NewSharedTransport(nil, "shared.example.com", map[string]int{"/papi/": 10})
+	require.NoError(t, err)
+	second, err := NewSharedTransport(nil, "shared.example.com", map[string]int{"/papi/": 5, "/appsec/": 1})
+	require.NoError(t, err)
+	other, err := NewSharedTransport(nil, "other.example.com", map[string]int{"/papi/": 5})
+	require.NoError(t, err)
+
+	assert.Same(t, first.limiterFor("/papi/v1/groups"), second.limiterFor("/papi/v1/groups"))
+	assert.NotSame(t, first.limiterFor("/papi/v1/groups"), other.limiterFor("/papi/v1/groups"))
+	assert.Equal(t, float64(5), first.limiterFor("/papi/v1/groups").rate)
+	assert.Nil(t, first.limiterFor("/appsec/v1/configs"))
+
+	_, err = NewSharedTransport(nil, "shared.example.com", map[string]int{"/papi/": -1})
+	assert.EqualError(t, err, `rate limit (-1) for "/papi/" cannot be negative`)
+}
+
+func TestParseLimits(t *testing.T) {
+	tests := map[string]struct {
+		given     string
+		expected  map[string]int
+		withError string
+	}{
+		"multiple limits": {
+			given:    "/papi/=20, /appsec/=10",
+			expected: map[string]int{"/papi/": 20, "/appsec/": 10},
+		},
+		"empty": {
+			given:    "",
+			expected: map[string]int{},
+		},
+		"missing limit": {
+			given:     "/papi/",
+			withError: `invalid rate limit "/papi/", expected format is prefix=limit`,
+		},
+		"invalid limit": {
+			given:     "/papi/=a",
+			withError: `invalid rate limit "/papi/=a": strconv.Atoi: parsing "a": invalid syntax`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			limits, err := ParseLimits(test.given)
+			if test.withError != "" {
+				assert.EqualError(t, err, test.withError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, limits)
+		})
+	}
+}
+
+func TestLimiterReserve(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := newLimiter("/papi/", 2, func() time.Time { return now })
+
+	assert.Equal(t, time.Duration(0), l.reserve())
+	assert.Equal(t, time.Duration(0), l.reserve())
+	assert.Equal(t, 500*time.Millisecond, l.reserve())
+	assert.Equal(t, time.Second, l.reserve())
+
+	now = now.Add(2 * time.Second)
+	assert.Equal(t, time.Duration(0), l.reserve())
+}
+
+func TestLimiterUpdate(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		status           int
+		headers          map[string]string
+		expectedTokens   float64
+		expectedBlocking time.Duration
+	}{
+		"no headers": {
+			status:         http.StatusOK,
+			expectedTokens: 10,
+		},
+		"remaining requests lower than tokens": {
+			status:         http.StatusOK,
+			headers:        map[string]string{"X-RateLimit-Remaining": "3"},
+			expectedTokens: 3,
+		},
+		"remaining requests higher than tokens": {
+			status:         http.StatusOK,
+			headers:        map[string]string{"X-RateLimit-Remaining": "30"},
+			expectedTokens: 10,
+		},
+		"quota exhausted": {
+			status: http.StatusOK,
+			headers: map[string]string{
+				"X-RateLimit-Remaining": "0",
+				"X-RateLimit-Next":      "2025-01-01T10:00:05.500Z",
+				"Date":                  "Wed, 01 Jan 2025 10:00:00 GMT",
+			},
+			expectedBlocking: 5500 * time.Millisecond,
+		},
+		"too many requests": {
+			status: http.StatusTooManyRequests,
+			headers: map[string]string{
+				"X-RateLimit-Next": "2025-01-01T10:00:02Z",
+				"Date":             "Wed, 01 Jan 2025 10:00:00 GMT",
+			},
+			expectedTokens:   10,
+			expectedBlocking: 2 * time.Second,
+		},
+		"next in the past": {
+			status: http.StatusTooManyRequests,
+			headers: map[string]string{
+				"X-RateLimit-Next": "2025-01-01T09:59:58Z",
+				"Date":             "Wed, 01 Jan 2025 10:00:00 GMT",
+			},
+			expectedTokens: 10,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			l := newLimiter("/papi/", 10, func() time.Time { return now })
+			resp := &http.Response{StatusCode: test.status, Header: http.Header{}}
+			for k, v := range test.headers {
+				resp.Header.Set(k, v)
+			}
+
+			l.update(resp)
+
+			var blocking time.Duration
+			if !l.blockedUntil.IsZero() {
+				blocking = l.blockedUntil.Sub(now)
+			}
+			assert.Equal(t, test.expectedTokens, l.tokens)
+			assert.Equal(t, test.expectedBlocking, blocking)
+		})
+	}
+}
+
+func TestTransport(t *testing.T) {
+	var papiCalls, otherCalls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/papi/v1/groups" {
+			papiCalls.Add(1)
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("Date", time.Now().UTC().Format(time.RFC1123))
+			w.Header().Set("X-RateLimit-Next", time.Now().UTC().Add(time.Second).Format(time.RFC3339Nano))
+		} else {
+			otherCalls.Add(1)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	transport, err := NewTransport(nil, map[string]int{"/papi/": 0})
+	require.NoError(t, err)
+	var prepared atomic.Int32
+	transport.PrepareRequest = func(_ *http.Request) error {
+		prepared.Add(1)
+		return nil
+	}
+	client := &http.Client{Transport: transport}
+
+	resp, err := client.Get(srv.URL + "/papi/v1/groups")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	// requests to other APIs are not affected
+	start := time.Now()
+	resp, err = client.Get(srv.URL + "/appsec/v1/configs")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+
+	// the request is cancelled while waiting for the quota to be renewed
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/papi/v1/groups", nil)
+	require.NoError(t, err)
+	_, err = client.Do(req)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// the request is delayed until the quota is renewed
+	resp, err = client.Get(srv.URL + "/papi/v1/groups")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	assert.Equal(t, int32(2), papiCalls.Load())
+	assert.Equal(t, int32(1), otherCalls.Load())
+	assert.Equal(t, int32(1), prepared.Load())
+}
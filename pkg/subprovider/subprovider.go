@@ -3,6 +3,8 @@ package subprovider
 
 import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -20,4 +22,10 @@ type Subprovider interface {
 
 	// FrameworkDataSources returns the data sources implemented using terraform-plugin-framework
 	FrameworkDataSources() []func() datasource.DataSource
+
+	// EphemeralResources returns the ephemeral resources implemented using terraform-plugin-framework
+	EphemeralResources() []func() ephemeral.EphemeralResource
+
+	// Functions returns the provider-defined functions implemented using terraform-plugin-framework
+	Functions() []func() function.Function
 }